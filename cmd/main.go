@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"time"
 
 	server "github.com/tdatIT/backend-go/internal"
 )
 
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	service, err := server.InitServer()
 	if err != nil {
@@ -18,4 +22,10 @@ func main() {
 		slog.Error("failed to start HTTP server", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := service.Shutdown(ctx); err != nil {
+		slog.Error("failed to finish background work", slog.String("error", err.Error()))
+	}
 }
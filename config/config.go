@@ -16,6 +16,8 @@ type ServiceConfig struct {
 	Redis    Redis
 	Logger   Logger
 	Auth     Auth
+	Mail     Mail
+	Notifier Notifier
 }
 
 type Server struct {
@@ -29,6 +31,9 @@ type Server struct {
 	DefaultTimeout    time.Duration
 	HttpClientTimeout time.Duration
 	DebugMode         bool
+	// TrustedProxies lists the CIDRs allowed to set X-Forwarded-For. When it
+	// is empty the client IP is the address of the TCP peer.
+	TrustedProxies []string
 }
 
 type Database struct {
@@ -69,10 +74,139 @@ type Logger struct {
 }
 
 type Auth struct {
-	JWTSecret       string
-	AccessTokenTTL  time.Duration
-	RefreshTokenTTL time.Duration
-	GoogleClientID  string
+	JWTSecret                 string
+	AccessTokenTTL            time.Duration
+	RefreshTokenTTL           time.Duration
+	GoogleClientID            string
+	SigningKeys               []SigningKey
+	AcceptLegacyHS256         bool
+	KeyRotationCheckInterval  time.Duration
+	RetiredKeyTTL             time.Duration
+	PasswordResetTTL          time.Duration
+	PasswordResetURL          string
+	RequireVerifiedEmail      bool
+	EmailVerificationTTL      time.Duration
+	EmailVerificationURL      string
+	VerificationResendDelay   time.Duration
+	MFAIssuer                 string
+	MFAEncryptionKey          string
+	MFAChallengeTTL           time.Duration
+	LoginMaxAttempts          int
+	LoginIPMaxAttempts        int
+	LoginFailureWindow        time.Duration
+	LoginBackoffBase          time.Duration
+	LoginLockoutDuration      time.Duration
+	OIDCProviders             []OIDCProvider
+	OIDCLinkByEmail           []string
+	PasswordPolicy            PasswordPolicy
+	PasswordHash              PasswordHash
+	IntrospectionClients      []IntrospectionClient
+	TokenCacheTTL             time.Duration
+	RBACCacheTTL              time.Duration
+	PersonalAccessTokenMaxTTL time.Duration
+	Roles                     []RoleSeed
+	OAuthConsentURL           string
+	OAuthCodeTTL              time.Duration
+	OAuthClients              []OAuthClient
+	CookieSession             CookieSession
+	GeoIPDatabase             string // MaxMind-format .mmdb City or Country database; empty disables
+	LoginAlerts               bool
+}
+
+// RoleSeed is created or updated at startup. Its permissions replace the ones
+// stored for the role; UserIDs lists the accounts that are given the role.
+type RoleSeed struct {
+	Name        string
+	Description string
+	Permissions []string
+	UserIDs     []uint64
+}
+
+// IntrospectionClient is a service allowed to call /auth/introspect with
+// HTTP Basic or client_secret_post credentials.
+type IntrospectionClient struct {
+	ID     string
+	Secret string
+}
+
+// OAuthClient is registered at startup for the authorization code flow.
+// Clients without a secret are public and must use PKCE alone; Scopes are the
+// permissions the client may request.
+type OAuthClient struct {
+	ID           string
+	Name         string
+	Secret       string
+	RedirectURIs []string
+	Scopes       []string
+	FirstParty   bool
+}
+
+// CookieSession lets browser clients keep the refresh token in an HttpOnly
+// cookie scoped to /api/v1/auth. Clients opt in per request with the
+// "X-Session-Mode: cookie" header; unsafe requests that carry the cookie must
+// echo the CSRF cookie in CSRFHeader.
+type CookieSession struct {
+	Enabled           bool
+	RefreshCookieName string
+	CSRFCookieName    string
+	CSRFHeaderName    string
+	Domain            string
+	SameSite          string // strict | lax | none
+}
+
+// PasswordPolicy applies to passwords set at registration, reset and change.
+// A common-password blocklist and a username/email check always apply.
+type PasswordPolicy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	BlocklistFile string
+}
+
+// PasswordHash selects the algorithm for new hashes. Hashes made with another
+// algorithm or other parameters are upgraded on the next successful login.
+type PasswordHash struct {
+	Algorithm         string // argon2id | bcrypt
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	BcryptCost        int
+	MaxConcurrent     int // hashes computed at once; defaults to the CPU count
+}
+
+// OIDCProvider is an OpenID Connect issuer whose ID tokens are accepted at
+// /auth/oidc/:name. Tokens must carry one of ClientIDs or Audiences as aud.
+type OIDCProvider struct {
+	Name      string
+	Issuer    string
+	ClientIDs []string
+	Audiences []string
+}
+
+type SigningKey struct {
+	KID            string
+	Algorithm      string // RS256 | ES256 | EdDSA
+	PrivateKeyFile string
+	ActivateAt     string // RFC3339, empty means active immediately
+}
+
+type Mail struct {
+	Driver  string // log | file | smtp
+	From    string
+	FileDir string
+	SMTP    struct {
+		Host     string
+		Port     int
+		Username string
+		Password string
+	}
+}
+
+type Notifier struct {
+	Driver string // log | mail
 }
 
 type TelegramBot struct {
@@ -3,11 +3,24 @@ package auth
 import (
 	"github.com/tdatIT/backend-go/config"
 	"github.com/tdatIT/backend-go/internal/application/auth/command"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/application/auth/query"
+	"github.com/tdatIT/backend-go/internal/infras/device"
 	"github.com/tdatIT/backend-go/internal/infras/httpclient/oidc"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/notifier"
+	"github.com/tdatIT/backend-go/internal/infras/repository/accesstoken"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"github.com/tdatIT/backend-go/internal/infras/repository/role"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
 	"github.com/tdatIT/backend-go/internal/infras/repository/session"
 	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
 	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/background"
+	"github.com/tdatIT/backend-go/pkgs/cache"
 )
 
 type queries struct {
@@ -15,37 +28,163 @@ type queries struct {
 	LoginByGoogle              query.ILoginByGoogleQuery
 	RefreshToken               query.IRefreshTokenQuery
 	VerifyToken                query.IVerifyTokenQuery
+	ListSessions               query.IListSessionsQuery
+	GetJWKS                    query.IGetJWKSQuery
+	VerifyMFA                  query.IVerifyMFAQuery
+	LoginByOIDC                query.ILoginByOIDCQuery
+	ListIdentities             query.IListIdentitiesQuery
+	IntrospectToken            query.IIntrospectTokenQuery
+	ForwardAuth                query.IForwardAuthQuery
+	ListRoles                  query.IListRolesQuery
+	ListUserRoles              query.IListUserRolesQuery
+	ListAccessTokens           query.IListPersonalAccessTokensQuery
+	VerifyAccessToken          query.IVerifyPersonalAccessTokenQuery
+	GetAuthorization           query.IGetAuthorizationQuery
+	OAuthToken                 query.IOAuthTokenQuery
+	ListConsents               query.IListConsentsQuery
+	ListUsers                  query.IListUsersQuery
+	GetUser                    query.IGetUserQuery
 }
 
 type commands struct {
-	Register command.IRegisterCommand
-	Logout   command.ILogoutCommand
+	Register                command.IRegisterCommand
+	Logout                  command.ILogoutCommand
+	RevokeSession           command.IRevokeSessionCommand
+	RevokeOtherSessions     command.IRevokeOtherSessionsCommand
+	RequestPasswordReset    command.IRequestPasswordResetCommand
+	ConfirmPasswordReset    command.IConfirmPasswordResetCommand
+	VerifyEmail             command.IVerifyEmailCommand
+	ResendEmailVerification command.IResendEmailVerificationCommand
+	ChangeEmail             command.IChangeEmailCommand
+	EnrollTOTP              command.IEnrollTOTPCommand
+	ConfirmTOTP             command.IConfirmTOTPCommand
+	DisableTOTP             command.IDisableTOTPCommand
+	IssueOIDCNonce          command.IIssueOIDCNonceCommand
+	LinkIdentity            command.ILinkIdentityCommand
+	UnlinkIdentity          command.IUnlinkIdentityCommand
+	ChangePassword          command.IChangePasswordCommand
+	AssignRole              command.IAssignRoleCommand
+	RevokeRole              command.IRevokeRoleCommand
+	CreateAccessToken       command.ICreatePersonalAccessTokenCommand
+	RevokeAccessToken       command.IRevokePersonalAccessTokenCommand
+	Authorize               command.IAuthorizeCommand
+	RevokeConsent           command.IRevokeConsentCommand
+	SuspendUser             command.ISuspendUserCommand
+	ReactivateUser          command.IReactivateUserCommand
+	DeleteUser              command.IDeleteUserCommand
+	UpdateUser              command.IUpdateUserCommand
+	ForceLogout             command.IForceLogoutCommand
+	SendPasswordReset       command.ISendPasswordResetCommand
 }
 
 type Application struct {
-	Queries  *queries
-	Commands *commands
+	Queries    *queries
+	Commands   *commands
+	Authorizer helper.Authorizer
 }
 
 func NewApplication(
 	config *config.ServiceConfig,
 	userRepo user.Repository,
 	sessionRepo session.Repository,
+	securityEventRepo securityevent.Repository,
+	userTokenRepo usertoken.Repository,
+	mfaRepo mfa.Repository,
+	identityRepo identity.Repository,
+	roleRepo role.Repository,
+	accessTokenRepo accesstoken.Repository,
+	oauthRepo oauth.Repository,
 	tokenManager security.TokenManager,
+	mailer mailer.Mailer,
+	notifier notifier.Notifier,
+	locator device.Locator,
+	cacheClient cache.Cache,
+	runner *background.Runner,
 ) *Application {
 	// Initialize essential components
 	googleOIDC := oidc.NewGoogleOIDCProvider(config)
+	oidcProviders := oidc.NewProviders(config)
+
+	mfaBox := security.NewSecretBox(config.Auth.MFAEncryptionKey)
+
+	hasher := security.NewPasswordHasher(security.PasswordHashConfig{
+		Algorithm: config.Auth.PasswordHash.Algorithm,
+		Argon2: security.Argon2Params{
+			Memory:      config.Auth.PasswordHash.Argon2Memory,
+			Iterations:  config.Auth.PasswordHash.Argon2Iterations,
+			Parallelism: config.Auth.PasswordHash.Argon2Parallelism,
+		},
+		BcryptCost:    config.Auth.PasswordHash.BcryptCost,
+		MaxConcurrent: config.Auth.PasswordHash.MaxConcurrent,
+	})
+	policy := security.NewPasswordPolicy(security.PasswordPolicyConfig{
+		MinLength:     config.Auth.PasswordPolicy.MinLength,
+		MaxLength:     config.Auth.PasswordPolicy.MaxLength,
+		RequireUpper:  config.Auth.PasswordPolicy.RequireUpper,
+		RequireLower:  config.Auth.PasswordPolicy.RequireLower,
+		RequireDigit:  config.Auth.PasswordPolicy.RequireDigit,
+		RequireSymbol: config.Auth.PasswordPolicy.RequireSymbol,
+		BlocklistFile: config.Auth.PasswordPolicy.BlocklistFile,
+	})
+
+	verifyToken := query.NewVerifyTokenQuery(userRepo, sessionRepo, tokenManager, cacheClient)
+	authorizer := helper.NewAuthorizer(config, roleRepo, cacheClient)
+	monitor := helper.NewLoginMonitor(config, sessionRepo, locator, notifier, runner)
+	refreshToken := query.NewRefreshTokenQuery(userRepo, sessionRepo, securityEventRepo, tokenManager, authorizer)
 
 	return &Application{
 		Queries: &queries{
-			LoginByUsernameAndPassword: query.NewLoginByUsrnameAndPwdQuery(userRepo, sessionRepo, tokenManager),
-			LoginByGoogle:              query.NewLoginByGoogleQuery(userRepo, sessionRepo, tokenManager, googleOIDC, config),
-			RefreshToken:               query.NewRefreshTokenQuery(sessionRepo, tokenManager),
-			VerifyToken:                query.NewVerifyTokenQuery(sessionRepo, tokenManager),
+			LoginByUsernameAndPassword: query.NewLoginByUsrnameAndPwdQuery(config, userRepo, sessionRepo, mfaRepo, securityEventRepo, tokenManager, authorizer, monitor, cacheClient, hasher),
+			LoginByGoogle:              query.NewLoginByGoogleQuery(config, userRepo, identityRepo, sessionRepo, tokenManager, authorizer, monitor, googleOIDC, cacheClient),
+			RefreshToken:               refreshToken,
+			VerifyToken:                verifyToken,
+			ListSessions:               query.NewListSessionsQuery(sessionRepo),
+			GetJWKS:                    query.NewGetJWKSQuery(tokenManager),
+			VerifyMFA:                  query.NewVerifyMFAQuery(config, userRepo, sessionRepo, mfaRepo, tokenManager, authorizer, monitor, cacheClient, mfaBox),
+			LoginByOIDC:                query.NewLoginByOIDCQuery(config, userRepo, identityRepo, sessionRepo, tokenManager, authorizer, monitor, oidcProviders, cacheClient),
+			ListIdentities:             query.NewListIdentitiesQuery(userRepo, identityRepo),
+			IntrospectToken:            query.NewIntrospectTokenQuery(config, cacheClient, verifyToken),
+			ForwardAuth:                query.NewForwardAuthQuery(config, cacheClient, verifyToken),
+			ListRoles:                  query.NewListRolesQuery(roleRepo),
+			ListUserRoles:              query.NewListUserRolesQuery(userRepo, authorizer),
+			ListAccessTokens:           query.NewListPersonalAccessTokensQuery(accessTokenRepo),
+			VerifyAccessToken:          query.NewVerifyPersonalAccessTokenQuery(userRepo, accessTokenRepo, authorizer),
+			GetAuthorization:           query.NewGetAuthorizationQuery(config, oauthRepo),
+			OAuthToken:                 query.NewOAuthTokenQuery(oauthRepo, userRepo, sessionRepo, tokenManager, authorizer, monitor, refreshToken),
+			ListConsents:               query.NewListConsentsQuery(oauthRepo),
+			ListUsers:                  query.NewListUsersQuery(userRepo),
+			GetUser:                    query.NewGetUserQuery(userRepo, identityRepo, sessionRepo),
 		},
 		Commands: &commands{
-			Register: command.NewRegisterCommand(userRepo, sessionRepo, tokenManager),
-			Logout:   command.NewLogoutCommand(sessionRepo, tokenManager),
+			Register:                command.NewRegisterCommand(config, userRepo, sessionRepo, userTokenRepo, tokenManager, monitor, mailer, hasher, policy),
+			Logout:                  command.NewLogoutCommand(sessionRepo, tokenManager),
+			RevokeSession:           command.NewRevokeSessionCommand(sessionRepo),
+			RevokeOtherSessions:     command.NewRevokeOtherSessionsCommand(sessionRepo),
+			RequestPasswordReset:    command.NewRequestPasswordResetCommand(config, userRepo, userTokenRepo, mailer, runner),
+			ConfirmPasswordReset:    command.NewConfirmPasswordResetCommand(config, userRepo, sessionRepo, userTokenRepo, cacheClient, hasher, policy),
+			VerifyEmail:             command.NewVerifyEmailCommand(userRepo, userTokenRepo),
+			ResendEmailVerification: command.NewResendEmailVerificationCommand(config, userRepo, userTokenRepo, cacheClient, mailer),
+			ChangeEmail:             command.NewChangeEmailCommand(config, userRepo, userTokenRepo, mailer, cacheClient, hasher),
+			EnrollTOTP:              command.NewEnrollTOTPCommand(config, userRepo, mfaRepo, mfaBox),
+			ConfirmTOTP:             command.NewConfirmTOTPCommand(mfaRepo, mfaBox),
+			DisableTOTP:             command.NewDisableTOTPCommand(mfaRepo, mfaBox),
+			IssueOIDCNonce:          command.NewIssueOIDCNonceCommand(cacheClient),
+			LinkIdentity:            command.NewLinkIdentityCommand(identityRepo, oidcProviders, cacheClient),
+			UnlinkIdentity:          command.NewUnlinkIdentityCommand(identityRepo),
+			ChangePassword:          command.NewChangePasswordCommand(config, userRepo, sessionRepo, securityEventRepo, tokenManager, authorizer, cacheClient, hasher, policy),
+			AssignRole:              command.NewAssignRoleCommand(userRepo, roleRepo, authorizer),
+			RevokeRole:              command.NewRevokeRoleCommand(roleRepo, authorizer),
+			CreateAccessToken:       command.NewCreatePersonalAccessTokenCommand(config, accessTokenRepo, authorizer),
+			RevokeAccessToken:       command.NewRevokePersonalAccessTokenCommand(accessTokenRepo),
+			Authorize:               command.NewAuthorizeCommand(config, oauthRepo),
+			RevokeConsent:           command.NewRevokeConsentCommand(oauthRepo, sessionRepo),
+			SuspendUser:             command.NewSuspendUserCommand(config, userRepo, sessionRepo, securityEventRepo, cacheClient),
+			ReactivateUser:          command.NewReactivateUserCommand(config, userRepo, securityEventRepo, cacheClient),
+			DeleteUser:              command.NewDeleteUserCommand(config, userRepo, sessionRepo, securityEventRepo, cacheClient),
+			UpdateUser:              command.NewUpdateUserCommand(userRepo),
+			ForceLogout:             command.NewForceLogoutCommand(userRepo, sessionRepo, securityEventRepo),
+			SendPasswordReset:       command.NewSendPasswordResetCommand(config, userRepo, userTokenRepo, securityEventRepo, mailer),
 		},
+		Authorizer: authorizer,
 	}
 }
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"gorm.io/gorm"
+)
+
+// accountStatusChanger applies an admin's status change to another account
+// and keeps an audit trail of it.
+type accountStatusChanger struct {
+	userRepo          user.Repository
+	sessionRepo       session.Repository
+	securityEventRepo securityevent.Repository
+	cache             cache.Cache
+	states            helper.AccountStates
+	tokenCacheTTL     time.Duration
+}
+
+// load returns the acting admin and the target account. Deleted accounts are
+// reported as missing so they cannot be changed again.
+func (a accountStatusChanger) load(ctx context.Context, userID uint64) (*principal.Principal, *models.User, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, nil, helper.ErrInvalidToken
+	}
+	if caller.UserID == userID {
+		return nil, nil, helper.ErrCannotChangeOwnStatus
+	}
+
+	account, err := a.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, helper.ErrUserNotFound
+		}
+		slog.Error("failed to find user for status change",
+			slog.Uint64("user_id", userID),
+			slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+	if account.IsDeleted() {
+		return nil, nil, helper.ErrUserNotFound
+	}
+
+	return caller, account, nil
+}
+
+// save stores the status change made by actor, updates the cached account
+// state, drops cached verifications of the account's tokens and records the
+// change as a security event. Failing to record the event does not undo the
+// change.
+func (a accountStatusChanger) save(ctx context.Context, account *models.User, actor *principal.Principal, eventType string, reason string) error {
+	account.StatusReason = reason
+	account.StatusChangedBy = &actor.UserID
+	account.StatusChangedAt = new(time.Now())
+	if err := a.userRepo.UpdateStatus(ctx, account); err != nil {
+		slog.Error("failed to update account status",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := a.states.Store(ctx, account); err != nil {
+		slog.Error("failed to cache account state",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := a.cache.Set(ctx, helper.StaleTokensKey(account.ID), 1, a.tokenCacheTTL); err != nil {
+		slog.Error("failed to expire cached tokens of account",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := a.securityEventRepo.Create(ctx, &models.SecurityEvent{
+		UserID:  account.ID,
+		ActorID: &actor.UserID,
+		Type:    eventType,
+		Details: reason,
+	}); err != nil {
+		slog.Error("failed to record account status event",
+			slog.Uint64("user_id", account.ID),
+			slog.String("type", eventType),
+			slog.String("error", err.Error()))
+	}
+
+	slog.Info("account status changed",
+		slog.Uint64("user_id", account.ID),
+		slog.Uint64("actor_id", actor.UserID),
+		slog.String("type", eventType))
+	return nil
+}
+
+// signOut deactivates every session of the account.
+func (a accountStatusChanger) signOut(ctx context.Context, userID uint64) error {
+	if _, err := a.sessionRepo.DeactivateAllByUserID(ctx, userID, ""); err != nil {
+		slog.Error("failed to deactivate sessions of disabled account",
+			slog.Uint64("user_id", userID),
+			slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
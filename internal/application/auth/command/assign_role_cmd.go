@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/role"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IAssignRoleCommand decorator.CommandHandler[*userdto.AssignRoleReq]
+
+type assignRoleCommand struct {
+	userRepo   user.Repository
+	roleRepo   role.Repository
+	authorizer helper.Authorizer
+}
+
+func NewAssignRoleCommand(userRepo user.Repository, roleRepo role.Repository, authorizer helper.Authorizer) IAssignRoleCommand {
+	return &assignRoleCommand{
+		userRepo:   userRepo,
+		roleRepo:   roleRepo,
+		authorizer: authorizer,
+	}
+}
+
+// Handle is idempotent: assigning a role the user already has succeeds.
+func (a assignRoleCommand) Handle(ctx context.Context, req *userdto.AssignRoleReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	if _, err := a.userRepo.FindByID(ctx, req.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrUserNotFound
+		}
+		slog.Error("failed to find user for role assignment",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	roleItem, err := a.roleRepo.FindByName(ctx, req.Role)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrRoleNotFound
+		}
+		slog.Error("failed to find role",
+			slog.String("role", req.Role),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	created, err := a.roleRepo.Assign(ctx, &models.UserRole{
+		UserID:     req.UserID,
+		RoleID:     roleItem.ID,
+		AssignedBy: &caller.UserID,
+	})
+	if err != nil {
+		slog.Error("failed to assign role",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("role", req.Role),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if created {
+		a.authorizer.Invalidate(ctx, req.UserID)
+		slog.Info("role assigned",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("role", req.Role),
+			slog.Uint64("assigned_by", caller.UserID))
+	}
+
+	return nil
+}
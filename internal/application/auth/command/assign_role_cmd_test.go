@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestAssignRoleCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2}, nil)
+	roleRepo.On("FindByName", mock.Anything, "editor").Return(&models.Role{ID: 4, Name: "editor"}, nil)
+	roleRepo.On("Assign", mock.Anything, mock.MatchedBy(func(item *models.UserRole) bool {
+		return item.UserID == 2 && item.RoleID == 4 && item.AssignedBy != nil && *item.AssignedBy == 1
+	})).Return(true, nil)
+	authorizer.On("Invalidate", mock.Anything, uint64(2)).Return()
+
+	cmd := NewAssignRoleCommand(userRepo, roleRepo, authorizer)
+	err := cmd.Handle(ctx, &userdto.AssignRoleReq{UserID: 2, Role: "editor"})
+
+	require.NoError(t, err)
+	roleRepo.AssertExpectations(t)
+	authorizer.AssertExpectations(t)
+}
+
+func TestAssignRoleCommand_Handle_AlreadyAssigned(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2}, nil)
+	roleRepo.On("FindByName", mock.Anything, "editor").Return(&models.Role{ID: 4, Name: "editor"}, nil)
+	roleRepo.On("Assign", mock.Anything, mock.Anything).Return(false, nil)
+
+	cmd := NewAssignRoleCommand(userRepo, roleRepo, authorizer)
+	err := cmd.Handle(ctx, &userdto.AssignRoleReq{UserID: 2, Role: "editor"})
+
+	require.NoError(t, err)
+	authorizer.AssertNotCalled(t, "Invalidate", mock.Anything, mock.Anything)
+}
+
+func TestAssignRoleCommand_Handle_UnknownRole(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2}, nil)
+	roleRepo.On("FindByName", mock.Anything, "ghost").Return((*models.Role)(nil), gorm.ErrRecordNotFound)
+
+	cmd := NewAssignRoleCommand(userRepo, roleRepo, new(mocks.MockAuthorizer))
+	err := cmd.Handle(ctx, &userdto.AssignRoleReq{UserID: 2, Role: "ghost"})
+
+	require.ErrorIs(t, err, helper.ErrRoleNotFound)
+	roleRepo.AssertNotCalled(t, "Assign", mock.Anything, mock.Anything)
+}
+
+func TestAssignRoleCommand_Handle_UnknownUser(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(9)).Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	cmd := NewAssignRoleCommand(userRepo, new(mocks.MockRoleRepository), new(mocks.MockAuthorizer))
+	err := cmd.Handle(ctx, &userdto.AssignRoleReq{UserID: 9, Role: "editor"})
+
+	require.ErrorIs(t, err, helper.ErrUserNotFound)
+}
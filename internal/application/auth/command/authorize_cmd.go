@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+const defaultOAuthCodeTTL = time.Minute
+
+type IAuthorizeCommand decorator.CommandReturnHandler[*userdto.AuthorizeDecisionReq, *userdto.AuthorizeDecisionRes]
+
+type authorizeCommand struct {
+	oauthRepo oauth.Repository
+	codeTTL   time.Duration
+}
+
+func NewAuthorizeCommand(config *config.ServiceConfig, oauthRepo oauth.Repository) IAuthorizeCommand {
+	codeTTL := config.Auth.OAuthCodeTTL
+	if codeTTL <= 0 {
+		codeTTL = defaultOAuthCodeTTL
+	}
+
+	return &authorizeCommand{
+		oauthRepo: oauthRepo,
+		codeTTL:   codeTTL,
+	}
+}
+
+// Handle issues a code right away for first-party clients and for scopes the
+// user already consented to. Otherwise it reports that consent is required
+// until the request comes back with an explicit decision.
+func (a authorizeCommand) Handle(ctx context.Context, req *userdto.AuthorizeDecisionReq) (*userdto.AuthorizeDecisionRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	client, scopes, err := helper.ValidateAuthorizeRequest(ctx, a.oauthRepo, &req.AuthorizeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	consented := client.FirstParty
+	if !consented {
+		consent, err := a.oauthRepo.FindConsent(ctx, caller.UserID, client.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Error("failed to find oauth consent",
+				slog.Uint64("user_id", caller.UserID),
+				slog.String("client_id", client.ID),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+		consented = consent != nil && helper.ScopesCovered(consent.Scopes, scopes)
+	}
+
+	if !consented {
+		if req.Approve == nil {
+			return &userdto.AuthorizeDecisionRes{
+				ConsentRequired: true,
+				Client: &userdto.OAuthClientRes{
+					ID:         client.ID,
+					Name:       client.Name,
+					FirstParty: client.FirstParty,
+				},
+				Scopes: scopes,
+			}, nil
+		}
+
+		if !*req.Approve {
+			slog.Info("user denied oauth authorization",
+				slog.Uint64("user_id", caller.UserID),
+				slog.String("client_id", client.ID))
+			return &userdto.AuthorizeDecisionRes{
+				RedirectTo: helper.AuthorizeDeniedRedirect(req.RedirectURI, req.State),
+			}, nil
+		}
+
+		if err := a.oauthRepo.SaveConsent(ctx, &models.OAuthConsent{
+			UserID:   caller.UserID,
+			ClientID: client.ID,
+			Scopes:   scopes,
+		}); err != nil {
+			slog.Error("failed to save oauth consent",
+				slog.Uint64("user_id", caller.UserID),
+				slog.String("client_id", client.ID),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	code, codeHash, err := security.GenerateOpaqueToken()
+	if err != nil {
+		slog.Error("failed to generate authorization code", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := a.oauthRepo.CreateCode(ctx, &models.OAuthAuthorizationCode{
+		CodeHash:            codeHash,
+		ClientID:            client.ID,
+		UserID:              caller.UserID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               strings.Join(scopes, " "),
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		ExpiresAt:           time.Now().Add(a.codeTTL),
+	}); err != nil {
+		slog.Error("failed to create authorization code",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("client_id", client.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.AuthorizeDecisionRes{
+		RedirectTo: helper.AuthorizeRedirect(req.RedirectURI, req.State, url.Values{"code": {code}}),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func testAuthorizeDecisionReq(approve *bool) *userdto.AuthorizeDecisionReq {
+	return &userdto.AuthorizeDecisionReq{
+		AuthorizeReq: userdto.AuthorizeReq{
+			ResponseType:        helper.OAuthResponseTypeCode,
+			ClientID:            "partner",
+			Scope:               "tasks:read",
+			State:               "xyz",
+			CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+			CodeChallengeMethod: helper.OAuthCodeChallengeMethodS256,
+		},
+		Approve: approve,
+	}
+}
+
+func testPartnerClient() *models.OAuthClient {
+	return &models.OAuthClient{
+		ID:           "partner",
+		Name:         "Partner",
+		RedirectURIs: []string{"https://partner.example.com/cb"},
+		Scopes:       []string{"tasks:read"},
+	}
+}
+
+func TestAuthorizeCommand_Handle_ConsentRequired(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(testPartnerClient(), nil)
+	oauthRepo.On("FindConsent", mock.Anything, uint64(1), "partner").Return(nil, gorm.ErrRecordNotFound)
+
+	cmd := NewAuthorizeCommand(&config.ServiceConfig{}, oauthRepo)
+	res, err := cmd.Handle(ctx, testAuthorizeDecisionReq(nil))
+
+	require.NoError(t, err)
+	require.True(t, res.ConsentRequired)
+	require.Equal(t, "Partner", res.Client.Name)
+	require.Equal(t, []string{"tasks:read"}, res.Scopes)
+	require.Empty(t, res.RedirectTo)
+	oauthRepo.AssertNotCalled(t, "CreateCode", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizeCommand_Handle_ApproveSavesConsentAndIssuesCode(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(testPartnerClient(), nil)
+	oauthRepo.On("FindConsent", mock.Anything, uint64(1), "partner").Return(nil, gorm.ErrRecordNotFound)
+	oauthRepo.On("SaveConsent", mock.Anything, mock.MatchedBy(func(item *models.OAuthConsent) bool {
+		return item.UserID == 1 && item.ClientID == "partner"
+	})).Return(nil)
+	oauthRepo.On("CreateCode", mock.Anything, mock.MatchedBy(func(item *models.OAuthAuthorizationCode) bool {
+		return item.UserID == 1 && item.ClientID == "partner" && item.Scope == "tasks:read" &&
+			item.RedirectURI == "https://partner.example.com/cb" && item.CodeHash != ""
+	})).Return(nil)
+
+	cmd := NewAuthorizeCommand(&config.ServiceConfig{}, oauthRepo)
+	res, err := cmd.Handle(ctx, testAuthorizeDecisionReq(new(true)))
+
+	require.NoError(t, err)
+	target, err := url.Parse(res.RedirectTo)
+	require.NoError(t, err)
+	require.Equal(t, "partner.example.com", target.Host)
+	require.NotEmpty(t, target.Query().Get("code"))
+	require.Equal(t, "xyz", target.Query().Get("state"))
+	oauthRepo.AssertExpectations(t)
+}
+
+func TestAuthorizeCommand_Handle_ExistingConsentSkipsPrompt(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(testPartnerClient(), nil)
+	oauthRepo.On("FindConsent", mock.Anything, uint64(1), "partner").
+		Return(&models.OAuthConsent{UserID: 1, ClientID: "partner", Scopes: []string{"tasks:read"}}, nil)
+	oauthRepo.On("CreateCode", mock.Anything, mock.Anything).Return(nil)
+
+	cmd := NewAuthorizeCommand(&config.ServiceConfig{}, oauthRepo)
+	res, err := cmd.Handle(ctx, testAuthorizeDecisionReq(nil))
+
+	require.NoError(t, err)
+	require.False(t, res.ConsentRequired)
+	require.Contains(t, res.RedirectTo, "code=")
+	oauthRepo.AssertNotCalled(t, "SaveConsent", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizeCommand_Handle_Denied(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(testPartnerClient(), nil)
+	oauthRepo.On("FindConsent", mock.Anything, uint64(1), "partner").Return(nil, gorm.ErrRecordNotFound)
+
+	cmd := NewAuthorizeCommand(&config.ServiceConfig{}, oauthRepo)
+	res, err := cmd.Handle(ctx, testAuthorizeDecisionReq(new(false)))
+
+	require.NoError(t, err)
+	target, err := url.Parse(res.RedirectTo)
+	require.NoError(t, err)
+	require.Equal(t, "access_denied", target.Query().Get("error"))
+	require.Equal(t, "xyz", target.Query().Get("state"))
+	oauthRepo.AssertNotCalled(t, "CreateCode", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizeCommand_Handle_InvalidRedirectURI(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(testPartnerClient(), nil)
+
+	req := testAuthorizeDecisionReq(new(true))
+	req.RedirectURI = "https://evil.example.com/cb"
+
+	cmd := NewAuthorizeCommand(&config.ServiceConfig{}, oauthRepo)
+	res, err := cmd.Handle(ctx, req)
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrOAuthInvalidRedirectURI)
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IChangeEmailCommand decorator.CommandHandler[*userdto.ChangeEmailReq]
+
+type changeEmailCommand struct {
+	userRepo user.Repository
+	mailer   mailer.Mailer
+	hasher   *security.PasswordHasher
+	throttle helper.LoginThrottle
+	issuer   emailVerificationIssuer
+}
+
+func NewChangeEmailCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	userTokenRepo usertoken.Repository,
+	mailer mailer.Mailer,
+	cacheClient cache.Cache,
+	hasher *security.PasswordHasher,
+) IChangeEmailCommand {
+	return &changeEmailCommand{
+		userRepo: userRepo,
+		mailer:   mailer,
+		hasher:   hasher,
+		throttle: helper.NewLoginThrottle(config, cacheClient),
+		issuer: emailVerificationIssuer{
+			config:        config,
+			userTokenRepo: userTokenRepo,
+			mailer:        mailer,
+		},
+	}
+}
+
+// Handle sends a verification link to the new address. The account keeps its
+// current address until that link is used, so a stolen session cannot move
+// the account to an address the owner does not control.
+func (c changeEmailCommand) Handle(ctx context.Context, req *userdto.ChangeEmailReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	account, err := c.userRepo.FindByID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to find user for email change",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	attempt, err := c.throttle.Reserve(ctx, account.Username, req.IPAddress)
+	if err != nil {
+		return err
+	}
+
+	// accounts without a password set one through the reset flow first
+	if !account.HasPassword() {
+		c.hasher.CompareDummy(req.CurrentPassword)
+		c.throttle.RecordFailure(ctx, attempt)
+		return helper.ErrInvalidCurrentPassword
+	}
+	if _, err := c.hasher.Verify(account.PasswordHash, req.CurrentPassword); err != nil {
+		slog.Warn("invalid current password on email change", slog.Uint64("user_id", account.ID))
+		c.throttle.RecordFailure(ctx, attempt)
+		return helper.ErrInvalidCurrentPassword
+	}
+	c.throttle.Reset(ctx, attempt)
+
+	email := strings.TrimSpace(req.Email)
+	if existing, err := c.userRepo.FindByEmail(ctx, email); err == nil {
+		if existing.ID == account.ID {
+			return nil
+		}
+		return helper.ErrEmailAlreadyInUse
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := c.issuer.issue(ctx, account, email); err != nil {
+		slog.Error("failed to send email verification for email change",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	// The owner learns about the request even if the session was stolen
+	if err := c.mailer.Send(ctx, &mailer.Message{
+		To:      account.Email,
+		Subject: "Email change requested",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to change the email address of your account to %s. "+
+			"The change only takes effect once the new address is confirmed. If this was not you, change your password.\n",
+			account.FirstName, email),
+	}); err != nil {
+		slog.Error("failed to notify previous address of email change",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestChangeEmailCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	mailSender := new(mocks.MockMailer)
+
+	cacheClient := new(mocks.MockCache)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(testEmailChangeAccount(t), nil)
+	userRepo.On("FindByEmail", mock.Anything, "new@example.com").
+		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	cacheClient.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	cacheClient.On("Incr", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	cacheClient.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(1), models.UserTokenPurposeEmailVerification).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.UserToken) bool {
+		return item.Target == "new@example.com"
+	})).Return(nil)
+	mailSender.On("Send", mock.Anything, mock.MatchedBy(func(msg *mailer.Message) bool {
+		return msg.To == "new@example.com"
+	})).Return(nil).Once()
+	mailSender.On("Send", mock.Anything, mock.MatchedBy(func(msg *mailer.Message) bool {
+		return msg.To == "old@example.com"
+	})).Return(nil).Once()
+
+	cmd := NewChangeEmailCommand(&config.ServiceConfig{}, userRepo, userTokenRepo, mailSender, cacheClient, testPasswordHasher)
+	err := cmd.Handle(ctx, &userdto.ChangeEmailReq{Email: "new@example.com", CurrentPassword: "Secret123!"})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "UpdateEmail", mock.Anything, mock.Anything)
+	userTokenRepo.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+}
+
+func TestChangeEmailCommand_Handle_WrongPassword(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	cacheClient := new(mocks.MockCache)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(testEmailChangeAccount(t), nil)
+	cacheClient.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	cacheClient.On("Incr", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	cacheClient.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	cmd := NewChangeEmailCommand(&config.ServiceConfig{}, userRepo, nil, nil, cacheClient, testPasswordHasher)
+	err := cmd.Handle(ctx, &userdto.ChangeEmailReq{Email: "new@example.com", CurrentPassword: "wrong"})
+
+	require.ErrorIs(t, err, helper.ErrInvalidCurrentPassword)
+	userRepo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+}
+
+func testEmailChangeAccount(t *testing.T) *models.User {
+	t.Helper()
+
+	passwordHash, err := testPasswordHasher.Hash("Secret123!")
+	require.NoError(t, err)
+	return &models.User{ID: 1, Username: "john", Email: "old@example.com", PasswordHash: passwordHash, EmailVerifiedAt: new(time.Now())}
+}
+
+func TestChangeEmailCommand_Handle_EmailInUse(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	cacheClient := new(mocks.MockCache)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(testEmailChangeAccount(t), nil)
+	userRepo.On("FindByEmail", mock.Anything, "taken@example.com").
+		Return(&models.User{ID: 2, Email: "taken@example.com"}, nil)
+	cacheClient.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	cacheClient.On("Incr", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	cacheClient.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	cmd := NewChangeEmailCommand(&config.ServiceConfig{}, userRepo, nil, nil, cacheClient, testPasswordHasher)
+	err := cmd.Handle(ctx, &userdto.ChangeEmailReq{Email: "taken@example.com", CurrentPassword: "Secret123!"})
+
+	require.ErrorIs(t, err, helper.ErrEmailAlreadyInUse)
+	userRepo.AssertExpectations(t)
+}
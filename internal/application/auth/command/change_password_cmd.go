@@ -0,0 +1,193 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+// IChangePasswordCommand returns a fresh token pair for the calling session,
+// since access tokens issued before the change are no longer accepted.
+type IChangePasswordCommand decorator.CommandReturnHandler[*userdto.ChangePasswordReq, *userdto.RefreshTokenRes]
+
+type changePasswordCommand struct {
+	userRepo          user.Repository
+	sessionRepo       session.Repository
+	securityEventRepo securityevent.Repository
+	tokenManager      security.TokenManager
+	authorizer        helper.Authorizer
+	hasher            *security.PasswordHasher
+	policy            *security.PasswordPolicy
+	cache             cache.Cache
+	throttle          helper.LoginThrottle
+	states            helper.AccountStates
+	tokenCacheTTL     time.Duration
+}
+
+func NewChangePasswordCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	securityEventRepo securityevent.Repository,
+	tokenManager security.TokenManager,
+	authorizer helper.Authorizer,
+	cacheClient cache.Cache,
+	hasher *security.PasswordHasher,
+	policy *security.PasswordPolicy,
+) IChangePasswordCommand {
+	return &changePasswordCommand{
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		securityEventRepo: securityEventRepo,
+		tokenManager:      tokenManager,
+		authorizer:        authorizer,
+		hasher:            hasher,
+		policy:            policy,
+		cache:             cacheClient,
+		throttle:          helper.NewLoginThrottle(config, cacheClient),
+		states:            helper.AccountStates{Cache: cacheClient},
+		tokenCacheTTL:     helper.TokenCacheTTL(config),
+	}
+}
+
+func (c changePasswordCommand) Handle(ctx context.Context, req *userdto.ChangePasswordReq) (*userdto.RefreshTokenRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	account, err := c.userRepo.FindByID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to find user for password change",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// a stolen access token must not become an unlimited password oracle
+	attempt, err := c.throttle.Reserve(ctx, account.Username, req.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// accounts without a password set one through the reset flow instead
+	if !account.HasPassword() {
+		c.hasher.CompareDummy(req.CurrentPassword)
+		c.throttle.RecordFailure(ctx, attempt)
+		return nil, helper.ErrInvalidCurrentPassword
+	}
+	if _, err := c.hasher.Verify(account.PasswordHash, req.CurrentPassword); err != nil {
+		slog.Warn("invalid current password on password change", slog.Uint64("user_id", account.ID))
+		c.throttle.RecordFailure(ctx, attempt)
+		return nil, helper.ErrInvalidCurrentPassword
+	}
+	c.throttle.Reset(ctx, attempt)
+
+	if err := helper.CheckPasswordPolicy(c.policy, req.NewPassword, account); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := c.hasher.Hash(req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	account.PasswordHash = passwordHash
+	account.PasswordChangedAt = new(time.Now())
+	if err := c.userRepo.UpdatePassword(ctx, account); err != nil {
+		slog.Error("failed to update password",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := c.states.Store(ctx, account); err != nil {
+		slog.Error("failed to cache account state after password change",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := c.cache.Set(ctx, helper.StaleTokensKey(account.ID), 1, c.tokenCacheTTL); err != nil {
+		slog.Error("failed to expire cached tokens after password change",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if _, err := c.sessionRepo.DeactivateAllByUserID(ctx, account.ID, caller.SessionID); err != nil {
+		slog.Error("failed to deactivate other sessions after password change",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := c.securityEventRepo.Create(ctx, &models.SecurityEvent{
+		UserID:    account.ID,
+		SessionID: caller.SessionID,
+		Type:      models.SecurityEventPasswordChanged,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+	}); err != nil {
+		slog.Error("failed to record password change event",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+	}
+
+	return c.reissue(ctx, caller)
+}
+
+// reissue rotates the calling session's refresh token so the caller keeps
+// working with tokens minted after the password change.
+func (c changePasswordCommand) reissue(ctx context.Context, caller *principal.Principal) (*userdto.RefreshTokenRes, error) {
+	sessionItem, err := c.sessionRepo.FindBySessionID(ctx, caller.SessionID)
+	if err != nil {
+		slog.Error("failed to find session after password change",
+			slog.String("sess_id", caller.SessionID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	grants, err := c.authorizer.Grants(ctx, sessionItem.UserID)
+	if err != nil {
+		slog.Error("failed to load user grants",
+			slog.Uint64("user_id", sessionItem.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	newRefreshJTI := uuid.NewString()
+	accessToken, refreshToken, accessExp, err := c.tokenManager.GenerateTokens(sessionItem.UserID, sessionItem.ID, newRefreshJTI, security.AccessClaims{Roles: grants.Roles})
+	if err != nil {
+		slog.Error("failed to generate tokens after password change",
+			slog.String("sess_id", sessionItem.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := c.sessionRepo.RotateRefreshJTI(ctx, sessionItem.ID, sessionItem.RefreshJTI, newRefreshJTI); err != nil {
+		slog.Error("failed to rotate refresh JTI after password change",
+			slog.String("sess_id", sessionItem.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.RefreshTokenRes{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(time.Until(accessExp).Seconds()),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+// testAuthorizer grants the given roles to every user.
+func testAuthorizer(roles ...string) *mocks.MockAuthorizer {
+	authorizer := new(mocks.MockAuthorizer)
+	authorizer.On("Grants", mock.Anything, mock.Anything).
+		Return(&helper.Grants{Roles: roles}, nil).Maybe()
+	return authorizer
+}
+
+func TestChangePasswordCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	cacheClient := new(mocks.MockCache)
+
+	passwordHash, err := security.HashPassword("old-password", security.DefaultCost)
+	require.NoError(t, err)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, Username: "john", PasswordHash: passwordHash}, nil)
+	cacheClient.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	cacheClient.On("Incr", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	cacheClient.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("UpdatePassword", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return item.PasswordChangedAt != nil &&
+			security.ComparePassword(item.PasswordHash, "new-password") == nil
+	})).Return(nil)
+	cacheClient.On("Set", mock.Anything, helper.AccountStateKey(1), mock.MatchedBy(func(raw []byte) bool {
+		return strings.Contains(string(raw), `"password_changed_at"`)
+	}), helper.AccountStateTTL).Return(nil)
+	cacheClient.On("Set", mock.Anything, helper.StaleTokensKey(1), 1, 30*time.Second).Return(nil)
+	sessionRepo.On("DeactivateAllByUserID", mock.Anything, uint64(1), "sess-1").Return(int64(2), nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.Type == models.SecurityEventPasswordChanged && item.SessionID == "sess-1"
+	})).Return(nil)
+	sessionRepo.On("FindBySessionID", mock.Anything, "sess-1").
+		Return(&models.Session{ID: "sess-1", UserID: 1, RefreshJTI: "jti-old", IsActive: true}, nil)
+	tokenManager.On("GenerateTokens", uint64(1), "sess-1", mock.Anything, mock.Anything).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+	sessionRepo.On("RotateRefreshJTI", mock.Anything, "sess-1", "jti-old", mock.Anything).Return(nil)
+
+	cmd := NewChangePasswordCommand(&config.ServiceConfig{}, userRepo, sessionRepo, securityEventRepo, tokenManager, testAuthorizer(), cacheClient, testPasswordHasher, testPasswordPolicy)
+	res, err := cmd.Handle(ctx, &userdto.ChangePasswordReq{CurrentPassword: "old-password", NewPassword: "new-password"})
+
+	require.NoError(t, err)
+	require.Equal(t, "access", res.AccessToken)
+	require.Equal(t, "refresh", res.RefreshToken)
+	userRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+	cacheClient.AssertCalled(t, "Set", mock.Anything, helper.StaleTokensKey(1), 1, 30*time.Second)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestChangePasswordCommand_Handle_WrongCurrentPassword(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	cacheClient := new(mocks.MockCache)
+
+	passwordHash, err := security.HashPassword("old-password", security.DefaultCost)
+	require.NoError(t, err)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, Username: "john", PasswordHash: passwordHash}, nil)
+	cacheClient.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	cacheClient.On("Incr", mock.Anything, "login:fail:user:john", mock.Anything).Return(int64(1), nil)
+	cacheClient.On("Set", mock.Anything, "login:block:user:john", mock.Anything, mock.Anything).Return(nil)
+
+	cmd := NewChangePasswordCommand(&config.ServiceConfig{}, userRepo, sessionRepo,
+		new(mocks.MockSecurityEventRepository), new(mocks.MockTokenManager), testAuthorizer(), cacheClient, testPasswordHasher, testPasswordPolicy)
+	res, err := cmd.Handle(ctx, &userdto.ChangePasswordReq{CurrentPassword: "wrong", NewPassword: "new-password"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidCurrentPassword)
+	cacheClient.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+	sessionRepo.AssertNotCalled(t, "DeactivateAllByUserID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangePasswordCommand_Handle_Throttled(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	cacheClient := new(mocks.MockCache)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, Username: "john"}, nil)
+	cacheClient.On("Exists", mock.Anything, "login:block:user:john").Return(true, nil)
+
+	cmd := NewChangePasswordCommand(&config.ServiceConfig{}, userRepo, new(mocks.MockSessionRepository),
+		new(mocks.MockSecurityEventRepository), new(mocks.MockTokenManager), testAuthorizer(), cacheClient, testPasswordHasher, testPasswordPolicy)
+	res, err := cmd.Handle(ctx, &userdto.ChangePasswordReq{CurrentPassword: "old-password", NewPassword: "new-password"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrLoginLocked)
+}
+
+func TestChangePasswordCommand_Handle_Unauthenticated(t *testing.T) {
+	cmd := NewChangePasswordCommand(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository),
+		new(mocks.MockSecurityEventRepository), new(mocks.MockTokenManager), testAuthorizer(), new(mocks.MockCache), testPasswordHasher, testPasswordPolicy)
+	res, err := cmd.Handle(context.Background(), &userdto.ChangePasswordReq{CurrentPassword: "a", NewPassword: "b"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IConfirmPasswordResetCommand decorator.CommandHandler[*userdto.ConfirmPasswordResetReq]
+
+type confirmPasswordResetCommand struct {
+	userRepo      user.Repository
+	sessionRepo   session.Repository
+	userTokenRepo usertoken.Repository
+	cache         cache.Cache
+	states        helper.AccountStates
+	hasher        *security.PasswordHasher
+	policy        *security.PasswordPolicy
+	tokenCacheTTL time.Duration
+}
+
+func NewConfirmPasswordResetCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	userTokenRepo usertoken.Repository,
+	cacheClient cache.Cache,
+	hasher *security.PasswordHasher,
+	policy *security.PasswordPolicy,
+) IConfirmPasswordResetCommand {
+	return &confirmPasswordResetCommand{
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		userTokenRepo: userTokenRepo,
+		cache:         cacheClient,
+		states:        helper.AccountStates{Cache: cacheClient},
+		hasher:        hasher,
+		policy:        policy,
+		tokenCacheTTL: helper.TokenCacheTTL(config),
+	}
+}
+
+// Handle only consumes the token once the new password is acceptable, so a
+// password rejected by the policy does not burn the emailed link.
+func (c confirmPasswordResetCommand) Handle(ctx context.Context, req *userdto.ConfirmPasswordResetReq) error {
+	tokenHash := security.HashOpaqueToken(req.Token)
+	token, err := c.userTokenRepo.FindActive(ctx, models.UserTokenPurposePasswordReset, tokenHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrInvalidResetToken
+		}
+		slog.Error("failed to find password reset token", slog.String("error", err.Error()))
+		return err
+	}
+
+	account, err := c.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrInvalidResetToken
+		}
+		slog.Error("failed to find user for password reset",
+			slog.Uint64("user_id", token.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+	if !account.CanSignIn() {
+		slog.Warn("password reset rejected for disabled account", slog.Uint64("user_id", account.ID))
+		return helper.ErrAccountDisabled
+	}
+
+	if err := helper.CheckPasswordPolicy(c.policy, req.NewPassword, account); err != nil {
+		return err
+	}
+
+	passwordHash, err := c.hasher.Hash(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	// a concurrent request may have used the token in the meantime
+	if _, err := c.userTokenRepo.Consume(ctx, models.UserTokenPurposePasswordReset, tokenHash); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrInvalidResetToken
+		}
+		slog.Error("failed to consume password reset token", slog.String("error", err.Error()))
+		return err
+	}
+
+	account.PasswordHash = passwordHash
+	account.PasswordChangedAt = new(time.Now())
+	if err := c.userRepo.UpdatePassword(ctx, account); err != nil {
+		slog.Error("failed to update password",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := c.states.Store(ctx, account); err != nil {
+		slog.Error("failed to cache account state after password reset",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := c.cache.Set(ctx, helper.StaleTokensKey(account.ID), 1, c.tokenCacheTTL); err != nil {
+		slog.Error("failed to expire cached tokens after password reset",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if _, err := c.sessionRepo.DeactivateAllByUserID(ctx, account.ID, ""); err != nil {
+		slog.Error("failed to deactivate sessions after password reset",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
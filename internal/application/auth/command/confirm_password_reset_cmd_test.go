@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestConfirmPasswordResetCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	cacheClient := new(mocks.MockCache)
+
+	userTokenRepo.On("FindActive", mock.Anything, models.UserTokenPurposePasswordReset, security.HashOpaqueToken("reset-token")).
+		Return(&models.UserToken{UserID: 1}, nil)
+	userTokenRepo.On("Consume", mock.Anything, models.UserTokenPurposePasswordReset, security.HashOpaqueToken("reset-token")).
+		Return(&models.UserToken{UserID: 1}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, IsActive: true, PasswordHash: "old"}, nil)
+	userRepo.On("UpdatePassword", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return item.PasswordChangedAt != nil &&
+			security.ComparePassword(item.PasswordHash, "new-password") == nil
+	})).Return(nil)
+	cacheClient.On("Set", mock.Anything, helper.AccountStateKey(1), mock.Anything, helper.AccountStateTTL).Return(nil)
+	cacheClient.On("Set", mock.Anything, helper.StaleTokensKey(1), 1, 30*time.Second).Return(nil)
+	sessionRepo.On("DeactivateAllByUserID", mock.Anything, uint64(1), "").Return(int64(2), nil)
+
+	cmd := NewConfirmPasswordResetCommand(&config.ServiceConfig{}, userRepo, sessionRepo, userTokenRepo, cacheClient, testPasswordHasher, testPasswordPolicy)
+	err := cmd.Handle(context.Background(), &userdto.ConfirmPasswordResetReq{
+		Token:       "reset-token",
+		NewPassword: "new-password",
+	})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+	userTokenRepo.AssertExpectations(t)
+	cacheClient.AssertExpectations(t)
+}
+
+func TestConfirmPasswordResetCommand_Handle_InvalidToken(t *testing.T) {
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+
+	userTokenRepo.On("FindActive", mock.Anything, models.UserTokenPurposePasswordReset, mock.Anything).
+		Return((*models.UserToken)(nil), gorm.ErrRecordNotFound)
+
+	cmd := NewConfirmPasswordResetCommand(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository), userTokenRepo, new(mocks.MockCache), testPasswordHasher, testPasswordPolicy)
+	err := cmd.Handle(context.Background(), &userdto.ConfirmPasswordResetReq{
+		Token:       "used-token",
+		NewPassword: "new-password",
+	})
+
+	require.ErrorIs(t, err, helper.ErrInvalidResetToken)
+	userTokenRepo.AssertExpectations(t)
+}
+
+func TestConfirmPasswordResetCommand_Handle_PolicyRejectionKeepsToken(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+
+	userTokenRepo.On("FindActive", mock.Anything, models.UserTokenPurposePasswordReset, mock.Anything).
+		Return(&models.UserToken{UserID: 1}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, IsActive: true, PasswordHash: "old"}, nil)
+
+	cmd := NewConfirmPasswordResetCommand(&config.ServiceConfig{}, userRepo, new(mocks.MockSessionRepository), userTokenRepo, new(mocks.MockCache), testPasswordHasher, testPasswordPolicy)
+	err := cmd.Handle(context.Background(), &userdto.ConfirmPasswordResetReq{
+		Token:       "reset-token",
+		NewPassword: "short",
+	})
+
+	require.ErrorIs(t, err, helper.ErrPasswordLength)
+	userTokenRepo.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+	userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+const recoveryCodeCount = 10
+
+type IConfirmTOTPCommand decorator.CommandReturnHandler[*userdto.ConfirmTOTPReq, *userdto.ConfirmTOTPRes]
+
+type confirmTOTPCommand struct {
+	mfaRepo mfa.Repository
+	box     *security.SecretBox
+}
+
+func NewConfirmTOTPCommand(mfaRepo mfa.Repository, box *security.SecretBox) IConfirmTOTPCommand {
+	return &confirmTOTPCommand{
+		mfaRepo: mfaRepo,
+		box:     box,
+	}
+}
+
+// Handle enables a pending enrollment and returns freshly generated recovery
+// codes. The plaintext codes are only ever shown in this response.
+func (c confirmTOTPCommand) Handle(ctx context.Context, req *userdto.ConfirmTOTPReq) (*userdto.ConfirmTOTPRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	item, err := c.mfaRepo.FindByUserID(ctx, caller.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrMFANotEnrolled
+		}
+
+		slog.Error("failed to find mfa enrollment",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if item.Enabled {
+		return nil, helper.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := c.box.Open(item.SecretCiphertext)
+	if err != nil {
+		slog.Error("failed to decrypt mfa secret",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	step, ok := security.ValidateTOTP(secret, req.Code, time.Now())
+	if !ok {
+		return nil, helper.ErrInvalidMFACode
+	}
+
+	codes, err := security.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, security.HashRecoveryCode(code))
+	}
+
+	if err := c.mfaRepo.Enable(ctx, caller.UserID, step, hashes); err != nil {
+		slog.Error("failed to enable mfa",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.ConfirmTOTPRes{RecoveryCodes: codes}, nil
+}
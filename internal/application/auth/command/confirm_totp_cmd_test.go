@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func pendingEnrollment(t *testing.T, box *security.SecretBox) (*models.UserMFA, string) {
+	t.Helper()
+
+	secret, err := security.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	ciphertext, err := box.Seal(secret)
+	require.NoError(t, err)
+
+	return &models.UserMFA{UserID: 1, SecretCiphertext: ciphertext}, secret
+}
+
+func TestConfirmTOTPCommand_Handle_Success(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	box := security.NewSecretBox("key")
+
+	item, secret := pendingEnrollment(t, box)
+	step := security.TOTPStep(time.Now())
+	code, err := security.TOTPCode(secret, step)
+	require.NoError(t, err)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(1)).Return(item, nil)
+
+	var hashes []string
+	mfaRepo.On("Enable", mock.Anything, uint64(1), step, mock.Anything).
+		Run(func(args mock.Arguments) { hashes = args.Get(3).([]string) }).
+		Return(nil)
+
+	res, err := NewConfirmTOTPCommand(mfaRepo, box).Handle(ctx, &userdto.ConfirmTOTPReq{Code: code})
+
+	require.NoError(t, err)
+	require.Len(t, res.RecoveryCodes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+	require.Equal(t, security.HashRecoveryCode(res.RecoveryCodes[0]), hashes[0])
+	mfaRepo.AssertExpectations(t)
+}
+
+func TestConfirmTOTPCommand_Handle_InvalidCode(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	box := security.NewSecretBox("key")
+
+	item, _ := pendingEnrollment(t, box)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(1)).Return(item, nil)
+
+	res, err := NewConfirmTOTPCommand(mfaRepo, box).Handle(ctx, &userdto.ConfirmTOTPReq{Code: "abcdef"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidMFACode)
+	mfaRepo.AssertNotCalled(t, "Enable", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
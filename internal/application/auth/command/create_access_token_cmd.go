@@ -0,0 +1,112 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/accesstoken"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+const defaultPersonalAccessTokenMaxTTL = 365 * 24 * time.Hour
+
+type ICreatePersonalAccessTokenCommand decorator.CommandReturnHandler[*userdto.CreatePersonalAccessTokenReq, *userdto.CreatePersonalAccessTokenRes]
+
+type createPersonalAccessTokenCommand struct {
+	tokenRepo  accesstoken.Repository
+	authorizer helper.Authorizer
+	maxTTL     time.Duration
+}
+
+func NewCreatePersonalAccessTokenCommand(
+	config *config.ServiceConfig,
+	tokenRepo accesstoken.Repository,
+	authorizer helper.Authorizer,
+) ICreatePersonalAccessTokenCommand {
+	maxTTL := config.Auth.PersonalAccessTokenMaxTTL
+	if maxTTL <= 0 {
+		maxTTL = defaultPersonalAccessTokenMaxTTL
+	}
+
+	return &createPersonalAccessTokenCommand{
+		tokenRepo:  tokenRepo,
+		authorizer: authorizer,
+		maxTTL:     maxTTL,
+	}
+}
+
+// Handle only accepts owner scopes and scopes the caller currently holds.
+// Scopes are checked again on every use, so revoking a role also narrows
+// existing tokens.
+func (c createPersonalAccessTokenCommand) Handle(ctx context.Context, req *userdto.CreatePersonalAccessTokenReq) (*userdto.CreatePersonalAccessTokenRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	ttl := c.maxTTL
+	if req.ExpiresInDays > 0 {
+		ttl = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+		if ttl > c.maxTTL {
+			return nil, helper.ErrInvalidTokenExpiry
+		}
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	if len(req.Scopes) > 0 {
+		grants, err := c.authorizer.Grants(ctx, caller.UserID)
+		if err != nil {
+			slog.Error("failed to load user grants",
+				slog.Uint64("user_id", caller.UserID),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+		for _, scope := range req.Scopes {
+			if !slices.Contains(helper.OwnerScopes, scope) && !grants.Has(scope) {
+				return nil, helper.ErrInvalidTokenScope
+			}
+			scopes = append(scopes, scope)
+		}
+	}
+
+	token, prefix, hash, err := security.GeneratePersonalAccessToken()
+	if err != nil {
+		slog.Error("failed to generate personal access token", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	item := &models.PersonalAccessToken{
+		UserID:    caller.UserID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		TokenHash: hash,
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := c.tokenRepo.Create(ctx, item); err != nil {
+		slog.Error("failed to create personal access token",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.CreatePersonalAccessTokenRes{
+		Token: token,
+		Item: &userdto.PersonalAccessTokenRes{
+			ID:        item.ID,
+			Name:      item.Name,
+			Prefix:    item.Prefix,
+			Scopes:    item.Scopes,
+			ExpiresAt: item.ExpiresAt,
+			CreatedAt: item.CreatedAt,
+		},
+	}, nil
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestCreatePersonalAccessTokenCommand_Handle_Success(t *testing.T) {
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	authorizer.On("Grants", mock.Anything, uint64(1)).
+		Return(&helper.Grants{Permissions: []string{"tasks:*"}}, nil)
+
+	var stored *models.PersonalAccessToken
+	tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.PersonalAccessToken")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.PersonalAccessToken)
+			stored.ID = 7
+		}).Return(nil)
+
+	cmd := NewCreatePersonalAccessTokenCommand(&config.ServiceConfig{}, tokenRepo, authorizer)
+	res, err := cmd.Handle(ctx, &userdto.CreatePersonalAccessTokenReq{
+		Name:          "ci",
+		Scopes:        []string{"tasks:read"},
+		ExpiresInDays: 30,
+	})
+
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(res.Token, security.PersonalAccessTokenPrefix))
+	require.Equal(t, uint64(7), res.Item.ID)
+	require.True(t, strings.HasPrefix(res.Token, res.Item.Prefix))
+	require.Equal(t, security.HashOpaqueToken(res.Token), stored.TokenHash)
+	require.Equal(t, []string{"tasks:read"}, stored.Scopes)
+	require.WithinDuration(t, time.Now().Add(30*24*time.Hour), stored.ExpiresAt, time.Minute)
+}
+
+func TestCreatePersonalAccessTokenCommand_Handle_ScopeNotHeld(t *testing.T) {
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	authorizer.On("Grants", mock.Anything, uint64(1)).
+		Return(&helper.Grants{Permissions: []string{"tasks:read"}}, nil)
+
+	cmd := NewCreatePersonalAccessTokenCommand(&config.ServiceConfig{}, tokenRepo, authorizer)
+	res, err := cmd.Handle(ctx, &userdto.CreatePersonalAccessTokenReq{Name: "ci", Scopes: []string{"roles:manage"}})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidTokenScope)
+	tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreatePersonalAccessTokenCommand_Handle_OwnerScopeWithoutRole(t *testing.T) {
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	authorizer.On("Grants", mock.Anything, uint64(1)).Return(&helper.Grants{}, nil)
+	tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.PersonalAccessToken")).Return(nil)
+
+	cmd := NewCreatePersonalAccessTokenCommand(&config.ServiceConfig{}, tokenRepo, authorizer)
+	res, err := cmd.Handle(ctx, &userdto.CreatePersonalAccessTokenReq{
+		Name:   "ci",
+		Scopes: []string{helper.ScopeTasksRead, helper.ScopeTasksWrite},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{helper.ScopeTasksRead, helper.ScopeTasksWrite}, res.Item.Scopes)
+}
+
+func TestCreatePersonalAccessTokenCommand_Handle_ExpiryTooLong(t *testing.T) {
+	cfg := &config.ServiceConfig{}
+	cfg.Auth.PersonalAccessTokenMaxTTL = 7 * 24 * time.Hour
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+
+	cmd := NewCreatePersonalAccessTokenCommand(cfg, new(mocks.MockAccessTokenRepository), new(mocks.MockAuthorizer))
+	res, err := cmd.Handle(ctx, &userdto.CreatePersonalAccessTokenReq{Name: "ci", ExpiresInDays: 8})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidTokenExpiry)
+}
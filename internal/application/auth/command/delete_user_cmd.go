@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IDeleteUserCommand decorator.CommandHandler[*userdto.DeleteUserReq]
+
+type deleteUserCommand struct {
+	changer accountStatusChanger
+}
+
+func NewDeleteUserCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	securityEventRepo securityevent.Repository,
+	cacheClient cache.Cache,
+) IDeleteUserCommand {
+	return &deleteUserCommand{
+		changer: accountStatusChanger{
+			userRepo:          userRepo,
+			sessionRepo:       sessionRepo,
+			securityEventRepo: securityEventRepo,
+			cache:             cacheClient,
+			states:            helper.AccountStates{Cache: cacheClient},
+			tokenCacheTTL:     helper.TokenCacheTTL(config),
+		},
+	}
+}
+
+// Handle soft-deletes the account: the row is kept for audit and to hold its
+// username and email, but it can no longer sign in or be reactivated.
+func (d deleteUserCommand) Handle(ctx context.Context, req *userdto.DeleteUserReq) error {
+	caller, account, err := d.changer.load(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	account.IsActive = false
+	account.DeletedAt = new(time.Now())
+	if err := d.changer.save(ctx, account, caller, models.SecurityEventAccountDeleted, req.Reason); err != nil {
+		return err
+	}
+
+	return d.changer.signOut(ctx, account.ID)
+}
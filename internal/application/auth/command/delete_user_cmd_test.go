@@ -0,0 +1,56 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestDeleteUserCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, IsActive: true}, nil)
+	userRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return !item.IsActive && item.IsDeleted() && item.StatusReason == "requested by user"
+	})).Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.Type == models.SecurityEventAccountDeleted
+	})).Return(nil)
+	sessionRepo.On("DeactivateAllByUserID", mock.Anything, uint64(2), "").Return(int64(1), nil)
+
+	cmd := NewDeleteUserCommand(&config.ServiceConfig{}, userRepo, sessionRepo, securityEventRepo, statusCache(t))
+	err := cmd.Handle(ctx, &userdto.DeleteUserReq{UserID: 2, Reason: "requested by user"})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestDeleteUserCommand_Handle_EventFailureDoesNotBlock(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, IsActive: true}, nil)
+	userRepo.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+	sessionRepo.On("DeactivateAllByUserID", mock.Anything, uint64(2), "").Return(int64(0), nil)
+
+	cmd := NewDeleteUserCommand(&config.ServiceConfig{}, userRepo, sessionRepo, securityEventRepo, statusCache(t))
+	err := cmd.Handle(ctx, &userdto.DeleteUserReq{UserID: 2, Reason: "spam"})
+
+	require.NoError(t, err)
+	sessionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IDisableTOTPCommand decorator.CommandHandler[*userdto.DisableTOTPReq]
+
+type disableTOTPCommand struct {
+	mfaRepo  mfa.Repository
+	verifier helper.MFACodeVerifier
+}
+
+func NewDisableTOTPCommand(mfaRepo mfa.Repository, box *security.SecretBox) IDisableTOTPCommand {
+	return &disableTOTPCommand{
+		mfaRepo: mfaRepo,
+		verifier: helper.MFACodeVerifier{
+			MFARepo: mfaRepo,
+			Box:     box,
+		},
+	}
+}
+
+// Handle removes the enrollment and its recovery codes after checking a
+// current TOTP or recovery code, so a stolen access token alone cannot
+// turn MFA off.
+func (d disableTOTPCommand) Handle(ctx context.Context, req *userdto.DisableTOTPReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	item, err := d.mfaRepo.FindByUserID(ctx, caller.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrMFANotEnrolled
+		}
+
+		slog.Error("failed to find mfa enrollment",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+	if !item.Enabled {
+		return helper.ErrMFANotEnrolled
+	}
+
+	if err := d.verifier.Verify(ctx, item, req.Code); err != nil {
+		return err
+	}
+
+	if err := d.mfaRepo.Delete(ctx, caller.UserID); err != nil {
+		slog.Error("failed to disable mfa",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestDisableTOTPCommand_Handle_Success(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	box := security.NewSecretBox("key")
+
+	item, secret := pendingEnrollment(t, box)
+	item.Enabled = true
+	code, err := security.TOTPCode(secret, security.TOTPStep(time.Now()))
+	require.NoError(t, err)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(1)).Return(item, nil)
+	mfaRepo.On("MarkStepUsed", mock.Anything, uint64(1), mock.AnythingOfType("int64")).Return(true, nil)
+	mfaRepo.On("Delete", mock.Anything, uint64(1)).Return(nil)
+
+	err = NewDisableTOTPCommand(mfaRepo, box).Handle(ctx, &userdto.DisableTOTPReq{Code: code})
+
+	require.NoError(t, err)
+	mfaRepo.AssertExpectations(t)
+}
+
+func TestDisableTOTPCommand_Handle_ReplayedCode(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	box := security.NewSecretBox("key")
+
+	item, secret := pendingEnrollment(t, box)
+	item.Enabled = true
+	code, err := security.TOTPCode(secret, security.TOTPStep(time.Now()))
+	require.NoError(t, err)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(1)).Return(item, nil)
+	mfaRepo.On("MarkStepUsed", mock.Anything, uint64(1), mock.AnythingOfType("int64")).Return(false, nil)
+
+	err = NewDisableTOTPCommand(mfaRepo, box).Handle(ctx, &userdto.DisableTOTPReq{Code: code})
+
+	require.ErrorIs(t, err, helper.ErrInvalidMFACode)
+	mfaRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDisableTOTPCommand_Handle_NotEnrolled(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(1)).Return((*models.UserMFA)(nil), gorm.ErrRecordNotFound)
+
+	err := NewDisableTOTPCommand(mfaRepo, security.NewSecretBox("key")).Handle(ctx, &userdto.DisableTOTPReq{Code: "123456"})
+
+	require.ErrorIs(t, err, helper.ErrMFANotEnrolled)
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+)
+
+const defaultEmailVerificationTTL = 24 * time.Hour
+
+// emailVerificationIssuer creates verification tokens bound to an email
+// address of the user and mails the link to that address. An address other
+// than the user's current one is a pending email change.
+type emailVerificationIssuer struct {
+	config        *config.ServiceConfig
+	userTokenRepo usertoken.Repository
+	mailer        mailer.Mailer
+}
+
+func (e emailVerificationIssuer) issue(ctx context.Context, account *models.User, email string) error {
+	if err := e.userTokenRepo.InvalidateByUser(ctx, account.ID, models.UserTokenPurposeEmailVerification); err != nil {
+		slog.Error("failed to invalidate previous email verification tokens",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	token, tokenHash, err := security.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	ttl := e.config.Auth.EmailVerificationTTL
+	if ttl <= 0 {
+		ttl = defaultEmailVerificationTTL
+	}
+
+	if err := e.userTokenRepo.Create(ctx, &models.UserToken{
+		UserID:    account.ID,
+		Purpose:   models.UserTokenPurposeEmailVerification,
+		TokenHash: tokenHash,
+		Target:    email,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		slog.Error("failed to store email verification token",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	link := helper.BuildTokenURL(e.config.Auth.EmailVerificationURL, token)
+	return e.mailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address using the link below. It expires in %s.\n\n%s\n",
+			account.FirstName, ttl, link),
+	})
+}
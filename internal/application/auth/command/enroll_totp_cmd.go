@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+const defaultMFAIssuer = "backend-go"
+
+type IEnrollTOTPCommand decorator.CommandReturnHandler[*userdto.EnrollTOTPReq, *userdto.EnrollTOTPRes]
+
+type enrollTOTPCommand struct {
+	config   *config.ServiceConfig
+	userRepo user.Repository
+	mfaRepo  mfa.Repository
+	box      *security.SecretBox
+}
+
+func NewEnrollTOTPCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	mfaRepo mfa.Repository,
+	box *security.SecretBox,
+) IEnrollTOTPCommand {
+	return &enrollTOTPCommand{
+		config:   config,
+		userRepo: userRepo,
+		mfaRepo:  mfaRepo,
+		box:      box,
+	}
+}
+
+// Handle starts a new pending enrollment, replacing any unconfirmed one.
+// The secret only takes effect once confirmed with a valid code.
+func (e enrollTOTPCommand) Handle(ctx context.Context, _ *userdto.EnrollTOTPReq) (*userdto.EnrollTOTPRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	existing, err := e.mfaRepo.FindByUserID(ctx, caller.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("failed to find mfa enrollment",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if existing != nil && existing.Enabled {
+		return nil, helper.ErrMFAAlreadyEnabled
+	}
+
+	account, err := e.userRepo.FindByID(ctx, caller.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrUserNotFound
+		}
+
+		slog.Error("failed to find user for mfa enrollment",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	secret, err := security.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := e.box.Seal(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.mfaRepo.Save(ctx, &models.UserMFA{
+		UserID:           account.ID,
+		SecretCiphertext: ciphertext,
+	}); err != nil {
+		slog.Error("failed to save mfa enrollment",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.EnrollTOTPRes{
+		Secret:          secret,
+		ProvisioningURI: security.TOTPProvisioningURI(e.issuer(), account.Email, secret),
+	}, nil
+}
+
+func (e enrollTOTPCommand) issuer() string {
+	if e.config.Auth.MFAIssuer != "" {
+		return e.config.Auth.MFAIssuer
+	}
+	if e.config.Server.Name != "" {
+		return e.config.Server.Name
+	}
+	return defaultMFAIssuer
+}
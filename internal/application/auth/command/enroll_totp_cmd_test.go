@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestEnrollTOTPCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	mfaRepo := new(mocks.MockMFARepository)
+	box := security.NewSecretBox("key")
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(1)).Return((*models.UserMFA)(nil), gorm.ErrRecordNotFound)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, Email: "user@example.com"}, nil)
+
+	var saved *models.UserMFA
+	mfaRepo.On("Save", mock.Anything, mock.AnythingOfType("*models.UserMFA")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*models.UserMFA) }).
+		Return(nil)
+
+	cfg := &config.ServiceConfig{Auth: config.Auth{MFAIssuer: "Tasks"}}
+	res, err := NewEnrollTOTPCommand(cfg, userRepo, mfaRepo, box).Handle(ctx, &userdto.EnrollTOTPReq{})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Secret)
+	require.Contains(t, res.ProvisioningURI, "otpauth://totp/Tasks:user@example.com?")
+	require.False(t, saved.Enabled)
+	require.NotContains(t, saved.SecretCiphertext, res.Secret)
+
+	opened, err := box.Open(saved.SecretCiphertext)
+	require.NoError(t, err)
+	require.Equal(t, res.Secret, opened)
+}
+
+func TestEnrollTOTPCommand_Handle_AlreadyEnabled(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	mfaRepo := new(mocks.MockMFARepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(1)).Return(&models.UserMFA{UserID: 1, Enabled: true}, nil)
+
+	res, err := NewEnrollTOTPCommand(&config.ServiceConfig{}, userRepo, mfaRepo, security.NewSecretBox("key")).
+		Handle(ctx, &userdto.EnrollTOTPReq{})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrMFAAlreadyEnabled)
+	mfaRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IForceLogoutCommand decorator.CommandReturnHandler[*userdto.ForceLogoutReq, *userdto.RevokeSessionsRes]
+
+type forceLogoutCommand struct {
+	userRepo          user.Repository
+	sessionRepo       session.Repository
+	securityEventRepo securityevent.Repository
+}
+
+func NewForceLogoutCommand(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	securityEventRepo securityevent.Repository,
+) IForceLogoutCommand {
+	return &forceLogoutCommand{
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		securityEventRepo: securityEventRepo,
+	}
+}
+
+// Handle signs the user out of every session. Personal access tokens are not
+// sessions and stay valid.
+func (f forceLogoutCommand) Handle(ctx context.Context, req *userdto.ForceLogoutReq) (*userdto.RevokeSessionsRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	if _, err := f.userRepo.FindByID(ctx, req.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrUserNotFound
+		}
+		slog.Error("failed to find user for forced logout",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	revoked, err := f.sessionRepo.DeactivateAllByUserID(ctx, req.UserID, "")
+	if err != nil {
+		slog.Error("failed to deactivate sessions for forced logout",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := f.securityEventRepo.Create(ctx, &models.SecurityEvent{
+		UserID:  req.UserID,
+		ActorID: &caller.UserID,
+		Type:    models.SecurityEventForcedLogout,
+	}); err != nil {
+		slog.Error("failed to record forced logout event",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+	}
+
+	slog.Info("user signed out by admin",
+		slog.Uint64("user_id", req.UserID),
+		slog.Uint64("actor_id", caller.UserID),
+		slog.Int64("revoked", revoked))
+	return &userdto.RevokeSessionsRes{Revoked: revoked}, nil
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestForceLogoutCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, IsActive: true}, nil)
+	sessionRepo.On("DeactivateAllByUserID", mock.Anything, uint64(2), "").Return(int64(2), nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.UserID == 2 && item.Type == models.SecurityEventForcedLogout && *item.ActorID == 1
+	})).Return(nil)
+
+	cmd := NewForceLogoutCommand(userRepo, sessionRepo, securityEventRepo)
+	res, err := cmd.Handle(ctx, &userdto.ForceLogoutReq{UserID: 2})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.Revoked)
+	sessionRepo.AssertExpectations(t)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestForceLogoutCommand_Handle_UserNotFound(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	cmd := NewForceLogoutCommand(userRepo, sessionRepo, new(mocks.MockSecurityEventRepository))
+	res, err := cmd.Handle(ctx, &userdto.ForceLogoutReq{UserID: 2})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrUserNotFound)
+	sessionRepo.AssertNotCalled(t, "DeactivateAllByUserID", mock.Anything, mock.Anything, mock.Anything)
+}
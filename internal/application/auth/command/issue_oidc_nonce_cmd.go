@@ -0,0 +1,36 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IIssueOIDCNonceCommand decorator.CommandReturnHandler[*userdto.IssueOIDCNonceReq, *userdto.OIDCNonceRes]
+
+type issueOIDCNonceCommand struct {
+	nonces helper.OIDCNonces
+}
+
+func NewIssueOIDCNonceCommand(cacheClient cache.Cache) IIssueOIDCNonceCommand {
+	return &issueOIDCNonceCommand{
+		nonces: helper.OIDCNonces{Cache: cacheClient},
+	}
+}
+
+func (i issueOIDCNonceCommand) Handle(ctx context.Context, _ *userdto.IssueOIDCNonceReq) (*userdto.OIDCNonceRes, error) {
+	nonce, err := i.nonces.Issue(ctx)
+	if err != nil {
+		slog.Error("failed to issue oidc nonce", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.OIDCNonceRes{
+		Nonce:     nonce,
+		ExpiresIn: int64(helper.OIDCNonceTTL.Seconds()),
+	}, nil
+}
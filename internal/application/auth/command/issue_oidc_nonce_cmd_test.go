@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestIssueOIDCNonceCommand_Handle(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+
+	var storedKey string
+	cacheClient.On("Set", mock.Anything, mock.AnythingOfType("string"), 1, helper.OIDCNonceTTL).
+		Run(func(args mock.Arguments) { storedKey = args.String(1) }).
+		Return(nil)
+
+	cmd := NewIssueOIDCNonceCommand(cacheClient)
+	res, err := cmd.Handle(context.Background(), &userdto.IssueOIDCNonceReq{})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Nonce)
+	require.Equal(t, int64(helper.OIDCNonceTTL.Seconds()), res.ExpiresIn)
+	// only the hash of the nonce is kept
+	require.Equal(t, helper.OIDCNonceKey(res.Nonce), storedKey)
+	require.NotContains(t, storedKey, res.Nonce)
+}
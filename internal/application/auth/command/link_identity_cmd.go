@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/httpclient/oidc"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+// maxLinkTokenAge bounds how old an ID token may be when used to prove
+// ownership of an external account, so a leaked old token cannot be replayed.
+const maxLinkTokenAge = 5 * time.Minute
+
+type ILinkIdentityCommand decorator.CommandReturnHandler[*userdto.LinkIdentityReq, *userdto.IdentityRes]
+
+type linkIdentityCommand struct {
+	identityRepo identity.Repository
+	verifier     oidc.IDTokenVerifier
+	nonces       helper.OIDCNonces
+	now          func() time.Time
+}
+
+func NewLinkIdentityCommand(identityRepo identity.Repository, verifier oidc.IDTokenVerifier, cacheClient cache.Cache) ILinkIdentityCommand {
+	return &linkIdentityCommand{
+		identityRepo: identityRepo,
+		verifier:     verifier,
+		nonces:       helper.OIDCNonces{Cache: cacheClient},
+		now:          time.Now,
+	}
+}
+
+func (l linkIdentityCommand) Handle(ctx context.Context, req *userdto.LinkIdentityReq) (*userdto.IdentityRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	if err := l.nonces.Consume(ctx, req.Nonce); err != nil {
+		return nil, err
+	}
+
+	claims, err := l.verifier.VerifyIDToken(ctx, req.Provider, req.IDToken, req.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrUnknownProvider) {
+			return nil, helper.ErrUnknownOIDCProvider
+		}
+
+		slog.Warn("failed to verify id token for identity link",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("provider", req.Provider),
+			slog.String("error", err.Error()))
+		return nil, helper.ErrInvalidToken
+	}
+
+	if l.now().Sub(claims.IssuedAt) > maxLinkTokenAge {
+		slog.Warn("rejecting stale id token for identity link",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("provider", req.Provider))
+		return nil, helper.ErrInvalidToken
+	}
+
+	item := &models.UserIdentity{
+		UserID:   caller.UserID,
+		Provider: req.Provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	if err := l.identityRepo.Create(ctx, item); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, helper.ErrIdentityAlreadyLinked
+		}
+
+		slog.Error("failed to link identity",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("provider", req.Provider),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.IdentityRes{
+		ID:        item.ID,
+		Provider:  item.Provider,
+		Email:     item.Email,
+		CreatedAt: item.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/httpclient/oidc"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+// issuedNonce returns a cache holding nonce as handed out and not yet used.
+func issuedNonce(nonce string) *mocks.MockCache {
+	cacheClient := new(mocks.MockCache)
+	cacheClient.On("Incr", mock.Anything, helper.OIDCNonceKey(nonce), helper.OIDCNonceTTL).Return(int64(2), nil)
+	cacheClient.On("Delete", mock.Anything, helper.OIDCNonceKey(nonce)).Return(nil)
+	return cacheClient
+}
+
+func TestLinkIdentityCommand_Handle_Success(t *testing.T) {
+	identityRepo := new(mocks.MockIdentityRepository)
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	claims := &oidc.IDTokenClaims{Subject: "sub-1", Email: "user@example.com", IssuedAt: time.Now()}
+	verifier.On("VerifyIDToken", mock.Anything, "google", "idtoken", "n-1").Return(claims, nil)
+	identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.UserIdentity) bool {
+		return item.UserID == 1 && item.Provider == "google" && item.Subject == "sub-1"
+	})).Return(nil)
+
+	cmd := NewLinkIdentityCommand(identityRepo, verifier, issuedNonce("n-1"))
+	res, err := cmd.Handle(ctx, &userdto.LinkIdentityReq{Provider: "google", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.NoError(t, err)
+	require.Equal(t, "google", res.Provider)
+	require.Equal(t, "user@example.com", res.Email)
+	identityRepo.AssertExpectations(t)
+}
+
+func TestLinkIdentityCommand_Handle_StaleToken(t *testing.T) {
+	identityRepo := new(mocks.MockIdentityRepository)
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	claims := &oidc.IDTokenClaims{Subject: "sub-1", IssuedAt: time.Now().Add(-time.Hour)}
+	verifier.On("VerifyIDToken", mock.Anything, "google", "idtoken", "n-1").Return(claims, nil)
+
+	cmd := NewLinkIdentityCommand(identityRepo, verifier, issuedNonce("n-1"))
+	res, err := cmd.Handle(ctx, &userdto.LinkIdentityReq{Provider: "google", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+	identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLinkIdentityCommand_Handle_AlreadyLinked(t *testing.T) {
+	identityRepo := new(mocks.MockIdentityRepository)
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	claims := &oidc.IDTokenClaims{Subject: "sub-1", IssuedAt: time.Now()}
+	verifier.On("VerifyIDToken", mock.Anything, "google", "idtoken", "n-1").Return(claims, nil)
+	identityRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.UserIdentity")).Return(gorm.ErrDuplicatedKey)
+
+	cmd := NewLinkIdentityCommand(identityRepo, verifier, issuedNonce("n-1"))
+	res, err := cmd.Handle(ctx, &userdto.LinkIdentityReq{Provider: "google", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrIdentityAlreadyLinked)
+}
+
+func TestLinkIdentityCommand_Handle_UnknownProvider(t *testing.T) {
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	verifier.On("VerifyIDToken", mock.Anything, "nope", "idtoken", "n-1").Return(nil, oidc.ErrUnknownProvider)
+
+	cmd := NewLinkIdentityCommand(new(mocks.MockIdentityRepository), verifier, issuedNonce("n-1"))
+	res, err := cmd.Handle(ctx, &userdto.LinkIdentityReq{Provider: "nope", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrUnknownOIDCProvider)
+}
+
+func TestLinkIdentityCommand_Handle_InvalidToken(t *testing.T) {
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	verifier.On("VerifyIDToken", mock.Anything, "google", "bad", "n-1").Return(nil, errors.New("bad signature"))
+
+	cmd := NewLinkIdentityCommand(new(mocks.MockIdentityRepository), verifier, issuedNonce("n-1"))
+	res, err := cmd.Handle(ctx, &userdto.LinkIdentityReq{Provider: "google", IDToken: "bad", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
+
+func TestLinkIdentityCommand_Handle_UnknownNonce(t *testing.T) {
+	verifier := new(mocks.MockIDTokenVerifier)
+	cacheClient := new(mocks.MockCache)
+
+	// a nonce never issued here starts a new counter
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	cacheClient.On("Incr", mock.Anything, helper.OIDCNonceKey("forged"), helper.OIDCNonceTTL).Return(int64(1), nil)
+	cacheClient.On("Delete", mock.Anything, helper.OIDCNonceKey("forged")).Return(nil)
+
+	cmd := NewLinkIdentityCommand(new(mocks.MockIdentityRepository), verifier, cacheClient)
+	res, err := cmd.Handle(ctx, &userdto.LinkIdentityReq{Provider: "google", IDToken: "idtoken", Nonce: "forged"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidOIDCNonce)
+	verifier.AssertNotCalled(t, "VerifyIDToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
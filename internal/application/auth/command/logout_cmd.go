@@ -33,6 +33,10 @@ func (l logoutCommand) Handle(ctx context.Context, req *userdto.LogoutReq) error
 		slog.Warn("failed to verify access token", slog.String("error", err.Error()))
 		return helper.ErrInvalidToken
 	}
+	if claims.TokenType != security.TokenTypeAccess {
+		slog.Warn("unexpected token type for access token", slog.String("typ", claims.TokenType))
+		return helper.ErrInvalidToken
+	}
 
 	sessionItem, err := l.sessionRepo.FindBySessionID(ctx, claims.SessionID)
 	if err != nil {
@@ -20,6 +20,7 @@ func TestLogoutCommand_Handle_Success(t *testing.T) {
 	tokenManager := new(mocks.MockTokenManager)
 
 	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeAccess,
 		SessionID: "sess-1",
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject: "1",
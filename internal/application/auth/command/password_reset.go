@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+)
+
+const defaultPasswordResetTTL = 30 * time.Minute
+
+// passwordResetIssuer replaces any outstanding reset token of the user with a
+// new one and mails the reset link.
+type passwordResetIssuer struct {
+	config        *config.ServiceConfig
+	userTokenRepo usertoken.Repository
+	mailer        mailer.Mailer
+}
+
+func (p passwordResetIssuer) issue(ctx context.Context, account *models.User) error {
+	if err := p.userTokenRepo.InvalidateByUser(ctx, account.ID, models.UserTokenPurposePasswordReset); err != nil {
+		slog.Error("failed to invalidate previous password reset tokens",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	token, tokenHash, err := security.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	ttl := p.config.Auth.PasswordResetTTL
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+
+	if err := p.userTokenRepo.Create(ctx, &models.UserToken{
+		UserID:    account.ID,
+		Purpose:   models.UserTokenPurposePasswordReset,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		slog.Error("failed to store password reset token",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	link := helper.BuildTokenURL(p.config.Auth.PasswordResetURL, token)
+	return p.mailer.Send(ctx, &mailer.Message{
+		To:      account.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to reset your password. It expires in %s.\n\n%s\n\n"+
+			"If you did not request a password reset, you can ignore this email.\n",
+			account.FirstName, ttl, link),
+	})
+}
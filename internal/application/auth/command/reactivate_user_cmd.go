@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IReactivateUserCommand decorator.CommandHandler[*userdto.ReactivateUserReq]
+
+type reactivateUserCommand struct {
+	changer accountStatusChanger
+}
+
+func NewReactivateUserCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	securityEventRepo securityevent.Repository,
+	cacheClient cache.Cache,
+) IReactivateUserCommand {
+	return &reactivateUserCommand{
+		changer: accountStatusChanger{
+			userRepo:          userRepo,
+			securityEventRepo: securityEventRepo,
+			cache:             cacheClient,
+			states:            helper.AccountStates{Cache: cacheClient},
+			tokenCacheTTL:     helper.TokenCacheTTL(config),
+		},
+	}
+}
+
+// Handle lifts a suspension. Deleted accounts cannot be reactivated.
+func (r reactivateUserCommand) Handle(ctx context.Context, req *userdto.ReactivateUserReq) error {
+	caller, account, err := r.changer.load(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	account.IsActive = true
+	return r.changer.save(ctx, account, caller, models.SecurityEventAccountReactivated, req.Reason)
+}
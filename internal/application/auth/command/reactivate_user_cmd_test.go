@@ -0,0 +1,50 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestReactivateUserCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, IsActive: false}, nil)
+	userRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return item.IsActive && item.StatusReason == "appeal accepted"
+	})).Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.Type == models.SecurityEventAccountReactivated
+	})).Return(nil)
+
+	cmd := NewReactivateUserCommand(&config.ServiceConfig{}, userRepo, securityEventRepo, statusCache(t))
+	err := cmd.Handle(ctx, &userdto.ReactivateUserReq{UserID: 2, Reason: "appeal accepted"})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestReactivateUserCommand_Handle_DeletedUser(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, DeletedAt: new(time.Now())}, nil)
+
+	cmd := NewReactivateUserCommand(&config.ServiceConfig{}, userRepo, new(mocks.MockSecurityEventRepository), statusCache(t))
+	err := cmd.Handle(ctx, &userdto.ReactivateUserReq{UserID: 2, Reason: "mistake"})
+
+	require.ErrorIs(t, err, helper.ErrUserNotFound)
+	userRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
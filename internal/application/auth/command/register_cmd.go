@@ -3,15 +3,19 @@ package command
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tdatIT/backend-go/config"
 	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
 	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
 	"github.com/tdatIT/backend-go/internal/infras/repository/session"
 	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
 	"github.com/tdatIT/backend-go/internal/infras/security"
 	"github.com/tdatIT/backend-go/pkgs/decorator"
 	"github.com/tdatIT/backend-go/pkgs/utils/genid"
@@ -21,16 +25,40 @@ import (
 type IRegisterCommand decorator.CommandReturnHandler[*userdto.RegisterReq, *userdto.LoginRes]
 
 type registerCommand struct {
+	config       *config.ServiceConfig
 	userRepo     user.Repository
 	sessionRepo  session.Repository
 	tokenManager security.TokenManager
+	monitor      helper.LoginMonitor
+	hasher       *security.PasswordHasher
+	policy       *security.PasswordPolicy
+	verification emailVerificationIssuer
 }
 
-func NewRegisterCommand(userRepo user.Repository, sessionRepo session.Repository, tokenManager security.TokenManager) IRegisterCommand {
+func NewRegisterCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	userTokenRepo usertoken.Repository,
+	tokenManager security.TokenManager,
+	monitor helper.LoginMonitor,
+	mailer mailer.Mailer,
+	hasher *security.PasswordHasher,
+	policy *security.PasswordPolicy,
+) IRegisterCommand {
 	return &registerCommand{
+		config:       config,
 		userRepo:     userRepo,
 		sessionRepo:  sessionRepo,
 		tokenManager: tokenManager,
+		monitor:      monitor,
+		hasher:       hasher,
+		policy:       policy,
+		verification: emailVerificationIssuer{
+			config:        config,
+			userTokenRepo: userTokenRepo,
+			mailer:        mailer,
+		},
 	}
 }
 
@@ -53,24 +81,48 @@ func (r registerCommand) Handle(ctx context.Context, req *userdto.RegisterReq) (
 		lastName = "User"
 	}
 
-	passwordHash, err := security.HashPassword(req.Password, security.DefaultCost)
-	if err != nil {
+	item := &models.User{
+		FirstName: strings.TrimSpace(req.FirstName),
+		LastName:  lastName,
+		Email:     strings.TrimSpace(req.Username),
+		Username:  strings.TrimSpace(req.Username),
+		IsActive:  true,
+	}
+
+	if err := helper.CheckPasswordPolicy(r.policy, req.Password, item); err != nil {
 		return nil, err
 	}
 
-	item := &models.User{
-		FirstName:    strings.TrimSpace(req.FirstName),
-		LastName:     lastName,
-		Email:        strings.TrimSpace(req.Username),
-		Username:     strings.TrimSpace(req.Username),
-		PasswordHash: passwordHash,
-		IsActive:     true,
+	passwordHash, err := r.hasher.Hash(req.Password)
+	if err != nil {
+		return nil, err
 	}
+	item.PasswordHash = passwordHash
 
 	if err := r.userRepo.Create(ctx, item); err != nil {
 		return nil, err
 	}
 
+	// A failed verification email should not fail the sign-up; the user can request a resend
+	if err := r.verification.issue(ctx, item, item.Email); err != nil {
+		slog.Error("failed to send email verification after register",
+			slog.Uint64("user_id", item.ID),
+			slog.String("error", err.Error()))
+	}
+
+	profile := &userdto.UserProfileRes{
+		ID:        item.ID,
+		FirstName: item.FirstName,
+		LastName:  item.LastName,
+		Email:     item.Email,
+		Username:  item.Username,
+	}
+
+	// No session until the address is verified when login requires it
+	if r.config.Auth.RequireVerifiedEmail {
+		return &userdto.LoginRes{User: profile}, nil
+	}
+
 	refreshJTI := uuid.NewString()
 	now := time.Now()
 	sessionItem := &models.Session{
@@ -82,11 +134,12 @@ func (r registerCommand) Handle(ctx context.Context, req *userdto.RegisterReq) (
 		IsActive:   true,
 		LastUsedAt: &now,
 	}
+	r.monitor.Describe(sessionItem)
 	if err := r.sessionRepo.Create(ctx, sessionItem); err != nil {
 		return nil, err
 	}
 
-	accessToken, refreshToken, accessExp, err := r.tokenManager.GenerateTokens(item.ID, sessionItem.ID, refreshJTI)
+	accessToken, refreshToken, accessExp, err := r.tokenManager.GenerateTokens(item.ID, sessionItem.ID, refreshJTI, security.AccessClaims{})
 	if err != nil {
 		return nil, err
 	}
@@ -95,12 +148,6 @@ func (r registerCommand) Handle(ctx context.Context, req *userdto.RegisterReq) (
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresIn:    int64(time.Until(accessExp).Seconds()),
-		User: &userdto.UserProfileRes{
-			ID:        item.ID,
-			FirstName: item.FirstName,
-			LastName:  item.LastName,
-			Email:     item.Email,
-			Username:  item.Username,
-		},
+		User:         profile,
 	}, nil
 }
@@ -7,17 +7,29 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
 	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
 	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/security"
 	"github.com/tdatIT/backend-go/mocks"
 	"gorm.io/gorm"
 )
 
+// cheap argon2id parameters keep the tests fast
+var (
+	testPasswordHasher = security.NewPasswordHasher(security.PasswordHashConfig{
+		Argon2: security.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1},
+	})
+	testPasswordPolicy = security.NewPasswordPolicy(security.PasswordPolicyConfig{})
+)
+
 func TestRegisterCommand_Handle_Success(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	sessionRepo := new(mocks.MockSessionRepository)
 	tokenManager := new(mocks.MockTokenManager)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	mailSender := new(mocks.MockMailer)
 
 	req := &userdto.RegisterReq{
 		Username:  "test@example.com",
@@ -36,13 +48,20 @@ func TestRegisterCommand_Handle_Success(t *testing.T) {
 			item.ID = 1
 		}).Return(nil)
 
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(1), models.UserTokenPurposeEmailVerification).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.UserToken) bool {
+		return item.Purpose == models.UserTokenPurposeEmailVerification && item.Target == req.Username
+	})).Return(nil)
+	mailSender.On("Send", mock.Anything, mock.AnythingOfType("*mailer.Message")).Return(nil)
 	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
+	monitor := new(mocks.MockLoginMonitor)
+	monitor.On("Describe", mock.AnythingOfType("*models.Session")).Return()
 
 	accessExp := time.Now().Add(time.Hour)
-	tokenManager.On("GenerateTokens", uint64(1), mock.Anything, mock.Anything).
+	tokenManager.On("GenerateTokens", uint64(1), mock.Anything, mock.Anything, mock.Anything).
 		Return("access", "refresh", accessExp, nil)
 
-	cmd := NewRegisterCommand(userRepo, sessionRepo, tokenManager)
+	cmd := NewRegisterCommand(&config.ServiceConfig{}, userRepo, sessionRepo, userTokenRepo, tokenManager, monitor, mailSender, testPasswordHasher, testPasswordPolicy)
 	res, err := cmd.Handle(context.Background(), req)
 
 	require.NoError(t, err)
@@ -54,6 +73,45 @@ func TestRegisterCommand_Handle_Success(t *testing.T) {
 	userRepo.AssertExpectations(t)
 	sessionRepo.AssertExpectations(t)
 	tokenManager.AssertExpectations(t)
+	userTokenRepo.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	monitor.AssertExpectations(t)
+}
+
+func TestRegisterCommand_Handle_RequireVerifiedEmail(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	mailSender := new(mocks.MockMailer)
+
+	req := &userdto.RegisterReq{
+		Username:  "test@example.com",
+		Password:  "pass1234",
+		FirstName: "Test",
+	}
+
+	userRepo.On("FindByUsername", mock.Anything, req.Username).
+		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	userRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).
+		Run(func(args mock.Arguments) {
+			item := args.Get(1).(*models.User)
+			item.ID = 1
+		}).Return(nil)
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(1), models.UserTokenPurposeEmailVerification).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.UserToken")).Return(nil)
+	mailSender.On("Send", mock.Anything, mock.AnythingOfType("*mailer.Message")).Return(nil)
+
+	cfg := &config.ServiceConfig{Auth: config.Auth{RequireVerifiedEmail: true}}
+	cmd := NewRegisterCommand(cfg, userRepo, sessionRepo, userTokenRepo, tokenManager, nil, mailSender, testPasswordHasher, testPasswordPolicy)
+	res, err := cmd.Handle(context.Background(), req)
+
+	require.NoError(t, err)
+	require.Empty(t, res.AccessToken)
+	require.Equal(t, uint64(1), res.User.ID)
+
+	sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	tokenManager.AssertNotCalled(t, "GenerateTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestRegisterCommand_Handle_UserAlreadyExists(t *testing.T) {
@@ -70,7 +128,7 @@ func TestRegisterCommand_Handle_UserAlreadyExists(t *testing.T) {
 	userRepo.On("FindByUsername", mock.Anything, req.Username).
 		Return(&models.User{ID: 9}, nil)
 
-	cmd := NewRegisterCommand(userRepo, sessionRepo, tokenManager)
+	cmd := NewRegisterCommand(&config.ServiceConfig{}, userRepo, sessionRepo, nil, tokenManager, nil, nil, testPasswordHasher, testPasswordPolicy)
 	res, err := cmd.Handle(context.Background(), req)
 
 	require.Nil(t, res)
@@ -80,7 +138,7 @@ func TestRegisterCommand_Handle_UserAlreadyExists(t *testing.T) {
 }
 
 func TestRegisterCommand_Handle_InvalidRequest(t *testing.T) {
-	cmd := NewRegisterCommand(nil, nil, nil)
+	cmd := NewRegisterCommand(nil, nil, nil, nil, nil, nil, nil, testPasswordHasher, testPasswordPolicy)
 	res, err := cmd.Handle(context.Background(), nil)
 
 	require.Nil(t, res)
@@ -94,9 +152,34 @@ func TestRegisterCommand_Handle_InvalidPassword(t *testing.T) {
 		FirstName: "Test",
 	}
 
-	cmd := NewRegisterCommand(nil, nil, nil)
+	cmd := NewRegisterCommand(nil, nil, nil, nil, nil, nil, nil, testPasswordHasher, testPasswordPolicy)
 	res, err := cmd.Handle(context.Background(), req)
 
 	require.Nil(t, res)
 	require.Error(t, err)
 }
+
+func TestRegisterCommand_Handle_PasswordPolicy(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	userRepo.On("FindByUsername", mock.Anything, "johnny@example.com").
+		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	cmd := NewRegisterCommand(&config.ServiceConfig{}, userRepo, nil, nil, nil, nil, nil, testPasswordHasher, testPasswordPolicy)
+
+	for password, want := range map[string]error{
+		"short":          helper.ErrPasswordLength,
+		"password123":    helper.ErrPasswordCommon,
+		"johnny-rocks-1": helper.ErrPasswordContainsUserInfo,
+	} {
+		res, err := cmd.Handle(context.Background(), &userdto.RegisterReq{
+			Username:  "johnny@example.com",
+			Password:  password,
+			FirstName: "John",
+		})
+
+		require.Nil(t, res)
+		require.ErrorIs(t, err, want, password)
+	}
+	userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
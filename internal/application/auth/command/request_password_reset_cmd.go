@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/pkgs/background"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IRequestPasswordResetCommand decorator.CommandHandler[*userdto.RequestPasswordResetReq]
+
+type requestPasswordResetCommand struct {
+	userRepo user.Repository
+	issuer   passwordResetIssuer
+	runner   *background.Runner
+}
+
+func NewRequestPasswordResetCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	userTokenRepo usertoken.Repository,
+	mailer mailer.Mailer,
+	runner *background.Runner,
+) IRequestPasswordResetCommand {
+	return &requestPasswordResetCommand{
+		userRepo: userRepo,
+		issuer: passwordResetIssuer{
+			config:        config,
+			userTokenRepo: userTokenRepo,
+			mailer:        mailer,
+		},
+		runner: runner,
+	}
+}
+
+// Handle always succeeds for unknown or inactive accounts so the endpoint
+// cannot be used to discover which emails are registered. The email is sent
+// in the background, so a known address does not take longer to answer.
+func (r requestPasswordResetCommand) Handle(ctx context.Context, req *userdto.RequestPasswordResetReq) error {
+	email := strings.TrimSpace(req.Email)
+
+	account, err := r.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Info("password reset requested for unknown email")
+			return nil
+		}
+		slog.Error("failed to find user by email for password reset", slog.String("error", err.Error()))
+		return err
+	}
+
+	if !account.CanSignIn() {
+		slog.Info("password reset requested for disabled user", slog.Uint64("user_id", account.ID))
+		return nil
+	}
+
+	r.runner.Go(ctx, func(ctx context.Context) {
+		if err := r.issuer.issue(ctx, account); err != nil {
+			// The caller gets the same response either way
+			slog.Error("failed to send password reset email",
+				slog.Uint64("user_id", account.ID),
+				slog.String("error", err.Error()))
+		}
+	})
+
+	return nil
+}
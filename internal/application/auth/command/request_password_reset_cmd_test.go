@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/mocks"
+	"github.com/tdatIT/backend-go/pkgs/background"
+	"gorm.io/gorm"
+)
+
+func TestRequestPasswordResetCommand_Handle_SendsEmail(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	mailSender := new(mocks.MockMailer)
+
+	cfg := &config.ServiceConfig{}
+	cfg.Auth.PasswordResetURL = "https://app.example.com/reset"
+
+	userRepo.On("FindByEmail", mock.Anything, "test@example.com").
+		Return(&models.User{ID: 1, Email: "test@example.com", IsActive: true}, nil)
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(1), models.UserTokenPurposePasswordReset).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.UserToken) bool {
+		return item.UserID == 1 && item.Purpose == models.UserTokenPurposePasswordReset && len(item.TokenHash) == 64
+	})).Return(nil)
+	mailSender.On("Send", mock.Anything, mock.MatchedBy(func(msg *mailer.Message) bool {
+		return msg.To == "test@example.com" && strings.Contains(msg.Body, "https://app.example.com/reset?token=")
+	})).Return(nil)
+
+	runner := background.NewRunner(time.Second)
+	cmd := NewRequestPasswordResetCommand(cfg, userRepo, userTokenRepo, mailSender, runner)
+	err := cmd.Handle(context.Background(), &userdto.RequestPasswordResetReq{Email: "test@example.com"})
+
+	require.NoError(t, err)
+	require.NoError(t, runner.Wait(context.Background()))
+	userRepo.AssertExpectations(t)
+	userTokenRepo.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+}
+
+func TestRequestPasswordResetCommand_Handle_UnknownEmail(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	mailSender := new(mocks.MockMailer)
+
+	userRepo.On("FindByEmail", mock.Anything, "missing@example.com").
+		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	runner := background.NewRunner(time.Second)
+	cmd := NewRequestPasswordResetCommand(&config.ServiceConfig{}, userRepo, userTokenRepo, mailSender, runner)
+	err := cmd.Handle(context.Background(), &userdto.RequestPasswordResetReq{Email: "missing@example.com"})
+
+	require.NoError(t, err)
+	require.NoError(t, runner.Wait(context.Background()))
+	userTokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mailSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestRequestPasswordResetCommand_Handle_DoesNotWaitForEmail(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	mailSender := new(mocks.MockMailer)
+
+	userRepo.On("FindByEmail", mock.Anything, "test@example.com").
+		Return(&models.User{ID: 1, Email: "test@example.com", IsActive: true}, nil)
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(1), models.UserTokenPurposePasswordReset).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	release := make(chan struct{})
+	mailSender.On("Send", mock.Anything, mock.Anything).Run(func(mock.Arguments) { <-release }).Return(nil)
+
+	runner := background.NewRunner(time.Second)
+	cmd := NewRequestPasswordResetCommand(&config.ServiceConfig{}, userRepo, userTokenRepo, mailSender, runner)
+	err := cmd.Handle(context.Background(), &userdto.RequestPasswordResetReq{Email: "test@example.com"})
+
+	require.NoError(t, err)
+	close(release)
+	require.NoError(t, runner.Wait(context.Background()))
+	mailSender.AssertExpectations(t)
+}
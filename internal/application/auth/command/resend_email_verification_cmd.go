@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+const defaultVerificationResendDelay = time.Minute
+
+type IResendEmailVerificationCommand decorator.CommandHandler[*userdto.ResendEmailVerificationReq]
+
+type resendEmailVerificationCommand struct {
+	config   *config.ServiceConfig
+	userRepo user.Repository
+	cache    cache.Cache
+	issuer   emailVerificationIssuer
+}
+
+func NewResendEmailVerificationCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	userTokenRepo usertoken.Repository,
+	cacheClient cache.Cache,
+	mailer mailer.Mailer,
+) IResendEmailVerificationCommand {
+	return &resendEmailVerificationCommand{
+		config:   config,
+		userRepo: userRepo,
+		cache:    cacheClient,
+		issuer: emailVerificationIssuer{
+			config:        config,
+			userTokenRepo: userTokenRepo,
+			mailer:        mailer,
+		},
+	}
+}
+
+func (r resendEmailVerificationCommand) Handle(ctx context.Context, _ *userdto.ResendEmailVerificationReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	account, err := r.userRepo.FindByID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to find user for email verification resend",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if account.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	delay := r.config.Auth.VerificationResendDelay
+	if delay <= 0 {
+		delay = defaultVerificationResendDelay
+	}
+
+	allowed, err := r.cache.SetNX(ctx, fmt.Sprintf("email_verify:resend:%d", account.ID), 1, delay)
+	if err != nil {
+		slog.Error("failed to apply email verification resend limit",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+	if !allowed {
+		return helper.ErrTooManyRequests
+	}
+
+	if err := r.issuer.issue(ctx, account, account.Email); err != nil {
+		slog.Error("failed to resend email verification",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestResendEmailVerificationCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	cacheClient := new(mocks.MockCache)
+	mailSender := new(mocks.MockMailer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, Email: "test@example.com"}, nil)
+	cacheClient.On("SetNX", mock.Anything, "email_verify:resend:1", mock.Anything, mock.Anything).Return(true, nil)
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(1), models.UserTokenPurposeEmailVerification).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.UserToken")).Return(nil)
+	mailSender.On("Send", mock.Anything, mock.AnythingOfType("*mailer.Message")).Return(nil)
+
+	cmd := NewResendEmailVerificationCommand(&config.ServiceConfig{}, userRepo, userTokenRepo, cacheClient, mailSender)
+	err := cmd.Handle(ctx, &userdto.ResendEmailVerificationReq{})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	userTokenRepo.AssertExpectations(t)
+	cacheClient.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+}
+
+func TestResendEmailVerificationCommand_Handle_RateLimited(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	cacheClient := new(mocks.MockCache)
+	mailSender := new(mocks.MockMailer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, Email: "test@example.com"}, nil)
+	cacheClient.On("SetNX", mock.Anything, "email_verify:resend:1", mock.Anything, mock.Anything).Return(false, nil)
+
+	cmd := NewResendEmailVerificationCommand(&config.ServiceConfig{}, userRepo, new(mocks.MockUserTokenRepository), cacheClient, mailSender)
+	err := cmd.Handle(ctx, &userdto.ResendEmailVerificationReq{})
+
+	require.ErrorIs(t, err, helper.ErrTooManyRequests)
+	mailSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
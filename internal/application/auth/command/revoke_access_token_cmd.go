@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/accesstoken"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IRevokePersonalAccessTokenCommand decorator.CommandHandler[*userdto.RevokePersonalAccessTokenReq]
+
+type revokePersonalAccessTokenCommand struct {
+	tokenRepo accesstoken.Repository
+}
+
+func NewRevokePersonalAccessTokenCommand(tokenRepo accesstoken.Repository) IRevokePersonalAccessTokenCommand {
+	return &revokePersonalAccessTokenCommand{
+		tokenRepo: tokenRepo,
+	}
+}
+
+func (r revokePersonalAccessTokenCommand) Handle(ctx context.Context, req *userdto.RevokePersonalAccessTokenReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	// Tokens owned by someone else are reported as missing by the repository
+	if err := r.tokenRepo.Revoke(ctx, caller.UserID, req.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrPersonalAccessTokenNotFound
+		}
+		slog.Error("failed to revoke personal access token",
+			slog.Uint64("user_id", caller.UserID),
+			slog.Uint64("token_id", req.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
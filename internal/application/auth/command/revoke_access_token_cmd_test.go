@@ -0,0 +1,39 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestRevokePersonalAccessTokenCommand_Handle_Success(t *testing.T) {
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	tokenRepo.On("Revoke", mock.Anything, uint64(1), uint64(7)).Return(nil)
+
+	cmd := NewRevokePersonalAccessTokenCommand(tokenRepo)
+	err := cmd.Handle(ctx, &userdto.RevokePersonalAccessTokenReq{ID: 7})
+
+	require.NoError(t, err)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestRevokePersonalAccessTokenCommand_Handle_NotFound(t *testing.T) {
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	tokenRepo.On("Revoke", mock.Anything, uint64(1), uint64(9)).Return(gorm.ErrRecordNotFound)
+
+	cmd := NewRevokePersonalAccessTokenCommand(tokenRepo)
+	err := cmd.Handle(ctx, &userdto.RevokePersonalAccessTokenReq{ID: 9})
+
+	require.ErrorIs(t, err, helper.ErrPersonalAccessTokenNotFound)
+}
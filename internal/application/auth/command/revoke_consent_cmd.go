@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IRevokeConsentCommand decorator.CommandHandler[*userdto.RevokeConsentReq]
+
+type revokeConsentCommand struct {
+	oauthRepo   oauth.Repository
+	sessionRepo session.Repository
+}
+
+func NewRevokeConsentCommand(oauthRepo oauth.Repository, sessionRepo session.Repository) IRevokeConsentCommand {
+	return &revokeConsentCommand{
+		oauthRepo:   oauthRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// Handle removes the consent and signs the client out, so it has to ask the
+// user again before it can get new tokens.
+func (r revokeConsentCommand) Handle(ctx context.Context, req *userdto.RevokeConsentReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	if err := r.oauthRepo.DeleteConsent(ctx, caller.UserID, req.ClientID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrOAuthConsentNotFound
+		}
+		slog.Error("failed to delete oauth consent",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("client_id", req.ClientID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if _, err := r.sessionRepo.DeactivateByClient(ctx, caller.UserID, req.ClientID); err != nil {
+		slog.Error("failed to deactivate oauth client sessions",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("client_id", req.ClientID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
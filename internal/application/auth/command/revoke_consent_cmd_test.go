@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestRevokeConsentCommand_Handle_DeactivatesClientSessions(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("DeleteConsent", mock.Anything, uint64(1), "partner").Return(nil)
+	sessionRepo.On("DeactivateByClient", mock.Anything, uint64(1), "partner").Return(int64(2), nil)
+
+	cmd := NewRevokeConsentCommand(oauthRepo, sessionRepo)
+	err := cmd.Handle(ctx, &userdto.RevokeConsentReq{ClientID: "partner"})
+
+	require.NoError(t, err)
+	oauthRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestRevokeConsentCommand_Handle_NotFound(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("DeleteConsent", mock.Anything, uint64(1), "partner").Return(gorm.ErrRecordNotFound)
+
+	cmd := NewRevokeConsentCommand(oauthRepo, sessionRepo)
+	err := cmd.Handle(ctx, &userdto.RevokeConsentReq{ClientID: "partner"})
+
+	require.ErrorIs(t, err, helper.ErrOAuthConsentNotFound)
+	sessionRepo.AssertNotCalled(t, "DeactivateByClient", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IRevokeOtherSessionsCommand decorator.CommandReturnHandler[*userdto.RevokeOtherSessionsReq, *userdto.RevokeSessionsRes]
+
+type revokeOtherSessionsCommand struct {
+	sessionRepo session.Repository
+}
+
+func NewRevokeOtherSessionsCommand(sessionRepo session.Repository) IRevokeOtherSessionsCommand {
+	return &revokeOtherSessionsCommand{
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (r revokeOtherSessionsCommand) Handle(ctx context.Context, _ *userdto.RevokeOtherSessionsReq) (*userdto.RevokeSessionsRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	revoked, err := r.sessionRepo.DeactivateAllByUserID(ctx, caller.UserID, caller.SessionID)
+	if err != nil {
+		slog.Error("failed to deactivate other sessions",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("sess_id", caller.SessionID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.RevokeSessionsRes{Revoked: revoked}, nil
+}
@@ -0,0 +1,35 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestRevokeOtherSessionsCommand_Handle_Success(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	sessionRepo.On("DeactivateAllByUserID", mock.Anything, uint64(1), "sess-1").Return(int64(3), nil)
+
+	cmd := NewRevokeOtherSessionsCommand(sessionRepo)
+	res, err := cmd.Handle(ctx, &userdto.RevokeOtherSessionsReq{})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.Revoked)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestRevokeOtherSessionsCommand_Handle_Unauthenticated(t *testing.T) {
+	cmd := NewRevokeOtherSessionsCommand(new(mocks.MockSessionRepository))
+	res, err := cmd.Handle(context.Background(), &userdto.RevokeOtherSessionsReq{})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
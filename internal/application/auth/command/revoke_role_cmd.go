@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/role"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IRevokeRoleCommand decorator.CommandHandler[*userdto.RevokeRoleReq]
+
+type revokeRoleCommand struct {
+	roleRepo   role.Repository
+	authorizer helper.Authorizer
+}
+
+func NewRevokeRoleCommand(roleRepo role.Repository, authorizer helper.Authorizer) IRevokeRoleCommand {
+	return &revokeRoleCommand{
+		roleRepo:   roleRepo,
+		authorizer: authorizer,
+	}
+}
+
+func (r revokeRoleCommand) Handle(ctx context.Context, req *userdto.RevokeRoleReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	roleItem, err := r.roleRepo.FindByName(ctx, req.Role)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrRoleNotFound
+		}
+		slog.Error("failed to find role",
+			slog.String("role", req.Role),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := r.roleRepo.Unassign(ctx, req.UserID, roleItem.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrRoleNotAssigned
+		}
+		slog.Error("failed to revoke role",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("role", req.Role),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	r.authorizer.Invalidate(ctx, req.UserID)
+	slog.Info("role revoked",
+		slog.Uint64("user_id", req.UserID),
+		slog.String("role", req.Role),
+		slog.Uint64("revoked_by", caller.UserID))
+
+	return nil
+}
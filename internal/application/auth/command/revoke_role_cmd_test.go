@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestRevokeRoleCommand_Handle_Success(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	roleRepo.On("FindByName", mock.Anything, "editor").Return(&models.Role{ID: 4, Name: "editor"}, nil)
+	roleRepo.On("Unassign", mock.Anything, uint64(2), uint64(4)).Return(nil)
+	authorizer.On("Invalidate", mock.Anything, uint64(2)).Return()
+
+	cmd := NewRevokeRoleCommand(roleRepo, authorizer)
+	err := cmd.Handle(ctx, &userdto.RevokeRoleReq{UserID: 2, Role: "editor"})
+
+	require.NoError(t, err)
+	roleRepo.AssertExpectations(t)
+	authorizer.AssertExpectations(t)
+}
+
+func TestRevokeRoleCommand_Handle_NotAssigned(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	roleRepo.On("FindByName", mock.Anything, "editor").Return(&models.Role{ID: 4, Name: "editor"}, nil)
+	roleRepo.On("Unassign", mock.Anything, uint64(2), uint64(4)).Return(gorm.ErrRecordNotFound)
+
+	cmd := NewRevokeRoleCommand(roleRepo, authorizer)
+	err := cmd.Handle(ctx, &userdto.RevokeRoleReq{UserID: 2, Role: "editor"})
+
+	require.ErrorIs(t, err, helper.ErrRoleNotAssigned)
+	authorizer.AssertNotCalled(t, "Invalidate", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IRevokeSessionCommand decorator.CommandHandler[*userdto.RevokeSessionReq]
+
+type revokeSessionCommand struct {
+	sessionRepo session.Repository
+}
+
+func NewRevokeSessionCommand(sessionRepo session.Repository) IRevokeSessionCommand {
+	return &revokeSessionCommand{
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (r revokeSessionCommand) Handle(ctx context.Context, req *userdto.RevokeSessionReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	sessionItem, err := r.sessionRepo.FindBySessionID(ctx, req.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrSessionNotFound
+		}
+		slog.Error("failed to find session by id",
+			slog.String("sess_id", req.SessionID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	// Sessions owned by someone else are reported as missing to avoid leaking their existence
+	if sessionItem.UserID != caller.UserID || !sessionItem.IsActive {
+		return helper.ErrSessionNotFound
+	}
+
+	if err := r.sessionRepo.Deactivate(ctx, sessionItem.ID); err != nil {
+		slog.Error("failed to deactivate session",
+			slog.String("sess_id", sessionItem.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
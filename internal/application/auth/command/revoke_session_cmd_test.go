@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestRevokeSessionCommand_Handle_Success(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	sessionRepo.On("FindBySessionID", mock.Anything, "sess-2").
+		Return(&models.Session{ID: "sess-2", UserID: 1, IsActive: true}, nil)
+	sessionRepo.On("Deactivate", mock.Anything, "sess-2").Return(nil)
+
+	cmd := NewRevokeSessionCommand(sessionRepo)
+	err := cmd.Handle(ctx, &userdto.RevokeSessionReq{SessionID: "sess-2"})
+
+	require.NoError(t, err)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestRevokeSessionCommand_Handle_OtherUser(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	sessionRepo.On("FindBySessionID", mock.Anything, "sess-9").
+		Return(&models.Session{ID: "sess-9", UserID: 2, IsActive: true}, nil)
+
+	cmd := NewRevokeSessionCommand(sessionRepo)
+	err := cmd.Handle(ctx, &userdto.RevokeSessionReq{SessionID: "sess-9"})
+
+	require.ErrorIs(t, err, helper.ErrSessionNotFound)
+	sessionRepo.AssertNotCalled(t, "Deactivate", mock.Anything, mock.Anything)
+}
+
+func TestRevokeSessionCommand_Handle_NotFound(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	sessionRepo.On("FindBySessionID", mock.Anything, "missing").
+		Return((*models.Session)(nil), gorm.ErrRecordNotFound)
+
+	cmd := NewRevokeSessionCommand(sessionRepo)
+	err := cmd.Handle(ctx, &userdto.RevokeSessionReq{SessionID: "missing"})
+
+	require.ErrorIs(t, err, helper.ErrSessionNotFound)
+	sessionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type ISendPasswordResetCommand decorator.CommandHandler[*userdto.SendPasswordResetReq]
+
+type sendPasswordResetCommand struct {
+	userRepo          user.Repository
+	securityEventRepo securityevent.Repository
+	issuer            passwordResetIssuer
+}
+
+func NewSendPasswordResetCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	userTokenRepo usertoken.Repository,
+	securityEventRepo securityevent.Repository,
+	mailer mailer.Mailer,
+) ISendPasswordResetCommand {
+	return &sendPasswordResetCommand{
+		userRepo:          userRepo,
+		securityEventRepo: securityEventRepo,
+		issuer: passwordResetIssuer{
+			config:        config,
+			userTokenRepo: userTokenRepo,
+			mailer:        mailer,
+		},
+	}
+}
+
+// Handle emails the user a password reset link on an admin's behalf. Unlike
+// the public endpoint it reports unknown and disabled accounts, and mail
+// failures.
+func (s sendPasswordResetCommand) Handle(ctx context.Context, req *userdto.SendPasswordResetReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	account, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrUserNotFound
+		}
+		slog.Error("failed to find user for password reset",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+	if !account.CanSignIn() {
+		return helper.ErrAccountDisabled
+	}
+
+	if err := s.issuer.issue(ctx, account); err != nil {
+		slog.Error("failed to send password reset email",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := s.securityEventRepo.Create(ctx, &models.SecurityEvent{
+		UserID:  account.ID,
+		ActorID: &caller.UserID,
+		Type:    models.SecurityEventPasswordResetSent,
+	}); err != nil {
+		slog.Error("failed to record password reset event",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestSendPasswordResetCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+	mailSender := new(mocks.MockMailer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).
+		Return(&models.User{ID: 2, Email: "user@example.com", IsActive: true}, nil)
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(2), models.UserTokenPurposePasswordReset).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mailSender.On("Send", mock.Anything, mock.Anything).Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.UserID == 2 && item.Type == models.SecurityEventPasswordResetSent &&
+			item.ActorID != nil && *item.ActorID == 1
+	})).Return(nil)
+
+	cmd := NewSendPasswordResetCommand(&config.ServiceConfig{}, userRepo, userTokenRepo, securityEventRepo, mailSender)
+	err := cmd.Handle(ctx, &userdto.SendPasswordResetReq{UserID: 2})
+
+	require.NoError(t, err)
+	mailSender.AssertExpectations(t)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestSendPasswordResetCommand_Handle_DisabledAccount(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	mailSender := new(mocks.MockMailer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, IsActive: false}, nil)
+
+	cmd := NewSendPasswordResetCommand(&config.ServiceConfig{}, userRepo, new(mocks.MockUserTokenRepository),
+		new(mocks.MockSecurityEventRepository), mailSender)
+	err := cmd.Handle(ctx, &userdto.SendPasswordResetReq{UserID: 2})
+
+	require.ErrorIs(t, err, helper.ErrAccountDisabled)
+	mailSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestSendPasswordResetCommand_Handle_MailFailure(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+	mailSender := new(mocks.MockMailer)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).
+		Return(&models.User{ID: 2, Email: "user@example.com", IsActive: true}, nil)
+	userTokenRepo.On("InvalidateByUser", mock.Anything, uint64(2), models.UserTokenPurposePasswordReset).Return(nil)
+	userTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mailSender.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+	cmd := NewSendPasswordResetCommand(&config.ServiceConfig{}, userRepo, userTokenRepo, securityEventRepo, mailSender)
+	err := cmd.Handle(ctx, &userdto.SendPasswordResetReq{UserID: 2})
+
+	require.Error(t, err)
+	securityEventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
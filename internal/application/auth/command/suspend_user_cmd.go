@@ -0,0 +1,56 @@
+package command
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type ISuspendUserCommand decorator.CommandHandler[*userdto.SuspendUserReq]
+
+type suspendUserCommand struct {
+	changer accountStatusChanger
+}
+
+func NewSuspendUserCommand(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	securityEventRepo securityevent.Repository,
+	cacheClient cache.Cache,
+) ISuspendUserCommand {
+	return &suspendUserCommand{
+		changer: accountStatusChanger{
+			userRepo:          userRepo,
+			sessionRepo:       sessionRepo,
+			securityEventRepo: securityEventRepo,
+			cache:             cacheClient,
+			states:            helper.AccountStates{Cache: cacheClient},
+			tokenCacheTTL:     helper.TokenCacheTTL(config),
+		},
+	}
+}
+
+// Handle disables the account and signs it out everywhere. Personal access
+// tokens are kept but rejected until the account is reactivated.
+func (s suspendUserCommand) Handle(ctx context.Context, req *userdto.SuspendUserReq) error {
+	caller, account, err := s.changer.load(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	account.IsActive = false
+	if err := s.changer.save(ctx, account, caller, models.SecurityEventAccountSuspended, req.Reason); err != nil {
+		return err
+	}
+
+	return s.changer.signOut(ctx, account.ID)
+}
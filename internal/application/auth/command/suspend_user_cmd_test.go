@@ -0,0 +1,83 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestSuspendUserCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, IsActive: true}, nil)
+	userRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return !item.IsActive && item.StatusReason == "spam" && item.StatusChangedBy != nil && *item.StatusChangedBy == 1
+	})).Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.UserID == 2 && item.Type == models.SecurityEventAccountSuspended &&
+			item.ActorID != nil && *item.ActorID == 1 && item.Details == "spam"
+	})).Return(nil)
+	sessionRepo.On("DeactivateAllByUserID", mock.Anything, uint64(2), "").Return(int64(3), nil)
+
+	cacheClient := statusCache(t)
+	cmd := NewSuspendUserCommand(&config.ServiceConfig{}, userRepo, sessionRepo, securityEventRepo, cacheClient)
+	err := cmd.Handle(ctx, &userdto.SuspendUserReq{UserID: 2, Reason: "spam"})
+
+	require.NoError(t, err)
+	cacheClient.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestSuspendUserCommand_Handle_Self(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	cmd := NewSuspendUserCommand(&config.ServiceConfig{}, userRepo, new(mocks.MockSessionRepository), new(mocks.MockSecurityEventRepository), statusCache(t))
+	err := cmd.Handle(ctx, &userdto.SuspendUserReq{UserID: 1, Reason: "oops"})
+
+	require.ErrorIs(t, err, helper.ErrCannotChangeOwnStatus)
+	userRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestSuspendUserCommand_Handle_NotFound(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(9)).Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	cmd := NewSuspendUserCommand(&config.ServiceConfig{}, userRepo, new(mocks.MockSessionRepository), new(mocks.MockSecurityEventRepository), statusCache(t))
+	err := cmd.Handle(ctx, &userdto.SuspendUserReq{UserID: 9, Reason: "spam"})
+
+	require.ErrorIs(t, err, helper.ErrUserNotFound)
+}
+
+// statusCache accepts the new account state and the marker that expires
+// cached verifications of the changed account.
+func statusCache(t *testing.T) *mocks.MockCache {
+	t.Helper()
+
+	cacheClient := new(mocks.MockCache)
+	cacheClient.On("Set", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "user:auth-state:")
+	}), mock.Anything, helper.AccountStateTTL).Return(nil)
+	cacheClient.On("Set", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "token:stale:user:")
+	}), mock.Anything, helper.TokenCacheTTL(&config.ServiceConfig{})).Return(nil)
+	return cacheClient
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IUnlinkIdentityCommand decorator.CommandHandler[*userdto.UnlinkIdentityReq]
+
+type unlinkIdentityCommand struct {
+	identityRepo identity.Repository
+}
+
+func NewUnlinkIdentityCommand(identityRepo identity.Repository) IUnlinkIdentityCommand {
+	return &unlinkIdentityCommand{
+		identityRepo: identityRepo,
+	}
+}
+
+func (u unlinkIdentityCommand) Handle(ctx context.Context, req *userdto.UnlinkIdentityReq) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return helper.ErrInvalidToken
+	}
+
+	// Identities owned by someone else are reported as missing by the repository
+	err := u.identityRepo.DeleteByID(ctx, caller.UserID, req.ID)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ErrIdentityNotFound
+	case errors.Is(err, identity.ErrLastLoginMethod):
+		return helper.ErrLastLoginMethod
+	default:
+		slog.Error("failed to unlink identity",
+			slog.Uint64("user_id", caller.UserID),
+			slog.Uint64("identity_id", req.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+}
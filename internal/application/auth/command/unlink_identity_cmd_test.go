@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestUnlinkIdentityCommand_Handle_Success(t *testing.T) {
+	identityRepo := new(mocks.MockIdentityRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	identityRepo.On("DeleteByID", mock.Anything, uint64(1), uint64(3)).Return(nil)
+
+	cmd := NewUnlinkIdentityCommand(identityRepo)
+	err := cmd.Handle(ctx, &userdto.UnlinkIdentityReq{ID: 3})
+
+	require.NoError(t, err)
+	identityRepo.AssertExpectations(t)
+}
+
+func TestUnlinkIdentityCommand_Handle_LastLoginMethod(t *testing.T) {
+	identityRepo := new(mocks.MockIdentityRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	identityRepo.On("DeleteByID", mock.Anything, uint64(1), uint64(3)).Return(identity.ErrLastLoginMethod)
+
+	cmd := NewUnlinkIdentityCommand(identityRepo)
+	err := cmd.Handle(ctx, &userdto.UnlinkIdentityReq{ID: 3})
+
+	require.ErrorIs(t, err, helper.ErrLastLoginMethod)
+}
+
+func TestUnlinkIdentityCommand_Handle_NotFound(t *testing.T) {
+	identityRepo := new(mocks.MockIdentityRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	identityRepo.On("DeleteByID", mock.Anything, uint64(1), uint64(9)).Return(gorm.ErrRecordNotFound)
+
+	cmd := NewUnlinkIdentityCommand(identityRepo)
+	err := cmd.Handle(ctx, &userdto.UnlinkIdentityReq{ID: 9})
+
+	require.ErrorIs(t, err, helper.ErrIdentityNotFound)
+}
+
+func TestUnlinkIdentityCommand_Handle_Unauthenticated(t *testing.T) {
+	cmd := NewUnlinkIdentityCommand(new(mocks.MockIdentityRepository))
+	err := cmd.Handle(context.Background(), &userdto.UnlinkIdentityReq{ID: 3})
+
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IUpdateUserCommand decorator.CommandHandler[*userdto.UpdateUserReq]
+
+type updateUserCommand struct {
+	userRepo user.Repository
+}
+
+func NewUpdateUserCommand(userRepo user.Repository) IUpdateUserCommand {
+	return &updateUserCommand{
+		userRepo: userRepo,
+	}
+}
+
+func (u updateUserCommand) Handle(ctx context.Context, req *userdto.UpdateUserReq) error {
+	account, err := u.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrUserNotFound
+		}
+		slog.Error("failed to find user for update",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+	if account.IsDeleted() {
+		return helper.ErrUserNotFound
+	}
+
+	account.FirstName = strings.TrimSpace(req.FirstName)
+	account.LastName = strings.TrimSpace(req.LastName)
+	if err := u.userRepo.UpdateProfile(ctx, account); err != nil {
+		slog.Error("failed to update user",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestUpdateUserCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	userRepo.On("FindByID", mock.Anything, uint64(2)).
+		Return(&models.User{ID: 2, FirstName: "Old", LastName: "Name", IsActive: true}, nil)
+	userRepo.On("UpdateProfile", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return item.FirstName == "Jane" && item.LastName == "Doe"
+	})).Return(nil)
+
+	cmd := NewUpdateUserCommand(userRepo)
+	err := cmd.Handle(context.Background(), &userdto.UpdateUserReq{UserID: 2, FirstName: " Jane ", LastName: "Doe"})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+}
+
+func TestUpdateUserCommand_Handle_DeletedUser(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, DeletedAt: new(time.Now())}, nil)
+
+	cmd := NewUpdateUserCommand(userRepo)
+	err := cmd.Handle(context.Background(), &userdto.UpdateUserReq{UserID: 2, FirstName: "Jane", LastName: "Doe"})
+
+	require.ErrorIs(t, err, helper.ErrUserNotFound)
+	userRepo.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IVerifyEmailCommand decorator.CommandHandler[*userdto.VerifyEmailReq]
+
+type verifyEmailCommand struct {
+	userRepo      user.Repository
+	userTokenRepo usertoken.Repository
+}
+
+func NewVerifyEmailCommand(userRepo user.Repository, userTokenRepo usertoken.Repository) IVerifyEmailCommand {
+	return &verifyEmailCommand{
+		userRepo:      userRepo,
+		userTokenRepo: userTokenRepo,
+	}
+}
+
+func (v verifyEmailCommand) Handle(ctx context.Context, req *userdto.VerifyEmailReq) error {
+	token, err := v.userTokenRepo.Consume(ctx, models.UserTokenPurposeEmailVerification, security.HashOpaqueToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrInvalidVerificationToken
+		}
+		slog.Error("failed to consume email verification token", slog.String("error", err.Error()))
+		return err
+	}
+
+	account, err := v.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ErrInvalidVerificationToken
+		}
+		slog.Error("failed to find user for email verification",
+			slog.Uint64("user_id", token.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	// The token confirms a pending email change; the new address takes effect
+	// only now that its owner has proven control of it
+	if account.Email != token.Target {
+		if _, err := v.userRepo.FindByEmail(ctx, token.Target); err == nil {
+			return helper.ErrEmailAlreadyInUse
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		account.Email = token.Target
+	} else if account.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	account.EmailVerifiedAt = new(time.Now())
+	if err := v.userRepo.UpdateEmail(ctx, account); err != nil {
+		slog.Error("failed to confirm email address",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestVerifyEmailCommand_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+
+	userTokenRepo.On("Consume", mock.Anything, models.UserTokenPurposeEmailVerification, security.HashOpaqueToken("verify-token")).
+		Return(&models.UserToken{UserID: 1, Target: "test@example.com"}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, Email: "test@example.com"}, nil)
+	userRepo.On("UpdateEmail", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return item.EmailVerifiedAt != nil
+	})).Return(nil)
+
+	cmd := NewVerifyEmailCommand(userRepo, userTokenRepo)
+	err := cmd.Handle(context.Background(), &userdto.VerifyEmailReq{Token: "verify-token"})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	userTokenRepo.AssertExpectations(t)
+}
+
+func TestVerifyEmailCommand_Handle_ConfirmsEmailChange(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+
+	userTokenRepo.On("Consume", mock.Anything, models.UserTokenPurposeEmailVerification, mock.Anything).
+		Return(&models.UserToken{UserID: 1, Target: "new@example.com"}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, Email: "old@example.com", EmailVerifiedAt: new(time.Now())}, nil)
+	userRepo.On("FindByEmail", mock.Anything, "new@example.com").
+		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	userRepo.On("UpdateEmail", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return item.Email == "new@example.com" && item.EmailVerifiedAt != nil
+	})).Return(nil)
+
+	cmd := NewVerifyEmailCommand(userRepo, userTokenRepo)
+	err := cmd.Handle(context.Background(), &userdto.VerifyEmailReq{Token: "verify-token"})
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+}
+
+func TestVerifyEmailCommand_Handle_EmailChangeAddressTaken(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userTokenRepo := new(mocks.MockUserTokenRepository)
+
+	userTokenRepo.On("Consume", mock.Anything, models.UserTokenPurposeEmailVerification, mock.Anything).
+		Return(&models.UserToken{UserID: 1, Target: "new@example.com"}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).
+		Return(&models.User{ID: 1, Email: "old@example.com"}, nil)
+	userRepo.On("FindByEmail", mock.Anything, "new@example.com").
+		Return(&models.User{ID: 2, Email: "new@example.com"}, nil)
+
+	cmd := NewVerifyEmailCommand(userRepo, userTokenRepo)
+	err := cmd.Handle(context.Background(), &userdto.VerifyEmailReq{Token: "verify-token"})
+
+	require.ErrorIs(t, err, helper.ErrEmailAlreadyInUse)
+	userRepo.AssertNotCalled(t, "UpdateEmail", mock.Anything, mock.Anything)
+}
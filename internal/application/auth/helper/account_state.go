@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+)
+
+// AccountStateTTL bounds how long a cached account state is trusted should
+// storing a change ever fail.
+const AccountStateTTL = 5 * time.Minute
+
+// AccountState is what access token verification needs to know about an
+// account, cached so that verifying a token does not read the user row.
+type AccountState struct {
+	CanSignIn         bool       `json:"can_sign_in"`
+	PasswordChangedAt *time.Time `json:"password_changed_at,omitempty"`
+}
+
+func AccountStateKey(userID uint64) string {
+	return "user:auth-state:" + strconv.FormatUint(userID, 10)
+}
+
+// AccountStates caches AccountState per user. Changes overwrite the entry
+// while fills from the database only create it, so a fill racing a change
+// can never bring back the old state.
+type AccountStates struct {
+	Cache cache.Cache
+}
+
+// Load returns the cached state of userID; ok is false on a miss or error.
+func (s AccountStates) Load(ctx context.Context, userID uint64) (state *AccountState, ok bool) {
+	raw, err := s.Cache.Get(ctx, AccountStateKey(userID))
+	if err != nil {
+		return nil, false
+	}
+
+	state = new(AccountState)
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, false
+	}
+	return state, true
+}
+
+// Fill caches the state of an account read from the database unless a
+// change stored a newer one meanwhile. Failures only cost a database read
+// next time, so they are logged.
+func (s AccountStates) Fill(ctx context.Context, account *models.User) {
+	raw, err := json.Marshal(NewAccountState(account))
+	if err == nil {
+		_, err = s.Cache.SetNX(ctx, AccountStateKey(account.ID), raw, AccountStateTTL)
+	}
+	if err != nil {
+		slog.Error("failed to cache account state",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// Store replaces the cached state after the status or password of account
+// changed.
+func (s AccountStates) Store(ctx context.Context, account *models.User) error {
+	raw, err := json.Marshal(NewAccountState(account))
+	if err != nil {
+		return err
+	}
+	return s.Cache.Set(ctx, AccountStateKey(account.ID), raw, AccountStateTTL)
+}
+
+// NewAccountState returns the current state of account.
+func NewAccountState(account *models.User) *AccountState {
+	return &AccountState{
+		CanSignIn:         account.CanSignIn(),
+		PasswordChangedAt: account.PasswordChangedAt,
+	}
+}
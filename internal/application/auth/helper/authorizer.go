@@ -0,0 +1,221 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/role"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+)
+
+const defaultRBACCacheTTL = 5 * time.Minute
+
+const (
+	// PermissionManageRoles allows listing roles and changing user assignments.
+	PermissionManageRoles = "roles:manage"
+	// PermissionManageUsers allows listing, editing, signing out, suspending
+	// and deleting other accounts.
+	PermissionManageUsers = "users:manage"
+)
+
+const (
+	// ScopeTasksRead allows reading the caller's task groups and tasks.
+	ScopeTasksRead = "tasks:read"
+	// ScopeTasksWrite allows changing the caller's task groups and tasks.
+	ScopeTasksWrite = "tasks:write"
+)
+
+// OwnerScopes only reach the caller's own data, so any user may delegate them
+// to a token without holding a role that grants them.
+var OwnerScopes = []string{ScopeTasksRead, ScopeTasksWrite}
+
+// Grants are the roles of a user and the permissions they carry.
+type Grants struct {
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// Has reports whether the grants include permission, either exactly, through
+// a "<resource>:*" wildcard or through "*".
+func (g *Grants) Has(permission string) bool {
+	return matchPermission(g.Permissions, permission)
+}
+
+func matchPermission(granted []string, permission string) bool {
+	for _, p := range granted {
+		if p == models.PermissionAll || p == permission {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(p, "*"); ok && strings.HasPrefix(permission, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authorizer resolves the grants of users and enforces permissions.
+//
+// Grants are read from the database rather than from the role claims of the
+// access token, so a revoked role stops working as soon as Invalidate runs
+// instead of when the token expires.
+type Authorizer interface {
+	Grants(ctx context.Context, userID uint64) (*Grants, error)
+	// Require returns ErrForbidden unless the principal in ctx holds permission.
+	// Scoped callers also need the permission in their scopes.
+	Require(ctx context.Context, permission string) error
+	// RequireScope returns ErrForbidden when the principal in ctx is scoped
+	// and scope is not among its scopes. Session callers always pass.
+	RequireScope(ctx context.Context, scope string) error
+	// Invalidate replaces the cached grants of a user after their roles change.
+	Invalidate(ctx context.Context, userID uint64)
+}
+
+type authorizer struct {
+	roleRepo role.Repository
+	cache    cache.Cache
+	ttl      time.Duration
+}
+
+func NewAuthorizer(cfg *config.ServiceConfig, roleRepo role.Repository, cacheClient cache.Cache) Authorizer {
+	ttl := cfg.Auth.RBACCacheTTL
+	if ttl <= 0 {
+		ttl = defaultRBACCacheTTL
+	}
+
+	return &authorizer{
+		roleRepo: roleRepo,
+		cache:    cacheClient,
+		ttl:      ttl,
+	}
+}
+
+func grantsKey(userID uint64) string {
+	return "rbac:user:" + strconv.FormatUint(userID, 10)
+}
+
+// Grants fills the cache with SetNX, so grants read before a role change
+// cannot replace the ones Invalidate stored after it.
+func (a authorizer) Grants(ctx context.Context, userID uint64) (*Grants, error) {
+	key := grantsKey(userID)
+	if raw, err := a.cache.Get(ctx, key); err == nil {
+		cached := new(Grants)
+		if err := json.Unmarshal(raw, cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	grants, err := a.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(grants)
+	if err == nil {
+		_, err = a.cache.SetNX(ctx, key, raw, a.ttl)
+	}
+	if err != nil {
+		slog.Error("failed to cache user grants",
+			slog.Uint64("user_id", userID),
+			slog.String("error", err.Error()))
+	}
+
+	return grants, nil
+}
+
+// load reads the roles of a user and the permissions they carry.
+func (a authorizer) load(ctx context.Context, userID uint64) (*Grants, error) {
+	roles, err := a.roleRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := &Grants{
+		Roles:       make([]string, 0, len(roles)),
+		Permissions: make([]string, 0),
+	}
+	for _, item := range roles {
+		grants.Roles = append(grants.Roles, item.Name)
+		for _, p := range item.Permissions {
+			if !slices.Contains(grants.Permissions, p.Name) {
+				grants.Permissions = append(grants.Permissions, p.Name)
+			}
+		}
+	}
+	slices.Sort(grants.Permissions)
+
+	return grants, nil
+}
+
+func (a authorizer) Require(ctx context.Context, permission string) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return ErrInvalidToken
+	}
+
+	grants, err := a.Grants(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to load user grants",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return err
+	}
+	if !grants.Has(permission) || (caller.Scoped() && !matchPermission(caller.Scopes, permission)) {
+		slog.Warn("permission denied",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("permission", permission))
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func (a authorizer) RequireScope(ctx context.Context, scope string) error {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return ErrInvalidToken
+	}
+
+	if caller.Scoped() && !matchPermission(caller.Scopes, scope) {
+		slog.Warn("scope denied",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("scope", scope))
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// Invalidate stores the new grants rather than dropping the entry, so a fill
+// that read the old roles finds the key taken. When they cannot be loaded the
+// entry is dropped instead.
+func (a authorizer) Invalidate(ctx context.Context, userID uint64) {
+	key := grantsKey(userID)
+	grants, err := a.load(ctx, userID)
+	if err == nil {
+		var raw []byte
+		if raw, err = json.Marshal(grants); err == nil {
+			err = a.cache.Set(ctx, key, raw, a.ttl)
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	slog.Error("failed to refresh user grants",
+		slog.Uint64("user_id", userID),
+		slog.String("error", err.Error()))
+	if err := a.cache.Delete(ctx, key); err != nil {
+		slog.Error("failed to invalidate user grants",
+			slog.Uint64("user_id", userID),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,131 @@
+package helper_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestGrants_Has(t *testing.T) {
+	grants := &helper.Grants{Permissions: []string{"tasks:*", "roles:read"}}
+
+	require.True(t, grants.Has("tasks:admin"))
+	require.True(t, grants.Has("roles:read"))
+	require.False(t, grants.Has("roles:manage"))
+	require.False(t, grants.Has("tasksx:admin"))
+	require.True(t, (&helper.Grants{Permissions: []string{models.PermissionAll}}).Has("roles:manage"))
+}
+
+func TestAuthorizer_Grants_LoadsAndCaches(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	cacheClient := new(mocks.MockCache)
+
+	cacheClient.On("Get", mock.Anything, "rbac:user:2").Return(nil, redis.Nil)
+	roleRepo.On("FindByUserID", mock.Anything, uint64(2)).Return([]*models.Role{
+		{Name: "editor", Permissions: []*models.Permission{{Name: "tasks:write"}, {Name: "tasks:read"}}},
+		{Name: "viewer", Permissions: []*models.Permission{{Name: "tasks:read"}}},
+	}, nil)
+	cacheClient.On("SetNX", mock.Anything, "rbac:user:2", mock.Anything, mock.Anything).Return(true, nil)
+
+	authorizer := helper.NewAuthorizer(&config.ServiceConfig{}, roleRepo, cacheClient)
+	grants, err := authorizer.Grants(context.Background(), 2)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"editor", "viewer"}, grants.Roles)
+	require.Equal(t, []string{"tasks:read", "tasks:write"}, grants.Permissions)
+	cacheClient.AssertExpectations(t)
+}
+
+func TestAuthorizer_Require_UsesCache(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	cacheClient := new(mocks.MockCache)
+
+	raw, err := json.Marshal(&helper.Grants{Roles: []string{"viewer"}, Permissions: []string{"tasks:read"}})
+	require.NoError(t, err)
+	cacheClient.On("Get", mock.Anything, "rbac:user:2").Return(raw, nil)
+
+	authorizer := helper.NewAuthorizer(&config.ServiceConfig{}, roleRepo, cacheClient)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 2})
+
+	require.NoError(t, authorizer.Require(ctx, "tasks:read"))
+	require.ErrorIs(t, authorizer.Require(ctx, "tasks:admin"), helper.ErrForbidden)
+	roleRepo.AssertNotCalled(t, "FindByUserID", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizer_Require_PersonalAccessTokenScopes(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+
+	raw, err := json.Marshal(&helper.Grants{Permissions: []string{"*"}})
+	require.NoError(t, err)
+	cacheClient.On("Get", mock.Anything, "rbac:user:2").Return(raw, nil)
+
+	authorizer := helper.NewAuthorizer(&config.ServiceConfig{}, new(mocks.MockRoleRepository), cacheClient)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 2, TokenID: 7, Scopes: []string{"tasks:read"}})
+
+	require.NoError(t, authorizer.Require(ctx, "tasks:read"))
+	require.ErrorIs(t, authorizer.Require(ctx, "roles:manage"), helper.ErrForbidden)
+}
+
+func TestAuthorizer_Require_NoPrincipal(t *testing.T) {
+	authorizer := helper.NewAuthorizer(&config.ServiceConfig{}, new(mocks.MockRoleRepository), new(mocks.MockCache))
+
+	err := authorizer.Require(context.Background(), "tasks:read")
+
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
+
+func TestAuthorizer_RequireScope(t *testing.T) {
+	authorizer := helper.NewAuthorizer(&config.ServiceConfig{}, new(mocks.MockRoleRepository), new(mocks.MockCache))
+
+	session := principal.NewContext(context.Background(), &principal.Principal{UserID: 2, SessionID: "sess-1"})
+	scoped := principal.NewContext(context.Background(), &principal.Principal{UserID: 2, TokenID: 7, Scopes: []string{helper.ScopeTasksRead}})
+	unscoped := principal.NewContext(context.Background(), &principal.Principal{UserID: 2, ClientID: "cli"})
+
+	require.NoError(t, authorizer.RequireScope(session, helper.ScopeTasksWrite))
+	require.NoError(t, authorizer.RequireScope(scoped, helper.ScopeTasksRead))
+	require.ErrorIs(t, authorizer.RequireScope(scoped, helper.ScopeTasksWrite), helper.ErrForbidden)
+	require.ErrorIs(t, authorizer.RequireScope(unscoped, helper.ScopeTasksRead), helper.ErrForbidden)
+	require.ErrorIs(t, authorizer.RequireScope(context.Background(), helper.ScopeTasksRead), helper.ErrInvalidToken)
+}
+
+func TestAuthorizer_Invalidate_StoresNewGrants(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	cacheClient := new(mocks.MockCache)
+
+	roleRepo.On("FindByUserID", mock.Anything, uint64(2)).Return([]*models.Role{
+		{Name: "viewer", Permissions: []*models.Permission{{Name: "tasks:read"}}},
+	}, nil)
+	cacheClient.On("Set", mock.Anything, "rbac:user:2", mock.MatchedBy(func(raw []byte) bool {
+		grants := new(helper.Grants)
+		return json.Unmarshal(raw, grants) == nil && grants.Has("tasks:read") && !grants.Has("tasks:write")
+	}), mock.Anything).Return(nil)
+
+	authorizer := helper.NewAuthorizer(&config.ServiceConfig{}, roleRepo, cacheClient)
+	authorizer.Invalidate(context.Background(), 2)
+
+	cacheClient.AssertExpectations(t)
+	cacheClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizer_Invalidate_DropsEntryWhenLoadFails(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	cacheClient := new(mocks.MockCache)
+
+	roleRepo.On("FindByUserID", mock.Anything, uint64(2)).Return(nil, errors.New("db down"))
+	cacheClient.On("Delete", mock.Anything, "rbac:user:2").Return(nil)
+
+	authorizer := helper.NewAuthorizer(&config.ServiceConfig{}, roleRepo, cacheClient)
+	authorizer.Invalidate(context.Background(), 2)
+
+	cacheClient.AssertExpectations(t)
+}
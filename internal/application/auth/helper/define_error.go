@@ -39,4 +39,332 @@ var (
 		HTTPStatus: http.StatusNotFound,
 		GRPCCode:   codes.NotFound,
 	}
+
+	ErrSessionNotFound = &svcerr.Error{
+		Message:    "Session not found",
+		VIMessage:  "Phiên đăng nhập không tồn tại",
+		Code:       "AUTH-005",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrRefreshTokenReused = &svcerr.Error{
+		Message:    "Refresh token reuse detected, please sign in again",
+		VIMessage:  "Phát hiện refresh token bị sử dụng lại, vui lòng đăng nhập lại",
+		Code:       "AUTH-006",
+		HTTPStatus: http.StatusUnauthorized,
+		GRPCCode:   codes.Unauthenticated,
+	}
+
+	ErrInvalidResetToken = &svcerr.Error{
+		Message:    "Password reset token is invalid or expired",
+		VIMessage:  "Mã đặt lại mật khẩu không hợp lệ hoặc đã hết hạn",
+		Code:       "AUTH-007",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrEmailNotVerified = &svcerr.Error{
+		Message:    "Email address is not verified",
+		VIMessage:  "Địa chỉ email chưa được xác minh",
+		Code:       "AUTH-008",
+		HTTPStatus: http.StatusForbidden,
+		GRPCCode:   codes.PermissionDenied,
+	}
+
+	ErrInvalidVerificationToken = &svcerr.Error{
+		Message:    "Email verification token is invalid or expired",
+		VIMessage:  "Mã xác minh email không hợp lệ hoặc đã hết hạn",
+		Code:       "AUTH-009",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrTooManyRequests = &svcerr.Error{
+		Message:    "Too many requests, please try again later",
+		VIMessage:  "Quá nhiều yêu cầu, vui lòng thử lại sau",
+		Code:       "AUTH-010",
+		HTTPStatus: http.StatusTooManyRequests,
+		GRPCCode:   codes.ResourceExhausted,
+	}
+
+	ErrEmailAlreadyInUse = &svcerr.Error{
+		Message:    "Email address is already in use",
+		VIMessage:  "Địa chỉ email đã được sử dụng",
+		Code:       "AUTH-011",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.AlreadyExists,
+	}
+
+	ErrMFAAlreadyEnabled = &svcerr.Error{
+		Message:    "Two-factor authentication is already enabled",
+		VIMessage:  "Xác thực hai yếu tố đã được bật",
+		Code:       "AUTH-012",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.AlreadyExists,
+	}
+
+	ErrMFANotEnrolled = &svcerr.Error{
+		Message:    "Two-factor authentication is not enabled",
+		VIMessage:  "Xác thực hai yếu tố chưa được bật",
+		Code:       "AUTH-013",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.FailedPrecondition,
+	}
+
+	ErrInvalidMFACode = &svcerr.Error{
+		Message:    "Invalid two-factor authentication code",
+		VIMessage:  "Mã xác thực hai yếu tố không hợp lệ",
+		Code:       "AUTH-014",
+		HTTPStatus: http.StatusUnauthorized,
+		GRPCCode:   codes.Unauthenticated,
+	}
+
+	ErrInvalidMFAChallenge = &svcerr.Error{
+		Message:    "Two-factor challenge is invalid or expired",
+		VIMessage:  "Phiên xác thực hai yếu tố không hợp lệ hoặc đã hết hạn",
+		Code:       "AUTH-015",
+		HTTPStatus: http.StatusUnauthorized,
+		GRPCCode:   codes.Unauthenticated,
+	}
+
+	ErrLoginLocked = &svcerr.Error{
+		Message:    "Too many failed login attempts, please try again later",
+		VIMessage:  "Đăng nhập sai quá nhiều lần, vui lòng thử lại sau",
+		Code:       "AUTH-016",
+		HTTPStatus: http.StatusTooManyRequests,
+		GRPCCode:   codes.ResourceExhausted,
+	}
+
+	ErrUnknownOIDCProvider = &svcerr.Error{
+		Message:    "Unknown identity provider",
+		VIMessage:  "Nhà cung cấp danh tính không xác định",
+		Code:       "AUTH-017",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrIdentityAlreadyLinked = &svcerr.Error{
+		Message:    "This external identity is already linked to an account",
+		VIMessage:  "Danh tính bên ngoài này đã được liên kết với một tài khoản",
+		Code:       "AUTH-018",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.AlreadyExists,
+	}
+
+	ErrLastLoginMethod = &svcerr.Error{
+		Message:    "Cannot remove the last way to sign in to this account",
+		VIMessage:  "Không thể xóa phương thức đăng nhập cuối cùng của tài khoản",
+		Code:       "AUTH-019",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.FailedPrecondition,
+	}
+
+	ErrIdentityNotFound = &svcerr.Error{
+		Message:    "Linked identity not found",
+		VIMessage:  "Không tìm thấy danh tính đã liên kết",
+		Code:       "AUTH-020",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrInvalidCurrentPassword = &svcerr.Error{
+		Message:    "Current password is incorrect",
+		VIMessage:  "Mật khẩu hiện tại không đúng",
+		Code:       "AUTH-021",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrPasswordLength = &svcerr.Error{
+		Message:    "Password length is outside the allowed range",
+		VIMessage:  "Độ dài mật khẩu không nằm trong giới hạn cho phép",
+		Code:       "AUTH-022",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrPasswordCharClasses = &svcerr.Error{
+		Message:    "Password must include the required character types",
+		VIMessage:  "Mật khẩu phải bao gồm các loại ký tự bắt buộc",
+		Code:       "AUTH-023",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrPasswordCommon = &svcerr.Error{
+		Message:    "Password is too common",
+		VIMessage:  "Mật khẩu quá phổ biến",
+		Code:       "AUTH-024",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrPasswordContainsUserInfo = &svcerr.Error{
+		Message:    "Password must not contain your username or email",
+		VIMessage:  "Mật khẩu không được chứa tên đăng nhập hoặc email",
+		Code:       "AUTH-025",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrInvalidClient = &svcerr.Error{
+		Message:    "Invalid client credentials",
+		VIMessage:  "Thông tin xác thực client không hợp lệ",
+		Code:       "AUTH-026",
+		HTTPStatus: http.StatusUnauthorized,
+		GRPCCode:   codes.Unauthenticated,
+	}
+
+	ErrForbidden = &svcerr.Error{
+		Message:    "You do not have permission to perform this action",
+		VIMessage:  "Bạn không có quyền thực hiện thao tác này",
+		Code:       "AUTH-027",
+		HTTPStatus: http.StatusForbidden,
+		GRPCCode:   codes.PermissionDenied,
+	}
+
+	ErrRoleNotFound = &svcerr.Error{
+		Message:    "Role not found",
+		VIMessage:  "Không tìm thấy vai trò",
+		Code:       "AUTH-028",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrRoleNotAssigned = &svcerr.Error{
+		Message:    "User does not have this role",
+		VIMessage:  "Người dùng không có vai trò này",
+		Code:       "AUTH-029",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrPersonalAccessTokenNotFound = &svcerr.Error{
+		Message:    "Personal access token not found",
+		VIMessage:  "Không tìm thấy token truy cập cá nhân",
+		Code:       "AUTH-030",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrInvalidTokenScope = &svcerr.Error{
+		Message:    "Token scopes must be permissions you hold",
+		VIMessage:  "Phạm vi của token phải là quyền bạn đang có",
+		Code:       "AUTH-031",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrSessionRequired = &svcerr.Error{
+		Message:    "This action requires signing in directly, personal access tokens and OAuth client tokens are not accepted",
+		VIMessage:  "Thao tác này yêu cầu đăng nhập trực tiếp, không chấp nhận token truy cập cá nhân hoặc token của ứng dụng OAuth",
+		Code:       "AUTH-032",
+		HTTPStatus: http.StatusForbidden,
+		GRPCCode:   codes.PermissionDenied,
+	}
+
+	ErrInvalidTokenExpiry = &svcerr.Error{
+		Message:    "Token expiry exceeds the allowed maximum",
+		VIMessage:  "Thời hạn token vượt quá mức tối đa cho phép",
+		Code:       "AUTH-033",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrOAuthInvalidRequest = &svcerr.Error{
+		Message:    "Authorization request is missing or has an invalid parameter",
+		VIMessage:  "Yêu cầu ủy quyền thiếu hoặc có tham số không hợp lệ",
+		Code:       "AUTH-034",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrOAuthInvalidRedirectURI = &svcerr.Error{
+		Message:    "Redirect URI is not registered for this client",
+		VIMessage:  "Redirect URI chưa được đăng ký cho client này",
+		Code:       "AUTH-035",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrOAuthInvalidGrant = &svcerr.Error{
+		Message:    "Authorization grant is invalid, expired or already used",
+		VIMessage:  "Mã ủy quyền không hợp lệ, đã hết hạn hoặc đã được sử dụng",
+		Code:       "AUTH-036",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrOAuthUnsupportedGrantType = &svcerr.Error{
+		Message:    "Grant type is not supported",
+		VIMessage:  "Loại cấp quyền không được hỗ trợ",
+		Code:       "AUTH-037",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrOAuthUnsupportedResponseType = &svcerr.Error{
+		Message:    "Response type is not supported",
+		VIMessage:  "Loại phản hồi không được hỗ trợ",
+		Code:       "AUTH-038",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrOAuthInvalidScope = &svcerr.Error{
+		Message:    "Requested scope is not allowed for this client",
+		VIMessage:  "Phạm vi yêu cầu không được phép cho client này",
+		Code:       "AUTH-039",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrOAuthConsentNotFound = &svcerr.Error{
+		Message:    "Consent not found",
+		VIMessage:  "Không tìm thấy ủy quyền đã cấp",
+		Code:       "AUTH-040",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrInvalidCSRFToken = &svcerr.Error{
+		Message:    "Missing or invalid CSRF token",
+		VIMessage:  "Thiếu hoặc sai CSRF token",
+		Code:       "AUTH-041",
+		HTTPStatus: http.StatusForbidden,
+		GRPCCode:   codes.PermissionDenied,
+	}
+
+	ErrAccountDisabled = &svcerr.Error{
+		Message:    "Account is suspended or has been deleted",
+		VIMessage:  "Tài khoản đã bị tạm khóa hoặc đã bị xóa",
+		Code:       "AUTH-042",
+		HTTPStatus: http.StatusForbidden,
+		GRPCCode:   codes.PermissionDenied,
+	}
+
+	ErrCannotChangeOwnStatus = &svcerr.Error{
+		Message:    "You cannot suspend or delete your own account",
+		VIMessage:  "Bạn không thể tạm khóa hoặc xóa tài khoản của chính mình",
+		Code:       "AUTH-043",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrInvalidOIDCNonce = &svcerr.Error{
+		Message:    "Sign-in nonce is missing, expired or already used",
+		VIMessage:  "Nonce đăng nhập bị thiếu, đã hết hạn hoặc đã được sử dụng",
+		Code:       "AUTH-045",
+		HTTPStatus: http.StatusUnauthorized,
+		GRPCCode:   codes.Unauthenticated,
+	}
+
+	ErrIdentityLinkRequired = &svcerr.Error{
+		Message:    "An account with this email already exists; sign in and link this provider from your account settings",
+		VIMessage:  "Đã có tài khoản dùng email này; hãy đăng nhập và liên kết nhà cung cấp này trong phần cài đặt tài khoản",
+		Code:       "AUTH-046",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.FailedPrecondition,
+	}
 )
@@ -0,0 +1,16 @@
+package helper
+
+import "net/url"
+
+// BuildTokenURL appends token as the "token" query parameter of base.
+func BuildTokenURL(base string, token string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(token)
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,159 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/device"
+	"github.com/tdatIT/backend-go/internal/infras/notifier"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/pkgs/background"
+)
+
+// LoginMonitor records where sessions are opened from and tells users about
+// sign-ins from a device or country not seen on their account before.
+type LoginMonitor interface {
+	// Describe fills in the device and location of a session before it is stored.
+	Describe(sessionItem *models.Session)
+	// Check notifies the user when the stored sessionItem comes from a new
+	// device or country. It runs in the background so the sign-in does not
+	// wait for the mail; failures are logged and never fail the sign-in.
+	Check(ctx context.Context, account *models.User, sessionItem *models.Session)
+}
+
+type loginMonitor struct {
+	enabled     bool
+	sessionRepo session.Repository
+	locator     device.Locator
+	notifier    notifier.Notifier
+	runner      *background.Runner
+}
+
+func NewLoginMonitor(
+	cfg *config.ServiceConfig,
+	sessionRepo session.Repository,
+	locator device.Locator,
+	notifier notifier.Notifier,
+	runner *background.Runner,
+) LoginMonitor {
+	return &loginMonitor{
+		enabled:     cfg.Auth.LoginAlerts,
+		sessionRepo: sessionRepo,
+		locator:     locator,
+		notifier:    notifier,
+		runner:      runner,
+	}
+}
+
+func (l *loginMonitor) Describe(sessionItem *models.Session) {
+	info := device.ParseUserAgent(sessionItem.UserAgent)
+	location := l.locator.Locate(sessionItem.IPAddress)
+
+	sessionItem.Browser = info.Browser
+	sessionItem.OS = info.OS
+	sessionItem.DeviceType = info.Type
+	sessionItem.Country = location.Country
+	sessionItem.City = location.City
+}
+
+func (l *loginMonitor) Check(ctx context.Context, account *models.User, sessionItem *models.Session) {
+	if !l.enabled || sessionItem.Browser == "" {
+		return
+	}
+
+	// The caller keeps using its copies once the sign-in has returned
+	accountCopy, sessionCopy := *account, *sessionItem
+	l.runner.Go(ctx, func(ctx context.Context) {
+		l.alert(ctx, &accountCopy, &sessionCopy)
+	})
+}
+
+func (l *loginMonitor) alert(ctx context.Context, account *models.User, sessionItem *models.Session) {
+	history, err := l.sessionRepo.FindDevicesByUserID(ctx, account.ID, sessionItem.ID)
+	if err != nil {
+		slog.Error("failed to load session history for login alert",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	// Sessions opened before devices were recorded tell us nothing, and a
+	// first sign-in has nothing to compare with
+	var (
+		described    bool
+		knownDevice  bool
+		knownCountry = sessionItem.Country == ""
+	)
+	for _, item := range history {
+		if item.Browser == "" {
+			continue
+		}
+		described = true
+		if item.Browser == sessionItem.Browser && item.OS == sessionItem.OS && item.DeviceType == sessionItem.DeviceType {
+			knownDevice = true
+		}
+		if item.Country == sessionItem.Country {
+			knownCountry = true
+		}
+	}
+	if !described || (knownDevice && knownCountry) {
+		return
+	}
+
+	reason := "a new device"
+	switch {
+	case !knownDevice && !knownCountry:
+		reason = "a new device and country"
+	case !knownCountry:
+		reason = "a new country"
+	}
+
+	if err := l.notifier.Notify(ctx, &notifier.Notification{
+		UserID:  account.ID,
+		Email:   account.Email,
+		Subject: "New sign-in to your account",
+		Body: fmt.Sprintf("Hi %s,\n\nYour account was signed in to from %s.\n\n"+
+			"Device: %s\nLocation: %s\nIP address: %s\nTime: %s\n\n"+
+			"If this was you, there is nothing to do. Otherwise change your password and sign out of your other sessions.\n",
+			account.FirstName, reason, describeDevice(sessionItem), describeLocation(sessionItem),
+			sessionItem.IPAddress, time.Now().UTC().Format(time.RFC1123)),
+	}); err != nil {
+		slog.Error("failed to send login alert",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	slog.Info("login alert sent",
+		slog.Uint64("user_id", account.ID),
+		slog.String("sess_id", sessionItem.ID),
+		slog.Bool("new_device", !knownDevice),
+		slog.Bool("new_country", !knownCountry))
+}
+
+func describeDevice(sessionItem *models.Session) string {
+	name := sessionItem.Browser
+	if sessionItem.OS != "" {
+		name += " on " + sessionItem.OS
+	}
+	return fmt.Sprintf("%s (%s)", name, sessionItem.DeviceType)
+}
+
+func describeLocation(sessionItem *models.Session) string {
+	parts := make([]string, 0, 2)
+	if sessionItem.City != "" {
+		parts = append(parts, sessionItem.City)
+	}
+	if sessionItem.Country != "" {
+		parts = append(parts, sessionItem.Country)
+	}
+	if len(parts) == 0 {
+		return "unknown"
+	}
+	return strings.Join(parts, ", ")
+}
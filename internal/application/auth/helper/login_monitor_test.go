@@ -0,0 +1,129 @@
+package helper_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/device"
+	"github.com/tdatIT/backend-go/internal/infras/notifier"
+	"github.com/tdatIT/backend-go/mocks"
+	"github.com/tdatIT/backend-go/pkgs/background"
+)
+
+const chromeOnWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+// newAlertingMonitor returns a monitor with alerts on and the runner its
+// checks run on, so tests can wait for them.
+func newAlertingMonitor(sessionRepo *mocks.MockSessionRepository, sender *mocks.MockNotifier) (helper.LoginMonitor, *background.Runner) {
+	cfg := &config.ServiceConfig{}
+	cfg.Auth.LoginAlerts = true
+	runner := background.NewRunner(time.Second)
+	return helper.NewLoginMonitor(cfg, sessionRepo, device.NoopLocator{}, sender, runner), runner
+}
+
+func TestLoginMonitor_Describe(t *testing.T) {
+	locator := new(mocks.MockLocator)
+	locator.On("Locate", "203.0.113.7").Return(device.Location{Country: "VN", City: "Hanoi"})
+
+	monitor := helper.NewLoginMonitor(&config.ServiceConfig{}, nil, locator, nil, nil)
+	sessionItem := &models.Session{UserAgent: chromeOnWindows, IPAddress: "203.0.113.7"}
+	monitor.Describe(sessionItem)
+
+	require.Equal(t, "Chrome", sessionItem.Browser)
+	require.Equal(t, "Windows", sessionItem.OS)
+	require.Equal(t, device.TypeDesktop, sessionItem.DeviceType)
+	require.Equal(t, "VN", sessionItem.Country)
+	require.Equal(t, "Hanoi", sessionItem.City)
+}
+
+func TestLoginMonitor_Check_NewDevice(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	sender := new(mocks.MockNotifier)
+
+	sessionRepo.On("FindDevicesByUserID", mock.Anything, uint64(1), "s2").Return([]*models.Session{
+		{Browser: "Safari", OS: "Mac OS X", DeviceType: device.TypeDesktop, Country: "VN"},
+	}, nil)
+	sender.On("Notify", mock.Anything, mock.MatchedBy(func(msg *notifier.Notification) bool {
+		return msg.UserID == 1 && msg.Email == "user@example.com" &&
+			strings.Contains(msg.Body, "from a new device.") && strings.Contains(msg.Body, "Chrome on Windows (desktop)")
+	})).Return(nil)
+
+	monitor, runner := newAlertingMonitor(sessionRepo, sender)
+	monitor.Check(context.Background(), &models.User{ID: 1, Email: "user@example.com"}, &models.Session{
+		ID: "s2", Browser: "Chrome", OS: "Windows", DeviceType: device.TypeDesktop, Country: "VN",
+	})
+	require.NoError(t, runner.Wait(context.Background()))
+
+	sender.AssertExpectations(t)
+}
+
+func TestLoginMonitor_Check_NewCountry(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	sender := new(mocks.MockNotifier)
+
+	sessionRepo.On("FindDevicesByUserID", mock.Anything, uint64(1), "s2").Return([]*models.Session{
+		{Browser: "Chrome", OS: "Windows", DeviceType: device.TypeDesktop, Country: "VN"},
+	}, nil)
+	sender.On("Notify", mock.Anything, mock.MatchedBy(func(msg *notifier.Notification) bool {
+		return strings.Contains(msg.Body, "from a new country.") && strings.Contains(msg.Body, "Location: Paris, FR")
+	})).Return(nil)
+
+	monitor, runner := newAlertingMonitor(sessionRepo, sender)
+	monitor.Check(context.Background(), &models.User{ID: 1}, &models.Session{
+		ID: "s2", Browser: "Chrome", OS: "Windows", DeviceType: device.TypeDesktop, Country: "FR", City: "Paris",
+	})
+	require.NoError(t, runner.Wait(context.Background()))
+
+	sender.AssertExpectations(t)
+}
+
+func TestLoginMonitor_Check_KnownDevice(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	sender := new(mocks.MockNotifier)
+
+	sessionRepo.On("FindDevicesByUserID", mock.Anything, uint64(1), "s2").Return([]*models.Session{
+		{Browser: "Chrome", OS: "Windows", DeviceType: device.TypeDesktop, Country: "VN"},
+	}, nil)
+
+	monitor, runner := newAlertingMonitor(sessionRepo, sender)
+	monitor.Check(context.Background(), &models.User{ID: 1}, &models.Session{
+		ID: "s2", Browser: "Chrome", OS: "Windows", DeviceType: device.TypeDesktop, Country: "VN",
+	})
+	require.NoError(t, runner.Wait(context.Background()))
+
+	sender.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestLoginMonitor_Check_FirstSignIn(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	sender := new(mocks.MockNotifier)
+
+	// only a session from before devices were recorded
+	sessionRepo.On("FindDevicesByUserID", mock.Anything, uint64(1), "s2").Return([]*models.Session{{}}, nil)
+
+	monitor, runner := newAlertingMonitor(sessionRepo, sender)
+	monitor.Check(context.Background(), &models.User{ID: 1}, &models.Session{
+		ID: "s2", Browser: "Chrome", OS: "Windows", DeviceType: device.TypeDesktop,
+	})
+	require.NoError(t, runner.Wait(context.Background()))
+
+	sender.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestLoginMonitor_Check_Disabled(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	runner := background.NewRunner(time.Second)
+	monitor := helper.NewLoginMonitor(&config.ServiceConfig{}, sessionRepo, device.NoopLocator{}, nil, runner)
+	monitor.Check(context.Background(), &models.User{ID: 1}, &models.Session{ID: "s2", Browser: "Chrome"})
+	require.NoError(t, runner.Wait(context.Background()))
+
+	sessionRepo.AssertNotCalled(t, "FindDevicesByUserID", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,222 @@
+package helper
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+)
+
+const (
+	defaultLoginMaxAttempts     = 5
+	defaultLoginIPMaxAttempts   = 50
+	defaultLoginFailureWindow   = 15 * time.Minute
+	defaultLoginBackoffBase     = time.Second
+	defaultLoginLockoutDuration = 15 * time.Minute
+)
+
+// LoginThrottle counts failed logins per username and per client IP. Each
+// failure blocks the next attempt with exponential backoff, and reaching the
+// threshold locks the key out. Usernames are throttled whether or not the
+// account exists so that lockouts do not reveal which accounts are real.
+//
+// Cache errors are logged and ignored: an unavailable Redis must not block
+// every login.
+type LoginThrottle struct {
+	cache           cache.Cache
+	maxAttempts     int64
+	ipMaxAttempts   int64
+	window          time.Duration
+	backoffBase     time.Duration
+	lockoutDuration time.Duration
+}
+
+// LoginFailure reports the lockouts triggered by a failed attempt.
+type LoginFailure struct {
+	UserLocked bool
+	IPLocked   bool
+}
+
+// LoginAttempt is an attempt counted by Reserve. It must be settled with
+// RecordFailure, Release or Reset once the credentials have been checked.
+type LoginAttempt struct {
+	username  string
+	ipAddress string
+	userCount int64
+	ipCount   int64
+}
+
+func NewLoginThrottle(cfg *config.ServiceConfig, cacheClient cache.Cache) LoginThrottle {
+	t := LoginThrottle{
+		cache:           cacheClient,
+		maxAttempts:     int64(cfg.Auth.LoginMaxAttempts),
+		ipMaxAttempts:   int64(cfg.Auth.LoginIPMaxAttempts),
+		window:          cfg.Auth.LoginFailureWindow,
+		backoffBase:     cfg.Auth.LoginBackoffBase,
+		lockoutDuration: cfg.Auth.LoginLockoutDuration,
+	}
+	if t.maxAttempts <= 0 {
+		t.maxAttempts = defaultLoginMaxAttempts
+	}
+	if t.ipMaxAttempts <= 0 {
+		t.ipMaxAttempts = defaultLoginIPMaxAttempts
+	}
+	if t.window <= 0 {
+		t.window = defaultLoginFailureWindow
+	}
+	if t.backoffBase <= 0 {
+		t.backoffBase = defaultLoginBackoffBase
+	}
+	if t.lockoutDuration <= 0 {
+		t.lockoutDuration = defaultLoginLockoutDuration
+	}
+
+	return t
+}
+
+// Reserve counts an attempt for the username and IP before the credentials
+// are checked. It returns ErrLoginLocked while either is blocked or has no
+// attempts left. Counting first means a burst of parallel requests cannot
+// all pass before the first failure is recorded.
+func (t LoginThrottle) Reserve(ctx context.Context, username string, ipAddress string) (LoginAttempt, error) {
+	attempt := LoginAttempt{username: username, ipAddress: ipAddress}
+	for _, key := range t.keys(username, ipAddress) {
+		blocked, err := t.cache.Exists(ctx, "login:block:"+key)
+		if err != nil {
+			slog.Error("failed to check login throttle", slog.String("error", err.Error()))
+			continue
+		}
+		if blocked {
+			return attempt, ErrLoginLocked
+		}
+	}
+
+	attempt.userCount = t.count(ctx, t.userKey(username))
+	if attempt.userCount > t.maxAttempts {
+		return attempt, ErrLoginLocked
+	}
+	if ipAddress != "" {
+		attempt.ipCount = t.count(ctx, t.ipKey(ipAddress))
+		if attempt.ipCount > t.ipMaxAttempts {
+			t.release(ctx, t.userKey(username), attempt.userCount)
+			return attempt, ErrLoginLocked
+		}
+	}
+
+	return attempt, nil
+}
+
+// RecordFailure keeps a reserved attempt as a failure and applies backoff or
+// lockout.
+func (t LoginThrottle) RecordFailure(ctx context.Context, attempt LoginAttempt) LoginFailure {
+	var result LoginFailure
+	result.UserLocked = t.fail(ctx, t.userKey(attempt.username), attempt.userCount, t.maxAttempts)
+	if attempt.ipAddress != "" {
+		result.IPLocked = t.fail(ctx, t.ipKey(attempt.ipAddress), attempt.ipCount, t.ipMaxAttempts)
+	}
+
+	return result
+}
+
+// Release gives back a reserved attempt that neither failed nor completed a
+// login, such as a password accepted while a second factor is still due.
+func (t LoginThrottle) Release(ctx context.Context, attempt LoginAttempt) {
+	t.release(ctx, t.userKey(attempt.username), attempt.userCount)
+	if attempt.ipAddress != "" {
+		t.release(ctx, t.ipKey(attempt.ipAddress), attempt.ipCount)
+	}
+}
+
+// Reset clears the username counters after a successful login. IP counters
+// only give back the reserved attempt so that logging into one account cannot
+// reset guessing on others.
+func (t LoginThrottle) Reset(ctx context.Context, attempt LoginAttempt) {
+	key := t.userKey(attempt.username)
+	for _, k := range []string{"login:fail:" + key, "login:block:" + key} {
+		if err := t.cache.Delete(ctx, k); err != nil {
+			slog.Error("failed to reset login throttle", slog.String("error", err.Error()))
+		}
+	}
+	if attempt.ipAddress != "" {
+		t.release(ctx, t.ipKey(attempt.ipAddress), attempt.ipCount)
+	}
+}
+
+// count increments the attempts on key. It returns 0 when the cache is
+// unavailable, which lets the attempt through uncounted.
+func (t LoginThrottle) count(ctx context.Context, key string) int64 {
+	count, err := t.cache.Incr(ctx, "login:fail:"+key, t.window)
+	if err != nil {
+		slog.Error("failed to count login attempt", slog.String("error", err.Error()))
+		return 0
+	}
+
+	return count
+}
+
+func (t LoginThrottle) release(ctx context.Context, key string, count int64) {
+	if count == 0 {
+		return
+	}
+	if _, err := t.cache.Decr(ctx, "login:fail:"+key); err != nil {
+		slog.Error("failed to release login attempt", slog.String("error", err.Error()))
+	}
+}
+
+func (t LoginThrottle) fail(ctx context.Context, key string, count int64, maxAttempts int64) bool {
+	if count == 0 {
+		return false
+	}
+
+	locked := count >= maxAttempts
+	block := t.lockoutDuration
+	if !locked {
+		block = t.backoff(count)
+	}
+
+	if err := t.cache.Set(ctx, "login:block:"+key, 1, block); err != nil {
+		slog.Error("failed to apply login backoff", slog.String("error", err.Error()))
+	}
+
+	if locked {
+		// keep attempts reserved during the lockout and start a fresh count
+		// once it expires
+		if err := t.cache.Expire(ctx, "login:fail:"+key, t.lockoutDuration); err != nil {
+			slog.Error("failed to extend login failures", slog.String("error", err.Error()))
+		}
+	}
+
+	return locked
+}
+
+// backoff returns backoffBase * 2^(count-1), capped at the lockout duration.
+func (t LoginThrottle) backoff(count int64) time.Duration {
+	delay := t.backoffBase
+	for i := int64(1); i < count; i++ {
+		delay *= 2
+		if delay >= t.lockoutDuration {
+			return t.lockoutDuration
+		}
+	}
+
+	return delay
+}
+
+func (t LoginThrottle) keys(username string, ipAddress string) []string {
+	keys := []string{t.userKey(username)}
+	if ipAddress != "" {
+		keys = append(keys, t.ipKey(ipAddress))
+	}
+	return keys
+}
+
+func (t LoginThrottle) userKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func (t LoginThrottle) ipKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"gorm.io/gorm"
+)
+
+// MaxMFAAttempts is the number of wrong codes accepted before a login
+// challenge is discarded. Wrong codes also count as failed logins for the
+// username, so the limit across challenges is the login throttle's.
+const MaxMFAAttempts = 5
+
+// MFAChallenge is the pending login stored in the cache between the password
+// step and the second factor. Username keys the login throttle, which counts
+// wrong codes across challenges.
+type MFAChallenge struct {
+	UserID    uint64    `json:"user_id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MFAChallengeKey returns the cache key for a challenge token. Only the hash
+// of the token is used so a cache dump cannot complete logins.
+func MFAChallengeKey(token string) string {
+	return "mfa:challenge:" + security.HashOpaqueToken(token)
+}
+
+// MFAAttemptsKey returns the cache key counting the codes tried against the
+// challenge stored under challengeKey.
+func MFAAttemptsKey(challengeKey string) string {
+	return challengeKey + ":attempts"
+}
+
+// MFACodeVerifier checks a second-factor code against a confirmed enrollment.
+// The code may be a current TOTP code or an unused recovery code.
+type MFACodeVerifier struct {
+	MFARepo mfa.Repository
+	Box     *security.SecretBox
+}
+
+func (v MFACodeVerifier) Verify(ctx context.Context, item *models.UserMFA, code string) error {
+	secret, err := v.Box.Open(item.SecretCiphertext)
+	if err != nil {
+		return err
+	}
+
+	if step, ok := security.ValidateTOTP(secret, code, time.Now()); ok {
+		fresh, err := v.MFARepo.MarkStepUsed(ctx, item.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	if err := v.MFARepo.ConsumeRecoveryCode(ctx, item.UserID, security.HashRecoveryCode(code)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidMFACode
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package helper
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"gorm.io/gorm"
+)
+
+const (
+	OAuthResponseTypeCode        = "code"
+	OAuthGrantAuthorizationCode  = "authorization_code"
+	OAuthGrantRefreshToken       = "refresh_token"
+	OAuthCodeChallengeMethodS256 = "S256"
+	oauthCodeChallengeMinLength  = 43
+	oauthCodeChallengeMaxLength  = 128
+	oauthErrorServerError        = "server_error"
+	oauthErrorAccessDenied       = "access_denied"
+)
+
+// OAuthErrorCode maps an error to its RFC 6749 error code.
+func OAuthErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrOAuthInvalidRequest), errors.Is(err, ErrOAuthInvalidRedirectURI):
+		return "invalid_request"
+	case errors.Is(err, ErrInvalidClient):
+		return "invalid_client"
+	case errors.Is(err, ErrOAuthInvalidGrant):
+		return "invalid_grant"
+	case errors.Is(err, ErrOAuthUnsupportedGrantType):
+		return "unsupported_grant_type"
+	case errors.Is(err, ErrOAuthUnsupportedResponseType):
+		return "unsupported_response_type"
+	case errors.Is(err, ErrOAuthInvalidScope):
+		return "invalid_scope"
+	default:
+		return oauthErrorServerError
+	}
+}
+
+// IsRedirectableOAuthError reports whether an authorization error may be sent
+// back to the redirect URI. Errors about the client or the redirect URI itself
+// must be shown to the user instead (RFC 6749 4.1.2.1).
+func IsRedirectableOAuthError(err error) bool {
+	return !errors.Is(err, ErrInvalidClient) && !errors.Is(err, ErrOAuthInvalidRedirectURI) &&
+		OAuthErrorCode(err) != oauthErrorServerError
+}
+
+// ValidateAuthorizeRequest checks an authorization request against the
+// registered client and returns the client and the requested scopes. An empty
+// redirect_uri is filled in when the client has exactly one registered, and an
+// empty scope means every scope the client may request.
+func ValidateAuthorizeRequest(ctx context.Context, oauthRepo oauth.Repository, req *userdto.AuthorizeReq) (*models.OAuthClient, []string, error) {
+	client, err := oauthRepo.FindClientByID(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Warn("authorization requested for unknown client", slog.String("client_id", req.ClientID))
+			return nil, nil, ErrInvalidClient
+		}
+		slog.Error("failed to find oauth client",
+			slog.String("client_id", req.ClientID),
+			slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	if req.RedirectURI == "" && len(client.RedirectURIs) == 1 {
+		req.RedirectURI = client.RedirectURIs[0]
+	}
+	// redirect URIs are compared exactly, no prefix or wildcard matching
+	if !slices.Contains(client.RedirectURIs, req.RedirectURI) {
+		slog.Warn("authorization requested with unregistered redirect uri",
+			slog.String("client_id", client.ID),
+			slog.String("redirect_uri", req.RedirectURI))
+		return nil, nil, ErrOAuthInvalidRedirectURI
+	}
+
+	if req.ResponseType != OAuthResponseTypeCode {
+		return nil, nil, ErrOAuthUnsupportedResponseType
+	}
+
+	// PKCE is required for every client, confidential ones included
+	if len(req.CodeChallenge) < oauthCodeChallengeMinLength || len(req.CodeChallenge) > oauthCodeChallengeMaxLength ||
+		req.CodeChallengeMethod != OAuthCodeChallengeMethodS256 {
+		return nil, nil, ErrOAuthInvalidRequest
+	}
+
+	scopes := ParseScope(req.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return nil, nil, ErrOAuthInvalidScope
+		}
+	}
+
+	return client, scopes, nil
+}
+
+// ParseScope splits a space-delimited scope string, dropping duplicates.
+func ParseScope(scope string) []string {
+	scopes := make([]string, 0)
+	for _, item := range strings.Fields(scope) {
+		if !slices.Contains(scopes, item) {
+			scopes = append(scopes, item)
+		}
+	}
+	return scopes
+}
+
+// ScopesCovered reports whether every requested scope has been granted.
+func ScopesCovered(granted []string, requested []string) bool {
+	for _, scope := range requested {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyPKCE checks an S256 code verifier against its challenge.
+func VerifyPKCE(verifier string, challenge string) bool {
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// AuthorizeRedirect appends params to the client's redirect URI, keeping any
+// query it already has. An empty state is left out.
+func AuthorizeRedirect(redirectURI string, state string, params url.Values) string {
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		// registered URIs are validated before use, so this is not expected
+		return redirectURI
+	}
+
+	query := target.Query()
+	for key, values := range params {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	target.RawQuery = query.Encode()
+
+	return target.String()
+}
+
+// AuthorizeErrorRedirect sends an authorization error back to the client.
+func AuthorizeErrorRedirect(redirectURI string, state string, err error) string {
+	return AuthorizeRedirect(redirectURI, state, url.Values{"error": {OAuthErrorCode(err)}})
+}
+
+// AuthorizeDeniedRedirect tells the client the user declined the request.
+func AuthorizeDeniedRedirect(redirectURI string, state string) string {
+	return AuthorizeRedirect(redirectURI, state, url.Values{"error": {oauthErrorAccessDenied}})
+}
+
+// ConsentRedirect points the user agent at the consent page with the
+// validated authorization request, which the page posts back once the user
+// has signed in and decided.
+func ConsentRedirect(consentURL string, req *userdto.AuthorizeReq) string {
+	return AuthorizeRedirect(consentURL, req.State, url.Values{
+		"response_type":         {req.ResponseType},
+		"client_id":             {req.ClientID},
+		"redirect_uri":          {req.RedirectURI},
+		"scope":                 {req.Scope},
+		"code_challenge":        {req.CodeChallenge},
+		"code_challenge_method": {req.CodeChallengeMethod},
+	})
+}
@@ -0,0 +1,113 @@
+package helper_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+// RFC 7636 appendix B
+const (
+	testCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func testOAuthClient() *models.OAuthClient {
+	return &models.OAuthClient{
+		ID:           "partner",
+		Name:         "Partner",
+		RedirectURIs: []string{"https://partner.example.com/cb"},
+		Scopes:       []string{"profile:read", "tasks:read"},
+	}
+}
+
+func testAuthorizeReq() *userdto.AuthorizeReq {
+	return &userdto.AuthorizeReq{
+		ResponseType:        helper.OAuthResponseTypeCode,
+		ClientID:            "partner",
+		Scope:               "tasks:read",
+		CodeChallenge:       testCodeChallenge,
+		CodeChallengeMethod: helper.OAuthCodeChallengeMethodS256,
+	}
+}
+
+func TestValidateAuthorizeRequest_Success(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(testOAuthClient(), nil)
+
+	req := testAuthorizeReq()
+	client, scopes, err := helper.ValidateAuthorizeRequest(context.Background(), oauthRepo, req)
+
+	require.NoError(t, err)
+	require.Equal(t, "partner", client.ID)
+	require.Equal(t, []string{"tasks:read"}, scopes)
+	// the only registered redirect URI is used when none is sent
+	require.Equal(t, "https://partner.example.com/cb", req.RedirectURI)
+}
+
+func TestValidateAuthorizeRequest_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(req *userdto.AuthorizeReq)
+		err    error
+	}{
+		{"unregistered redirect uri", func(req *userdto.AuthorizeReq) { req.RedirectURI = "https://evil.example.com/cb" }, helper.ErrOAuthInvalidRedirectURI},
+		{"redirect uri prefix", func(req *userdto.AuthorizeReq) { req.RedirectURI = "https://partner.example.com/cb/extra" }, helper.ErrOAuthInvalidRedirectURI},
+		{"response type", func(req *userdto.AuthorizeReq) { req.ResponseType = "token" }, helper.ErrOAuthUnsupportedResponseType},
+		{"missing challenge", func(req *userdto.AuthorizeReq) { req.CodeChallenge = "" }, helper.ErrOAuthInvalidRequest},
+		{"plain challenge", func(req *userdto.AuthorizeReq) { req.CodeChallengeMethod = "plain" }, helper.ErrOAuthInvalidRequest},
+		{"scope", func(req *userdto.AuthorizeReq) { req.Scope = "roles:manage" }, helper.ErrOAuthInvalidScope},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oauthRepo := new(mocks.MockOAuthRepository)
+			oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(testOAuthClient(), nil)
+
+			req := testAuthorizeReq()
+			tc.modify(req)
+			_, _, err := helper.ValidateAuthorizeRequest(context.Background(), oauthRepo, req)
+
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateAuthorizeRequest_UnknownClient(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(nil, gorm.ErrRecordNotFound)
+
+	_, _, err := helper.ValidateAuthorizeRequest(context.Background(), oauthRepo, testAuthorizeReq())
+
+	require.ErrorIs(t, err, helper.ErrInvalidClient)
+	require.False(t, helper.IsRedirectableOAuthError(err))
+}
+
+func TestVerifyPKCE(t *testing.T) {
+	require.True(t, helper.VerifyPKCE(testCodeVerifier, testCodeChallenge))
+	require.False(t, helper.VerifyPKCE("other-verifier", testCodeChallenge))
+}
+
+func TestAuthorizeRedirect_KeepsExistingQuery(t *testing.T) {
+	target, err := url.Parse(helper.AuthorizeRedirect("https://partner.example.com/cb?tenant=a", "xyz", url.Values{"code": {"abc"}}))
+	require.NoError(t, err)
+
+	query := target.Query()
+	require.Equal(t, "a", query.Get("tenant"))
+	require.Equal(t, "abc", query.Get("code"))
+	require.Equal(t, "xyz", query.Get("state"))
+}
+
+func TestOAuthErrorCode(t *testing.T) {
+	require.Equal(t, "invalid_grant", helper.OAuthErrorCode(helper.ErrOAuthInvalidGrant))
+	require.Equal(t, "invalid_client", helper.OAuthErrorCode(helper.ErrInvalidClient))
+	require.Equal(t, "server_error", helper.OAuthErrorCode(gorm.ErrInvalidDB))
+}
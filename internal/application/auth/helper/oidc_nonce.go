@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+)
+
+// OIDCNonceTTL is how long a nonce handed out for an OIDC sign-in or link
+// stays usable.
+const OIDCNonceTTL = 10 * time.Minute
+
+// OIDCNonceKey returns the cache key for a nonce. Only its hash is used so a
+// cache dump cannot be replayed.
+func OIDCNonceKey(nonce string) string {
+	return "oidc:nonce:" + security.HashOpaqueToken(nonce)
+}
+
+// OIDCNonces hands out the nonces ID tokens must carry and accepts each one
+// once, so an ID token obtained for another client or captured earlier cannot
+// be used here.
+type OIDCNonces struct {
+	Cache cache.Cache
+}
+
+func (n OIDCNonces) Issue(ctx context.Context) (string, error) {
+	nonce, _, err := security.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := n.Cache.Set(ctx, OIDCNonceKey(nonce), 1, OIDCNonceTTL); err != nil {
+		return "", err
+	}
+
+	return nonce, nil
+}
+
+// Consume accepts nonce when it was issued here and has not been used yet.
+func (n OIDCNonces) Consume(ctx context.Context, nonce string) error {
+	if nonce == "" {
+		return ErrInvalidOIDCNonce
+	}
+
+	// An issued nonce is stored as 1, so only its first use counts to 2; an
+	// unknown one starts a fresh counter at 1
+	key := OIDCNonceKey(nonce)
+	count, err := n.Cache.Incr(ctx, key, OIDCNonceTTL)
+	if err != nil {
+		return err
+	}
+	if err := n.Cache.Delete(ctx, key); err != nil {
+		slog.Warn("failed to delete used oidc nonce", slog.String("error", err.Error()))
+	}
+
+	if count != 2 {
+		return ErrInvalidOIDCNonce
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"errors"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+)
+
+// CheckPasswordPolicy validates a new password for account and maps policy
+// violations to service errors.
+func CheckPasswordPolicy(policy *security.PasswordPolicy, password string, account *models.User) error {
+	err := policy.Validate(password, account.Username, account.Email)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, security.ErrPasswordLength):
+		return ErrPasswordLength
+	case errors.Is(err, security.ErrPasswordCharClasses):
+		return ErrPasswordCharClasses
+	case errors.Is(err, security.ErrPasswordCommon):
+		return ErrPasswordCommon
+	case errors.Is(err, security.ErrPasswordContainsUserID):
+		return ErrPasswordContainsUserInfo
+	default:
+		return err
+	}
+}
@@ -0,0 +1,27 @@
+package helper
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+)
+
+const defaultTokenCacheTTL = 30 * time.Second
+
+// TokenCacheTTL is how long forward auth and introspection may reuse the
+// verification of an access token.
+func TokenCacheTTL(cfg *config.ServiceConfig) time.Duration {
+	if cfg.Auth.TokenCacheTTL <= 0 {
+		return defaultTokenCacheTTL
+	}
+	return cfg.Auth.TokenCacheTTL
+}
+
+// StaleTokensKey marks every cached token verification of a user as stale.
+// It is set when the account status or password changes and kept for
+// TokenCacheTTL, by which time the entries cached before the change have
+// expired.
+func StaleTokensKey(userID uint64) string {
+	return "token:stale:user:" + strconv.FormatUint(userID, 10)
+}
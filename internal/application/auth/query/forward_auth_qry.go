@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+// IForwardAuthQuery backs gateway subrequests (Nginx auth_request, Traefik
+// ForwardAuth): every proxied request carries the caller's own access token.
+type IForwardAuthQuery decorator.QueryHandler[*userdto.ForwardAuthReq, *userdto.ForwardAuthRes]
+
+type forwardAuthQuery struct {
+	verifier cachedTokenVerifier
+}
+
+func NewForwardAuthQuery(config *config.ServiceConfig, cacheClient cache.Cache, verifyToken IVerifyTokenQuery) IForwardAuthQuery {
+	return &forwardAuthQuery{
+		verifier: newCachedTokenVerifier(cacheClient, verifyToken, helper.TokenCacheTTL(config)),
+	}
+}
+
+func (f forwardAuthQuery) Handle(ctx context.Context, req *userdto.ForwardAuthReq) (*userdto.ForwardAuthRes, error) {
+	res, err := f.verifier.verify(ctx, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userdto.ForwardAuthRes{
+		UserID:    res.Sub,
+		SessionID: res.SessionID,
+		Roles:     res.Roles,
+	}, nil
+}
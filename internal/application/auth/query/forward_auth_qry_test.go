@@ -0,0 +1,51 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestForwardAuthQuery_Handle_Success(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+
+	// the cache entry must not outlive the token
+	expiresAt := time.Now().Add(10 * time.Second)
+	cacheClient.On("Get", mock.Anything, mock.Anything).Return(nil, redis.Nil)
+	verifyToken.On("Handle", mock.Anything, mock.Anything).
+		Return(&userdto.VerifyTokenRes{Sub: "7", SessionID: "sess-7", ExpiresAt: expiresAt.Unix()}, nil)
+	cacheClient.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(ttl time.Duration) bool {
+		return ttl > 0 && ttl <= 10*time.Second
+	})).Return(nil)
+
+	qry := NewForwardAuthQuery(&config.ServiceConfig{}, cacheClient, verifyToken)
+	res, err := qry.Handle(context.Background(), &userdto.ForwardAuthReq{AccessToken: "access"})
+
+	require.NoError(t, err)
+	require.Equal(t, "7", res.UserID)
+	require.Equal(t, "sess-7", res.SessionID)
+	cacheClient.AssertExpectations(t)
+}
+
+func TestForwardAuthQuery_Handle_Inactive(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+
+	cacheClient.On("Get", mock.Anything, mock.Anything).Return(nil, redis.Nil)
+	verifyToken.On("Handle", mock.Anything, mock.Anything).Return(nil, helper.ErrInvalidToken)
+
+	qry := NewForwardAuthQuery(&config.ServiceConfig{}, cacheClient, verifyToken)
+	res, err := qry.Handle(context.Background(), &userdto.ForwardAuthReq{AccessToken: "bad"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
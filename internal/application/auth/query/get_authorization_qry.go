@@ -0,0 +1,48 @@
+package query
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IGetAuthorizationQuery decorator.QueryHandler[*userdto.AuthorizeReq, *userdto.AuthorizeInfoRes]
+
+type getAuthorizationQuery struct {
+	oauthRepo  oauth.Repository
+	consentURL string
+}
+
+func NewGetAuthorizationQuery(config *config.ServiceConfig, oauthRepo oauth.Repository) IGetAuthorizationQuery {
+	return &getAuthorizationQuery{
+		oauthRepo:  oauthRepo,
+		consentURL: config.Auth.OAuthConsentURL,
+	}
+}
+
+// Handle validates the request before the user is asked for consent, so a bad
+// client or redirect URI is reported here and never reaches the consent page.
+func (g getAuthorizationQuery) Handle(ctx context.Context, req *userdto.AuthorizeReq) (*userdto.AuthorizeInfoRes, error) {
+	client, scopes, err := helper.ValidateAuthorizeRequest(ctx, g.oauthRepo, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userdto.AuthorizeInfoRes{
+		Client: &userdto.OAuthClientRes{
+			ID:         client.ID,
+			Name:       client.Name,
+			FirstParty: client.FirstParty,
+		},
+		Scopes: scopes,
+	}
+	if g.consentURL != "" {
+		res.RedirectTo = helper.ConsentRedirect(g.consentURL, req)
+	}
+
+	return res, nil
+}
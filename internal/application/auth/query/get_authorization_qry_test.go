@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func testAuthorizationReq() *userdto.AuthorizeReq {
+	return &userdto.AuthorizeReq{
+		ResponseType:        helper.OAuthResponseTypeCode,
+		ClientID:            "partner",
+		State:               "xyz",
+		CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeMethod: helper.OAuthCodeChallengeMethodS256,
+	}
+}
+
+func TestGetAuthorizationQuery_Handle_ReturnsClientInfo(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{
+		ID:           "partner",
+		Name:         "Partner",
+		RedirectURIs: []string{"https://partner.example.com/cb"},
+		Scopes:       []string{"tasks:read"},
+	}, nil)
+
+	qry := NewGetAuthorizationQuery(&config.ServiceConfig{}, oauthRepo)
+	res, err := qry.Handle(context.Background(), testAuthorizationReq())
+
+	require.NoError(t, err)
+	require.Equal(t, "Partner", res.Client.Name)
+	require.Equal(t, []string{"tasks:read"}, res.Scopes)
+	require.Empty(t, res.RedirectTo)
+}
+
+func TestGetAuthorizationQuery_Handle_RedirectsToConsentPage(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{
+		ID:           "partner",
+		RedirectURIs: []string{"https://partner.example.com/cb"},
+	}, nil)
+
+	cfg := &config.ServiceConfig{Auth: config.Auth{OAuthConsentURL: "https://app.example.com/consent"}}
+	qry := NewGetAuthorizationQuery(cfg, oauthRepo)
+	res, err := qry.Handle(context.Background(), testAuthorizationReq())
+
+	require.NoError(t, err)
+	target, err := url.Parse(res.RedirectTo)
+	require.NoError(t, err)
+	require.Equal(t, "app.example.com", target.Host)
+	require.Equal(t, "partner", target.Query().Get("client_id"))
+	require.Equal(t, "https://partner.example.com/cb", target.Query().Get("redirect_uri"))
+	require.Equal(t, "xyz", target.Query().Get("state"))
+}
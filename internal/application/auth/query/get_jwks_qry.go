@@ -0,0 +1,25 @@
+package query
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IGetJWKSQuery decorator.QueryHandler[*userdto.GetJWKSReq, *security.JSONWebKeySet]
+
+type getJWKSQuery struct {
+	tokenManager security.TokenManager
+}
+
+func NewGetJWKSQuery(tokenManager security.TokenManager) IGetJWKSQuery {
+	return &getJWKSQuery{
+		tokenManager: tokenManager,
+	}
+}
+
+func (g getJWKSQuery) Handle(_ context.Context, _ *userdto.GetJWKSReq) (*security.JSONWebKeySet, error) {
+	return g.tokenManager.JWKS(), nil
+}
@@ -0,0 +1,26 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestGetJWKSQuery_Handle_Success(t *testing.T) {
+	tokenManager := new(mocks.MockTokenManager)
+
+	jwks := &security.JSONWebKeySet{Keys: []security.JSONWebKey{{Kty: "OKP", Kid: "k1", Alg: "EdDSA"}}}
+	tokenManager.On("JWKS").Return(jwks)
+
+	qry := NewGetJWKSQuery(tokenManager)
+	res, err := qry.Handle(context.Background(), &userdto.GetJWKSReq{})
+
+	require.NoError(t, err)
+	require.Equal(t, jwks, res)
+
+	tokenManager.AssertExpectations(t)
+}
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IGetUserQuery decorator.QueryHandler[*userdto.GetUserReq, *userdto.UserDetailRes]
+
+type getUserQuery struct {
+	userRepo     user.Repository
+	identityRepo identity.Repository
+	sessionRepo  session.Repository
+}
+
+func NewGetUserQuery(
+	userRepo user.Repository,
+	identityRepo identity.Repository,
+	sessionRepo session.Repository,
+) IGetUserQuery {
+	return &getUserQuery{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		sessionRepo:  sessionRepo,
+	}
+}
+
+func (g getUserQuery) Handle(ctx context.Context, req *userdto.GetUserReq) (*userdto.UserDetailRes, error) {
+	account, err := g.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrUserNotFound
+		}
+		slog.Error("failed to find user",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	account.Identities, err = g.identityRepo.FindByUserID(ctx, account.ID)
+	if err != nil {
+		slog.Error("failed to list user identities",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	sessions, err := g.sessionRepo.FindActiveByUserID(ctx, account.ID)
+	if err != nil {
+		slog.Error("failed to list user sessions",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := &userdto.UserDetailRes{
+		AdminUserRes: toAdminUserRes(account),
+		Sessions:     make([]*userdto.SessionRes, 0, len(sessions)),
+	}
+	for _, item := range sessions {
+		res.Sessions = append(res.Sessions, &userdto.SessionRes{
+			ID:         item.ID,
+			UserAgent:  item.UserAgent,
+			IPAddress:  item.IPAddress,
+			Browser:    item.Browser,
+			OS:         item.OS,
+			DeviceType: item.DeviceType,
+			Country:    item.Country,
+			City:       item.City,
+			LastUsedAt: item.LastUsedAt,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
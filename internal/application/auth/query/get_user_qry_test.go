@@ -0,0 +1,47 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestGetUserQuery_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	identityRepo := new(mocks.MockIdentityRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2, Username: "jane", IsActive: true}, nil)
+	identityRepo.On("FindByUserID", mock.Anything, uint64(2)).
+		Return([]*models.UserIdentity{{Provider: "google"}}, nil)
+	sessionRepo.On("FindActiveByUserID", mock.Anything, uint64(2)).
+		Return([]*models.Session{{ID: "s1", IPAddress: "10.0.0.1"}}, nil)
+
+	qry := NewGetUserQuery(userRepo, identityRepo, sessionRepo)
+	res, err := qry.Handle(context.Background(), &userdto.GetUserReq{UserID: 2})
+
+	require.NoError(t, err)
+	require.Equal(t, "jane", res.Username)
+	require.Equal(t, []string{"google"}, res.Providers)
+	require.Len(t, res.Sessions, 1)
+	require.Equal(t, "s1", res.Sessions[0].ID)
+}
+
+func TestGetUserQuery_Handle_NotFound(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	qry := NewGetUserQuery(userRepo, new(mocks.MockIdentityRepository), new(mocks.MockSessionRepository))
+	res, err := qry.Handle(context.Background(), &userdto.GetUserReq{UserID: 2})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrUserNotFound)
+}
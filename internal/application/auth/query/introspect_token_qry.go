@@ -0,0 +1,79 @@
+package query
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IIntrospectTokenQuery decorator.QueryHandler[*userdto.IntrospectReq, *userdto.IntrospectRes]
+
+type introspectTokenQuery struct {
+	clients  []config.IntrospectionClient
+	verifier cachedTokenVerifier
+}
+
+func NewIntrospectTokenQuery(config *config.ServiceConfig, cacheClient cache.Cache, verifyToken IVerifyTokenQuery) IIntrospectTokenQuery {
+	return &introspectTokenQuery{
+		clients:  config.Auth.IntrospectionClients,
+		verifier: newCachedTokenVerifier(cacheClient, verifyToken, helper.TokenCacheTTL(config)),
+	}
+}
+
+func (i introspectTokenQuery) Handle(ctx context.Context, req *userdto.IntrospectReq) (*userdto.IntrospectRes, error) {
+	if !i.authenticate(req.ClientID, req.ClientSecret) {
+		slog.Warn("introspection rejected for client", slog.String("client_id", req.ClientID))
+		return nil, helper.ErrInvalidClient
+	}
+
+	res, err := i.verifier.verify(ctx, req.Token)
+	if err != nil {
+		// RFC 7662 reports unusable tokens as inactive rather than as an error
+		if errors.Is(err, helper.ErrInvalidToken) {
+			return &userdto.IntrospectRes{Active: false}, nil
+		}
+		slog.Error("failed to introspect token",
+			slog.String("client_id", req.ClientID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.IntrospectRes{
+		Active:    true,
+		Sub:       res.Sub,
+		SessionID: res.SessionID,
+		JTI:       res.JTI,
+		ExpiresAt: res.ExpiresAt,
+		TokenType: "Bearer",
+		ClientID:  res.ClientID,
+		Scope:     res.Scope,
+		Roles:     res.Roles,
+	}, nil
+}
+
+// authenticate compares against every client so the response time does not
+// reveal which client IDs exist.
+func (i introspectTokenQuery) authenticate(clientID string, secret string) bool {
+	if clientID == "" || secret == "" {
+		return false
+	}
+
+	matched := 0
+	for _, client := range i.clients {
+		if client.ID == "" || client.Secret == "" {
+			continue
+		}
+		idMatch := subtle.ConstantTimeCompare([]byte(client.ID), []byte(clientID))
+		secretMatch := subtle.ConstantTimeCompare([]byte(client.Secret), []byte(secret))
+		matched |= idMatch & secretMatch
+	}
+
+	return matched == 1
+}
@@ -0,0 +1,126 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func newIntrospectionConfig() *config.ServiceConfig {
+	cfg := &config.ServiceConfig{}
+	cfg.Auth.IntrospectionClients = []config.IntrospectionClient{{ID: "gateway", Secret: "s3cret"}}
+	cfg.Auth.TokenCacheTTL = time.Minute
+	return cfg
+}
+
+func TestIntrospectTokenQuery_Handle_ActiveAndCached(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+
+	key := "token:active:" + security.HashOpaqueToken("access")
+	verified := &userdto.VerifyTokenRes{Sub: "1", SessionID: "sess-1", JTI: "jti-1", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	cacheClient.On("Get", mock.Anything, key).Return(nil, redis.Nil)
+	verifyToken.On("Handle", mock.Anything, &userdto.VerifyTokenReq{AccessToken: "access"}).Return(verified, nil)
+	cacheClient.On("Set", mock.Anything, key, mock.Anything, time.Minute).Return(nil)
+
+	qry := NewIntrospectTokenQuery(newIntrospectionConfig(), cacheClient, verifyToken)
+	res, err := qry.Handle(context.Background(), &userdto.IntrospectReq{Token: "access", ClientID: "gateway", ClientSecret: "s3cret"})
+
+	require.NoError(t, err)
+	require.True(t, res.Active)
+	require.Equal(t, "1", res.Sub)
+	require.Equal(t, "sess-1", res.SessionID)
+	verifyToken.AssertExpectations(t)
+	cacheClient.AssertExpectations(t)
+}
+
+func TestIntrospectTokenQuery_Handle_CacheHit(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+
+	raw, err := json.Marshal(&userdto.VerifyTokenRes{Sub: "1", SessionID: "sess-1", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	cacheClient.On("Get", mock.Anything, "token:active:"+security.HashOpaqueToken("access")).Return(raw, nil)
+	cacheClient.On("Exists", mock.Anything, helper.StaleTokensKey(1)).Return(false, nil)
+
+	qry := NewIntrospectTokenQuery(newIntrospectionConfig(), cacheClient, verifyToken)
+	res, err := qry.Handle(context.Background(), &userdto.IntrospectReq{Token: "access", ClientID: "gateway", ClientSecret: "s3cret"})
+
+	require.NoError(t, err)
+	require.True(t, res.Active)
+	verifyToken.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything)
+}
+
+func TestIntrospectTokenQuery_Handle_StaleCacheEntryIsVerifiedAgain(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+
+	raw, err := json.Marshal(&userdto.VerifyTokenRes{Sub: "1", SessionID: "sess-1", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	cacheClient.On("Get", mock.Anything, "token:active:"+security.HashOpaqueToken("access")).Return(raw, nil)
+	cacheClient.On("Exists", mock.Anything, helper.StaleTokensKey(1)).Return(true, nil)
+	verifyToken.On("Handle", mock.Anything, mock.Anything).Return(nil, helper.ErrAccountDisabled)
+
+	qry := NewIntrospectTokenQuery(newIntrospectionConfig(), cacheClient, verifyToken)
+	res, err := qry.Handle(context.Background(), &userdto.IntrospectReq{Token: "access", ClientID: "gateway", ClientSecret: "s3cret"})
+
+	require.NoError(t, err)
+	require.False(t, res.Active)
+	verifyToken.AssertExpectations(t)
+}
+
+func TestIntrospectTokenQuery_Handle_InactiveIsNotCached(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+
+	cacheClient.On("Get", mock.Anything, mock.Anything).Return(nil, redis.Nil)
+	verifyToken.On("Handle", mock.Anything, mock.Anything).Return(nil, helper.ErrInvalidToken)
+
+	qry := NewIntrospectTokenQuery(newIntrospectionConfig(), cacheClient, verifyToken)
+	res, err := qry.Handle(context.Background(), &userdto.IntrospectReq{Token: "revoked", ClientID: "gateway", ClientSecret: "s3cret"})
+
+	require.NoError(t, err)
+	require.Equal(t, &userdto.IntrospectRes{Active: false}, res)
+	cacheClient.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIntrospectTokenQuery_Handle_InvalidClient(t *testing.T) {
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+	qry := NewIntrospectTokenQuery(newIntrospectionConfig(), new(mocks.MockCache), verifyToken)
+
+	for _, req := range []*userdto.IntrospectReq{
+		{Token: "access"},
+		{Token: "access", ClientID: "gateway", ClientSecret: "wrong"},
+		{Token: "access", ClientID: "other", ClientSecret: "s3cret"},
+	} {
+		res, err := qry.Handle(context.Background(), req)
+		require.Nil(t, res)
+		require.ErrorIs(t, err, helper.ErrInvalidClient)
+	}
+	verifyToken.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything)
+}
+
+func TestIntrospectTokenQuery_Handle_VerifyFailure(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	verifyToken := new(mocks.MockQueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes])
+
+	cacheClient.On("Get", mock.Anything, mock.Anything).Return(nil, redis.Nil)
+	verifyToken.On("Handle", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	qry := NewIntrospectTokenQuery(newIntrospectionConfig(), cacheClient, verifyToken)
+	res, err := qry.Handle(context.Background(), &userdto.IntrospectReq{Token: "access", ClientID: "gateway", ClientSecret: "s3cret"})
+
+	require.Nil(t, res)
+	require.EqualError(t, err, "db down")
+}
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/accesstoken"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IListPersonalAccessTokensQuery decorator.QueryHandler[*userdto.ListPersonalAccessTokensReq, *userdto.ListPersonalAccessTokensRes]
+
+type listPersonalAccessTokensQuery struct {
+	tokenRepo accesstoken.Repository
+}
+
+func NewListPersonalAccessTokensQuery(tokenRepo accesstoken.Repository) IListPersonalAccessTokensQuery {
+	return &listPersonalAccessTokensQuery{
+		tokenRepo: tokenRepo,
+	}
+}
+
+func (l listPersonalAccessTokensQuery) Handle(ctx context.Context, _ *userdto.ListPersonalAccessTokensReq) (*userdto.ListPersonalAccessTokensRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	items, err := l.tokenRepo.FindByUserID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to list personal access tokens",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := &userdto.ListPersonalAccessTokensRes{
+		Items: make([]*userdto.PersonalAccessTokenRes, 0, len(items)),
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, &userdto.PersonalAccessTokenRes{
+			ID:         item.ID,
+			Name:       item.Name,
+			Prefix:     item.Prefix,
+			Scopes:     item.Scopes,
+			ExpiresAt:  item.ExpiresAt,
+			LastUsedAt: item.LastUsedAt,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,31 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestListPersonalAccessTokensQuery_Handle_Success(t *testing.T) {
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	tokenRepo.On("FindByUserID", mock.Anything, uint64(1)).Return([]*models.PersonalAccessToken{
+		{ID: 7, Name: "ci", Prefix: "pat_abcdefgh", TokenHash: "hash", Scopes: []string{"tasks:read"}, ExpiresAt: time.Now()},
+	}, nil)
+
+	qry := NewListPersonalAccessTokensQuery(tokenRepo)
+	res, err := qry.Handle(ctx, &userdto.ListPersonalAccessTokensReq{})
+
+	require.NoError(t, err)
+	require.Len(t, res.Items, 1)
+	require.Equal(t, "pat_abcdefgh", res.Items[0].Prefix)
+	require.Equal(t, []string{"tasks:read"}, res.Items[0].Scopes)
+}
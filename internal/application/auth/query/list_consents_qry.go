@@ -0,0 +1,58 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IListConsentsQuery decorator.QueryHandler[*userdto.ListConsentsReq, *userdto.ListConsentsRes]
+
+type listConsentsQuery struct {
+	oauthRepo oauth.Repository
+}
+
+func NewListConsentsQuery(oauthRepo oauth.Repository) IListConsentsQuery {
+	return &listConsentsQuery{
+		oauthRepo: oauthRepo,
+	}
+}
+
+func (l listConsentsQuery) Handle(ctx context.Context, _ *userdto.ListConsentsReq) (*userdto.ListConsentsRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	items, err := l.oauthRepo.FindConsentsByUserID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to list oauth consents",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := &userdto.ListConsentsRes{
+		Items: make([]*userdto.ConsentRes, 0, len(items)),
+	}
+	for _, item := range items {
+		client := &userdto.OAuthClientRes{ID: item.ClientID}
+		if item.Client != nil {
+			client.Name = item.Client.Name
+			client.FirstParty = item.Client.FirstParty
+		}
+		res.Items = append(res.Items, &userdto.ConsentRes{
+			Client:    client,
+			Scopes:    item.Scopes,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	return res, nil
+}
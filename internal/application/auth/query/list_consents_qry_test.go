@@ -0,0 +1,39 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestListConsentsQuery_Handle_Success(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	oauthRepo.On("FindConsentsByUserID", mock.Anything, uint64(1)).Return([]*models.OAuthConsent{
+		{UserID: 1, ClientID: "partner", Scopes: []string{"tasks:read"}, Client: &models.OAuthClient{ID: "partner", Name: "Partner"}},
+	}, nil)
+
+	qry := NewListConsentsQuery(oauthRepo)
+	res, err := qry.Handle(ctx, &userdto.ListConsentsReq{})
+
+	require.NoError(t, err)
+	require.Len(t, res.Items, 1)
+	require.Equal(t, "Partner", res.Items[0].Client.Name)
+	require.Equal(t, []string{"tasks:read"}, res.Items[0].Scopes)
+}
+
+func TestListConsentsQuery_Handle_NoPrincipal(t *testing.T) {
+	qry := NewListConsentsQuery(new(mocks.MockOAuthRepository))
+	res, err := qry.Handle(context.Background(), &userdto.ListConsentsReq{})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
@@ -0,0 +1,65 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IListIdentitiesQuery decorator.QueryHandler[*userdto.ListIdentitiesReq, *userdto.ListIdentitiesRes]
+
+type listIdentitiesQuery struct {
+	userRepo     user.Repository
+	identityRepo identity.Repository
+}
+
+func NewListIdentitiesQuery(userRepo user.Repository, identityRepo identity.Repository) IListIdentitiesQuery {
+	return &listIdentitiesQuery{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+	}
+}
+
+func (l listIdentitiesQuery) Handle(ctx context.Context, _ *userdto.ListIdentitiesReq) (*userdto.ListIdentitiesRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	account, err := l.userRepo.FindByID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to find user for identity list",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	items, err := l.identityRepo.FindByUserID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to list linked identities",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := &userdto.ListIdentitiesRes{
+		Items:       make([]*userdto.IdentityRes, 0, len(items)),
+		HasPassword: account.HasPassword(),
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, &userdto.IdentityRes{
+			ID:        item.ID,
+			Provider:  item.Provider,
+			Email:     item.Email,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestListIdentitiesQuery_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	identityRepo := new(mocks.MockIdentityRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1}, nil)
+	identityRepo.On("FindByUserID", mock.Anything, uint64(1)).Return([]*models.UserIdentity{
+		{ID: 3, UserID: 1, Provider: "google", Subject: "sub-1", Email: "user@example.com"},
+		{ID: 4, UserID: 1, Provider: "keycloak", Subject: "sub-2"},
+	}, nil)
+
+	qry := NewListIdentitiesQuery(userRepo, identityRepo)
+	res, err := qry.Handle(ctx, &userdto.ListIdentitiesReq{})
+
+	require.NoError(t, err)
+	require.False(t, res.HasPassword)
+	require.Len(t, res.Items, 2)
+	require.Equal(t, "google", res.Items[0].Provider)
+	require.Equal(t, uint64(4), res.Items[1].ID)
+}
+
+func TestListIdentitiesQuery_Handle_Unauthenticated(t *testing.T) {
+	qry := NewListIdentitiesQuery(new(mocks.MockUserRepository), new(mocks.MockIdentityRepository))
+	res, err := qry.Handle(context.Background(), &userdto.ListIdentitiesReq{})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
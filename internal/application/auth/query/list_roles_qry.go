@@ -0,0 +1,47 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/role"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IListRolesQuery decorator.QueryHandler[*userdto.ListRolesReq, *userdto.ListRolesRes]
+
+type listRolesQuery struct {
+	roleRepo role.Repository
+}
+
+func NewListRolesQuery(roleRepo role.Repository) IListRolesQuery {
+	return &listRolesQuery{
+		roleRepo: roleRepo,
+	}
+}
+
+func (l listRolesQuery) Handle(ctx context.Context, _ *userdto.ListRolesReq) (*userdto.ListRolesRes, error) {
+	items, err := l.roleRepo.FindAll(ctx)
+	if err != nil {
+		slog.Error("failed to list roles", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := &userdto.ListRolesRes{
+		Items: make([]*userdto.RoleRes, 0, len(items)),
+	}
+	for _, item := range items {
+		permissions := make([]string, 0, len(item.Permissions))
+		for _, p := range item.Permissions {
+			permissions = append(permissions, p.Name)
+		}
+		res.Items = append(res.Items, &userdto.RoleRes{
+			Name:        item.Name,
+			Description: item.Description,
+			Permissions: permissions,
+		})
+	}
+
+	return res, nil
+}
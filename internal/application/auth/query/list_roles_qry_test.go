@@ -0,0 +1,29 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestListRolesQuery_Handle_Success(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	roleRepo.On("FindAll", mock.Anything).Return([]*models.Role{
+		{Name: "admin", Description: "Full access", Permissions: []*models.Permission{{Name: "*"}}},
+		{Name: "user"},
+	}, nil)
+
+	qry := NewListRolesQuery(roleRepo)
+	res, err := qry.Handle(context.Background(), &userdto.ListRolesReq{})
+
+	require.NoError(t, err)
+	require.Len(t, res.Items, 2)
+	require.Equal(t, "admin", res.Items[0].Name)
+	require.Equal(t, []string{"*"}, res.Items[0].Permissions)
+	require.Empty(t, res.Items[1].Permissions)
+}
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IListSessionsQuery decorator.QueryHandler[*userdto.ListSessionsReq, *userdto.ListSessionsRes]
+
+type listSessionsQuery struct {
+	sessionRepo session.Repository
+}
+
+func NewListSessionsQuery(sessionRepo session.Repository) IListSessionsQuery {
+	return &listSessionsQuery{
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (l listSessionsQuery) Handle(ctx context.Context, _ *userdto.ListSessionsReq) (*userdto.ListSessionsRes, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, helper.ErrInvalidToken
+	}
+
+	items, err := l.sessionRepo.FindActiveByUserID(ctx, caller.UserID)
+	if err != nil {
+		slog.Error("failed to list active sessions",
+			slog.Uint64("user_id", caller.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := &userdto.ListSessionsRes{Items: make([]*userdto.SessionRes, 0, len(items))}
+	for _, item := range items {
+		res.Items = append(res.Items, &userdto.SessionRes{
+			ID:         item.ID,
+			UserAgent:  item.UserAgent,
+			IPAddress:  item.IPAddress,
+			Browser:    item.Browser,
+			OS:         item.OS,
+			DeviceType: item.DeviceType,
+			Country:    item.Country,
+			City:       item.City,
+			LastUsedAt: item.LastUsedAt,
+			CreatedAt:  item.CreatedAt,
+			IsCurrent:  item.ID == caller.SessionID,
+		})
+	}
+
+	return res, nil
+}
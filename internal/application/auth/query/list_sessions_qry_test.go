@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestListSessionsQuery_Handle_Success(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1, SessionID: "sess-1"})
+	sessionRepo.On("FindActiveByUserID", mock.Anything, uint64(1)).Return([]*models.Session{
+		{ID: "sess-1", UserID: 1, UserAgent: "ua-1", IPAddress: "10.0.0.1", IsActive: true},
+		{ID: "sess-2", UserID: 1, UserAgent: "ua-2", IPAddress: "10.0.0.2", IsActive: true},
+	}, nil)
+
+	qry := NewListSessionsQuery(sessionRepo)
+	res, err := qry.Handle(ctx, &userdto.ListSessionsReq{})
+
+	require.NoError(t, err)
+	require.Len(t, res.Items, 2)
+	require.True(t, res.Items[0].IsCurrent)
+	require.False(t, res.Items[1].IsCurrent)
+	require.Equal(t, "ua-2", res.Items[1].UserAgent)
+
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestListSessionsQuery_Handle_Unauthenticated(t *testing.T) {
+	qry := NewListSessionsQuery(new(mocks.MockSessionRepository))
+	res, err := qry.Handle(context.Background(), &userdto.ListSessionsReq{})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IListUserRolesQuery decorator.QueryHandler[*userdto.ListUserRolesReq, *userdto.UserRolesRes]
+
+type listUserRolesQuery struct {
+	userRepo   user.Repository
+	authorizer helper.Authorizer
+}
+
+func NewListUserRolesQuery(userRepo user.Repository, authorizer helper.Authorizer) IListUserRolesQuery {
+	return &listUserRolesQuery{
+		userRepo:   userRepo,
+		authorizer: authorizer,
+	}
+}
+
+func (l listUserRolesQuery) Handle(ctx context.Context, req *userdto.ListUserRolesReq) (*userdto.UserRolesRes, error) {
+	if _, err := l.userRepo.FindByID(ctx, req.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrUserNotFound
+		}
+		slog.Error("failed to find user for role list",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	grants, err := l.authorizer.Grants(ctx, req.UserID)
+	if err != nil {
+		slog.Error("failed to load user grants",
+			slog.Uint64("user_id", req.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userdto.UserRolesRes{
+		Roles:       grants.Roles,
+		Permissions: grants.Permissions,
+	}, nil
+}
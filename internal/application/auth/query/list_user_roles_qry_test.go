@@ -0,0 +1,41 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestListUserRolesQuery_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	authorizer := new(mocks.MockAuthorizer)
+
+	userRepo.On("FindByID", mock.Anything, uint64(2)).Return(&models.User{ID: 2}, nil)
+	authorizer.On("Grants", mock.Anything, uint64(2)).
+		Return(&helper.Grants{Roles: []string{"editor"}, Permissions: []string{"tasks:write"}}, nil)
+
+	qry := NewListUserRolesQuery(userRepo, authorizer)
+	res, err := qry.Handle(context.Background(), &userdto.ListUserRolesReq{UserID: 2})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"editor"}, res.Roles)
+	require.Equal(t, []string{"tasks:write"}, res.Permissions)
+}
+
+func TestListUserRolesQuery_Handle_UnknownUser(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindByID", mock.Anything, uint64(9)).Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	qry := NewListUserRolesQuery(userRepo, new(mocks.MockAuthorizer))
+	res, err := qry.Handle(context.Background(), &userdto.ListUserRolesReq{UserID: 9})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrUserNotFound)
+}
@@ -0,0 +1,76 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+type IListUsersQuery decorator.QueryHandler[*userdto.ListUsersReq, *pageable.ListResponse]
+
+type listUsersQuery struct {
+	userRepo user.Repository
+}
+
+func NewListUsersQuery(userRepo user.Repository) IListUsersQuery {
+	return &listUsersQuery{
+		userRepo: userRepo,
+	}
+}
+
+func (l listUsersQuery) Handle(ctx context.Context, req *userdto.ListUsersReq) (*pageable.ListResponse, error) {
+	items, total, err := l.userRepo.FindAllAndCount(ctx, user.GetListParams{
+		ListQuery: req.ListQuery,
+		IsActive:  req.IsActive,
+		Provider:  strings.TrimSpace(req.Provider),
+	})
+	if err != nil {
+		if errors.Is(err, pageable.ErrInvalidSortField) {
+			return nil, err
+		}
+		slog.Error("failed to list users", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := make([]*userdto.AdminUserRes, 0, len(items))
+	for _, item := range items {
+		res = append(res, toAdminUserRes(item))
+	}
+
+	return pageable.NewListResponse(&req.ListQuery, res, total), nil
+}
+
+// toAdminUserRes describes an account for administrators. Identities must be
+// loaded for the providers to be listed.
+func toAdminUserRes(item *models.User) *userdto.AdminUserRes {
+	providers := make([]string, 0, len(item.Identities)+1)
+	if item.HasPassword() {
+		providers = append(providers, user.ProviderPassword)
+	}
+	for _, identity := range item.Identities {
+		providers = append(providers, identity.Provider)
+	}
+
+	return &userdto.AdminUserRes{
+		ID:              item.ID,
+		Username:        item.Username,
+		Email:           item.Email,
+		FirstName:       item.FirstName,
+		LastName:        item.LastName,
+		Providers:       providers,
+		EmailVerifiedAt: item.EmailVerifiedAt,
+		LastLoginAt:     item.LastLoginAt,
+		IsActive:        item.IsActive,
+		DeletedAt:       item.DeletedAt,
+		StatusReason:    item.StatusReason,
+		StatusChangedAt: item.StatusChangedAt,
+		CreatedAt:       item.CreatedAt,
+	}
+}
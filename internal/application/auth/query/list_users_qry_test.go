@@ -0,0 +1,85 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/mocks"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+func TestListUsersQuery_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	userRepo.On("FindAllAndCount", mock.Anything, mock.MatchedBy(func(params user.GetListParams) bool {
+		return params.GetOffset() == 10 && params.GetLimit() == 10 &&
+			*params.Search == " jane " && params.Provider == "google" &&
+			params.IsActive != nil && *params.IsActive &&
+			*params.FromDate == "2026-01-01" && *params.ToDate == "2026-01-31" &&
+			*params.SortField == "lastLoginAt"
+	})).Return([]*models.User{{
+		ID:           7,
+		Username:     "jane",
+		PasswordHash: "hash",
+		IsActive:     true,
+		Identities:   []*models.UserIdentity{{Provider: "google"}},
+	}}, int64(11), nil)
+
+	qry := NewListUsersQuery(userRepo)
+	res, err := qry.Handle(context.Background(), &userdto.ListUsersReq{
+		ListQuery: pageable.ListQuery{
+			Page:          2,
+			Size:          10,
+			Search:        new(" jane "),
+			SortField:     new("lastLoginAt"),
+			SortDirection: new(pageable.ASCENDING),
+			FromDate:      new("2026-01-01"),
+			ToDate:        new("2026-01-31"),
+		},
+		IsActive: new(true),
+		Provider: "google",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 11, res.Total)
+	require.Equal(t, 2, res.Page)
+	require.False(t, res.HasMore)
+	items := res.Items.([]*userdto.AdminUserRes)
+	require.Len(t, items, 1)
+	require.Equal(t, []string{user.ProviderPassword, "google"}, items[0].Providers)
+}
+
+func TestListUsersQuery_Handle_Defaults(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	userRepo.On("FindAllAndCount", mock.Anything, mock.MatchedBy(func(params user.GetListParams) bool {
+		return params.GetOffset() == 0 && params.GetLimit() == 15 && params.SortField == nil && params.IsActive == nil
+	})).Return([]*models.User{}, int64(0), nil)
+
+	qry := NewListUsersQuery(userRepo)
+	res, err := qry.Handle(context.Background(), &userdto.ListUsersReq{})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Page)
+	require.Equal(t, 15, res.Size)
+	require.Empty(t, res.Items)
+}
+
+func TestListUsersQuery_Handle_InvalidSortField(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	userRepo.On("FindAllAndCount", mock.Anything, mock.Anything).Return(nil, int64(0), pageable.ErrInvalidSortField)
+
+	qry := NewListUsersQuery(userRepo)
+	res, err := qry.Handle(context.Background(), &userdto.ListUsersReq{
+		ListQuery: pageable.ListQuery{SortField: new("password_hash")},
+	})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, pageable.ErrInvalidSortField)
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
 	"github.com/tdatIT/backend-go/internal/domain/models"
 	"github.com/tdatIT/backend-go/internal/infras/httpclient/oidc"
 	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
 )
 
 func TestLoginByGoogleQuery_Handle_Success(t *testing.T) {
@@ -20,30 +22,26 @@ func TestLoginByGoogleQuery_Handle_Success(t *testing.T) {
 	tokenManager := new(mocks.MockTokenManager)
 	verifier := new(mocks.MockGoogleOIDCVerifier)
 
-	cfg := &config.ServiceConfig{
-		Auth: config.Auth{GoogleClientID: "client"},
-	}
-
-	info := &oidc.GoogleIDTokenInfo{
+	info := &oidc.IDTokenClaims{
 		Email:         "user@example.com",
-		EmailVerified: "true",
+		EmailVerified: true,
 		Subject:       "sub-1",
-		Audience:      "client",
+		Audience:      []string{"client"},
 		GivenName:     "Test",
 		FamilyName:    "User",
 	}
 
-	verifier.On("VerifyIDToken", mock.Anything, "idtoken").Return(info, nil)
+	verifier.On("VerifyIDToken", mock.Anything, "idtoken", "n-1").Return(info, nil)
 	userRepo.On("FindByOIDC", mock.Anything, "google", info.Subject).
-		Return(&models.User{ID: 5, OidcProvider: "google", OidcSubject: info.Subject}, nil)
+		Return(&models.User{ID: 5, IsActive: true}, nil)
 	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
 
 	accessExp := time.Now().Add(time.Hour)
-	tokenManager.On("GenerateTokens", uint64(5), mock.Anything, mock.Anything).
+	tokenManager.On("GenerateTokens", uint64(5), mock.Anything, mock.Anything, mock.Anything).
 		Return("access", "refresh", accessExp, nil)
 
-	qry := NewLoginByGoogleQuery(userRepo, sessionRepo, tokenManager, verifier, cfg)
-	res, err := qry.Handle(context.Background(), &userdto.LoginByGoogleReq{IDToken: "idtoken"})
+	qry := NewLoginByGoogleQuery(&config.ServiceConfig{}, userRepo, new(mocks.MockIdentityRepository), sessionRepo, tokenManager, testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByGoogleReq{IDToken: "idtoken", Nonce: "n-1"})
 
 	require.NoError(t, err)
 	require.Equal(t, "access", res.AccessToken)
@@ -55,3 +53,56 @@ func TestLoginByGoogleQuery_Handle_Success(t *testing.T) {
 	tokenManager.AssertExpectations(t)
 	verifier.AssertExpectations(t)
 }
+
+func TestLoginByGoogleQuery_Handle_NewUserIsVerified(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	verifier := new(mocks.MockGoogleOIDCVerifier)
+
+	info := &oidc.IDTokenClaims{
+		Email:         "new@example.com",
+		EmailVerified: true,
+		Subject:       "sub-2",
+		Audience:      []string{"client"},
+	}
+
+	verifier.On("VerifyIDToken", mock.Anything, "idtoken", "n-1").Return(info, nil)
+	userRepo.On("FindByOIDC", mock.Anything, "google", info.Subject).
+		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	userRepo.On("FindByEmail", mock.Anything, info.Email).
+		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	userRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.User) bool {
+		return item.EmailVerifiedAt != nil && !item.HasPassword() &&
+			len(item.Identities) == 1 && item.Identities[0].Subject == info.Subject
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.User).ID = 6
+	}).Return(nil)
+	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
+	tokenManager.On("GenerateTokens", uint64(6), mock.Anything, mock.Anything, mock.Anything).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+
+	qry := NewLoginByGoogleQuery(&config.ServiceConfig{}, userRepo, new(mocks.MockIdentityRepository), sessionRepo, tokenManager, testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByGoogleReq{IDToken: "idtoken", Nonce: "n-1"})
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), res.User.ID)
+
+	userRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestLoginByGoogleQuery_Handle_UnverifiedEmail(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	verifier := new(mocks.MockGoogleOIDCVerifier)
+
+	info := &oidc.IDTokenClaims{Email: "user@example.com", Subject: "sub-1"}
+	verifier.On("VerifyIDToken", mock.Anything, "idtoken", "n-1").Return(info, nil)
+
+	qry := NewLoginByGoogleQuery(&config.ServiceConfig{}, userRepo, new(mocks.MockIdentityRepository), new(mocks.MockSessionRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByGoogleReq{IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+	userRepo.AssertNotCalled(t, "FindByOIDC", mock.Anything, mock.Anything, mock.Anything)
+}
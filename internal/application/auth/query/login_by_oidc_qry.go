@@ -0,0 +1,84 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/httpclient/oidc"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type ILoginByOIDCQuery decorator.QueryHandler[*userdto.LoginByOIDCReq, *userdto.LoginRes]
+
+type loginByOIDCQuery struct {
+	verifier oidc.IDTokenVerifier
+	nonces   helper.OIDCNonces
+	resolver oidcAccountResolver
+	issuer   sessionIssuer
+}
+
+func NewLoginByOIDCQuery(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	identityRepo identity.Repository,
+	sessionRepo session.Repository,
+	tokenManager security.TokenManager,
+	authorizer helper.Authorizer,
+	monitor helper.LoginMonitor,
+	verifier oidc.IDTokenVerifier,
+	cacheClient cache.Cache,
+) ILoginByOIDCQuery {
+	return &loginByOIDCQuery{
+		verifier: verifier,
+		nonces:   helper.OIDCNonces{Cache: cacheClient},
+		resolver: newOIDCAccountResolver(config, userRepo, identityRepo),
+		issuer: sessionIssuer{
+			sessionRepo:  sessionRepo,
+			tokenManager: tokenManager,
+			authorizer:   authorizer,
+			monitor:      monitor,
+		},
+	}
+}
+
+func (l loginByOIDCQuery) Handle(ctx context.Context, req *userdto.LoginByOIDCReq) (*userdto.LoginRes, error) {
+	if err := l.nonces.Consume(ctx, req.Nonce); err != nil {
+		return nil, err
+	}
+
+	claims, err := l.verifier.VerifyIDToken(ctx, req.Provider, req.IDToken, req.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrUnknownProvider) {
+			return nil, helper.ErrUnknownOIDCProvider
+		}
+
+		slog.Error("failed to verify oidc id token",
+			slog.String("provider", req.Provider),
+			slog.String("error", err.Error()))
+		return nil, helper.ErrInvalidToken
+	}
+
+	account, err := l.resolver.resolve(ctx, &externalIdentity{
+		Provider:      req.Provider,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+		Name:          claims.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return l.issuer.issue(ctx, account, req.UserAgent, req.IPAddress)
+}
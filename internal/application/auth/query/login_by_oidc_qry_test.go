@@ -0,0 +1,165 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/httpclient/oidc"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+// linkByEmailConfig trusts providers to sign in to existing accounts by email.
+func linkByEmailConfig(providers ...string) *config.ServiceConfig {
+	cfg := &config.ServiceConfig{}
+	cfg.Auth.OIDCLinkByEmail = providers
+	return cfg
+}
+
+// issuedNonce returns a cache holding nonce as handed out and not yet used.
+func issuedNonce(nonce string) *mocks.MockCache {
+	cacheClient := new(mocks.MockCache)
+	cacheClient.On("Incr", mock.Anything, helper.OIDCNonceKey(nonce), helper.OIDCNonceTTL).Return(int64(2), nil)
+	cacheClient.On("Delete", mock.Anything, helper.OIDCNonceKey(nonce)).Return(nil)
+	return cacheClient
+}
+
+func TestLoginByOIDCQuery_Handle_LinksExistingAccount(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	identityRepo := new(mocks.MockIdentityRepository)
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	claims := &oidc.IDTokenClaims{Subject: "sub-1", Email: "user@example.com", EmailVerified: true}
+	verifier.On("VerifyIDToken", mock.Anything, "keycloak", "idtoken", "n-1").Return(claims, nil)
+	userRepo.On("FindByOIDC", mock.Anything, "keycloak", "sub-1").Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	userRepo.On("FindByEmail", mock.Anything, "user@example.com").
+		Return(&models.User{ID: 7, IsActive: true, Email: "user@example.com"}, nil)
+	identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.UserIdentity) bool {
+		return item.UserID == 7 && item.Provider == "keycloak" && item.Subject == "sub-1"
+	})).Return(nil)
+	userRepo.On("MarkEmailVerified", mock.Anything, uint64(7), mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
+	tokenManager.On("GenerateTokens", uint64(7), mock.Anything, mock.Anything, mock.Anything).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+
+	qry := NewLoginByOIDCQuery(linkByEmailConfig("keycloak"), userRepo, identityRepo, sessionRepo, tokenManager, testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByOIDCReq{Provider: "keycloak", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.NoError(t, err)
+	require.Equal(t, "access", res.AccessToken)
+	require.Equal(t, uint64(7), res.User.ID)
+	userRepo.AssertExpectations(t)
+	identityRepo.AssertExpectations(t)
+}
+
+func TestLoginByOIDCQuery_Handle_UnverifiedEmailIsNotLinked(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	claims := &oidc.IDTokenClaims{Subject: "sub-1", Email: "user@example.com"}
+	verifier.On("VerifyIDToken", mock.Anything, "okta", "idtoken", "n-1").Return(claims, nil)
+	userRepo.On("FindByOIDC", mock.Anything, "okta", "sub-1").Return((*models.User)(nil), gorm.ErrRecordNotFound)
+
+	qry := NewLoginByOIDCQuery(&config.ServiceConfig{}, userRepo, new(mocks.MockIdentityRepository), new(mocks.MockSessionRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByOIDCReq{Provider: "okta", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+	userRepo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+}
+
+func TestLoginByOIDCQuery_Handle_UnknownProvider(t *testing.T) {
+	verifier := new(mocks.MockIDTokenVerifier)
+	verifier.On("VerifyIDToken", mock.Anything, "missing", "idtoken", "n-1").Return(nil, oidc.ErrUnknownProvider)
+
+	qry := NewLoginByOIDCQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockIdentityRepository), new(mocks.MockSessionRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByOIDCReq{Provider: "missing", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrUnknownOIDCProvider)
+}
+
+func TestLoginByOIDCQuery_Handle_InvalidToken(t *testing.T) {
+	verifier := new(mocks.MockIDTokenVerifier)
+	verifier.On("VerifyIDToken", mock.Anything, "okta", "idtoken", "n-1").
+		Return(nil, errors.Join(oidc.ErrInvalidIDToken, errors.New("token is expired")))
+
+	qry := NewLoginByOIDCQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockIdentityRepository), new(mocks.MockSessionRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByOIDCReq{Provider: "okta", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
+
+func TestLoginByOIDCQuery_Handle_LinkConflictUsesOwner(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	identityRepo := new(mocks.MockIdentityRepository)
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	claims := &oidc.IDTokenClaims{Subject: "sub-1", Email: "user@example.com", EmailVerified: true}
+	verifier.On("VerifyIDToken", mock.Anything, "keycloak", "idtoken", "n-1").Return(claims, nil)
+	userRepo.On("FindByOIDC", mock.Anything, "keycloak", "sub-1").
+		Return((*models.User)(nil), gorm.ErrRecordNotFound).Once()
+	userRepo.On("FindByEmail", mock.Anything, "user@example.com").
+		Return(&models.User{ID: 7, IsActive: true, Email: "user@example.com", EmailVerifiedAt: new(time.Now())}, nil)
+	identityRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.UserIdentity")).Return(gorm.ErrDuplicatedKey)
+	userRepo.On("FindByOIDC", mock.Anything, "keycloak", "sub-1").
+		Return(&models.User{ID: 7, IsActive: true, Email: "user@example.com", EmailVerifiedAt: new(time.Now())}, nil).Once()
+	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
+	tokenManager.On("GenerateTokens", uint64(7), mock.Anything, mock.Anything, mock.Anything).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+
+	qry := NewLoginByOIDCQuery(linkByEmailConfig("keycloak"), userRepo, identityRepo, sessionRepo, tokenManager, testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByOIDCReq{Provider: "keycloak", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), res.User.ID)
+	userRepo.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginByOIDCQuery_Handle_ReusedNonce(t *testing.T) {
+	verifier := new(mocks.MockIDTokenVerifier)
+	cacheClient := new(mocks.MockCache)
+
+	cacheClient.On("Incr", mock.Anything, helper.OIDCNonceKey("n-1"), helper.OIDCNonceTTL).Return(int64(3), nil)
+	cacheClient.On("Delete", mock.Anything, helper.OIDCNonceKey("n-1")).Return(nil)
+
+	qry := NewLoginByOIDCQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockIdentityRepository), new(mocks.MockSessionRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), verifier, cacheClient)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByOIDCReq{Provider: "okta", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidOIDCNonce)
+	verifier.AssertNotCalled(t, "VerifyIDToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginByOIDCQuery_Handle_UntrustedProviderDoesNotLinkByEmail(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	identityRepo := new(mocks.MockIdentityRepository)
+	verifier := new(mocks.MockIDTokenVerifier)
+
+	claims := &oidc.IDTokenClaims{Subject: "sub-1", Email: "user@example.com", EmailVerified: true}
+	verifier.On("VerifyIDToken", mock.Anything, "okta", "idtoken", "n-1").Return(claims, nil)
+	userRepo.On("FindByOIDC", mock.Anything, "okta", "sub-1").Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	userRepo.On("FindByEmail", mock.Anything, "user@example.com").
+		Return(&models.User{ID: 7, IsActive: true, Email: "user@example.com"}, nil)
+
+	qry := NewLoginByOIDCQuery(linkByEmailConfig("keycloak"), userRepo, identityRepo, new(mocks.MockSessionRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), verifier, issuedNonce("n-1"))
+	res, err := qry.Handle(context.Background(), &userdto.LoginByOIDCReq{Provider: "okta", IDToken: "idtoken", Nonce: "n-1"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrIdentityLinkRequired)
+	identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
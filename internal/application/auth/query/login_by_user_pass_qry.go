@@ -2,47 +2,84 @@ package query
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/tdatIT/backend-go/config"
 	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
 	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
 	"github.com/tdatIT/backend-go/internal/infras/repository/session"
 	"github.com/tdatIT/backend-go/internal/infras/repository/user"
 	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
 	"github.com/tdatIT/backend-go/pkgs/decorator"
-	"github.com/tdatIT/backend-go/pkgs/utils/genid"
 	"gorm.io/gorm"
 )
 
+const defaultMFAChallengeTTL = 5 * time.Minute
+
 type ILoginByUsrnameAndPwdQuery decorator.QueryHandler[*userdto.LoginByUserPassReq, *userdto.LoginRes]
 
 type loginByUsrnameAndPwdQuery struct {
-	userRepo     user.Repository
-	sessionRepo  session.Repository
-	tokenManager security.TokenManager
+	config            *config.ServiceConfig
+	userRepo          user.Repository
+	mfaRepo           mfa.Repository
+	securityEventRepo securityevent.Repository
+	cache             cache.Cache
+	hasher            *security.PasswordHasher
+	throttle          helper.LoginThrottle
+	issuer            sessionIssuer
 }
 
 func NewLoginByUsrnameAndPwdQuery(
+	config *config.ServiceConfig,
 	userRepo user.Repository,
 	sessionRepo session.Repository,
+	mfaRepo mfa.Repository,
+	securityEventRepo securityevent.Repository,
 	tokenManager security.TokenManager,
+	authorizer helper.Authorizer,
+	monitor helper.LoginMonitor,
+	cacheClient cache.Cache,
+	hasher *security.PasswordHasher,
 ) ILoginByUsrnameAndPwdQuery {
 	return &loginByUsrnameAndPwdQuery{
-		userRepo:     userRepo,
-		sessionRepo:  sessionRepo,
-		tokenManager: tokenManager,
+		config:            config,
+		userRepo:          userRepo,
+		mfaRepo:           mfaRepo,
+		securityEventRepo: securityEventRepo,
+		cache:             cacheClient,
+		hasher:            hasher,
+		throttle:          helper.NewLoginThrottle(config, cacheClient),
+		issuer: sessionIssuer{
+			sessionRepo:  sessionRepo,
+			tokenManager: tokenManager,
+			authorizer:   authorizer,
+			monitor:      monitor,
+		},
 	}
 }
 
 func (l loginByUsrnameAndPwdQuery) Handle(ctx context.Context, req *userdto.LoginByUserPassReq) (*userdto.LoginRes, error) {
+	attempt, err := l.throttle.Reserve(ctx, req.Username, req.IPAddress)
+	if err != nil {
+		slog.Warn("login rejected by throttle",
+			slog.String("username", req.Username),
+			slog.String("ip_address", req.IPAddress))
+		return nil, err
+	}
+
 	account, err := l.userRepo.FindByUsername(ctx, req.Username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, helper.ErrUserNotFound
+			// unknown users get the same work and the same error as a wrong password
+			l.hasher.CompareDummy(req.Password)
+			return nil, l.fail(ctx, req, attempt, nil)
 		}
 
 		slog.Error("failed to find user by username",
@@ -51,49 +88,144 @@ func (l loginByUsrnameAndPwdQuery) Handle(ctx context.Context, req *userdto.Logi
 		return nil, err
 	}
 
-	if err := security.ComparePassword(account.PasswordHash, req.Password); err != nil {
+	if !account.HasPassword() {
+		// accounts created through an external identity have no password yet
+		l.hasher.CompareDummy(req.Password)
+		return nil, l.fail(ctx, req, attempt, account)
+	}
+
+	rehash, err := l.hasher.Verify(account.PasswordHash, req.Password)
+	if err != nil {
 		slog.Warn("invalid password for user",
 			slog.String("username", req.Username),
 			slog.String("error", err.Error()))
-		return nil, helper.ErrInvalidUserOrPwd
+		return nil, l.fail(ctx, req, attempt, account)
+	}
+
+	if rehash {
+		l.upgradeHash(ctx, account, req.Password)
 	}
 
-	refreshJTI := uuid.NewString()
-	sessionItem := &models.Session{
-		ID:         genid.GenerateNanoID(),
-		UserID:     account.ID,
-		RefreshJTI: refreshJTI,
-		UserAgent:  req.UserAgent,
-		IPAddress:  req.IPAddress,
-		IsActive:   true,
-		LastUsedAt: new(time.Now()),
+	// checked after the password so the status is not revealed to guessers
+	if !account.CanSignIn() {
+		slog.Warn("login rejected for disabled account", slog.Uint64("user_id", account.ID))
+		l.throttle.Release(ctx, attempt)
+		return nil, helper.ErrAccountDisabled
 	}
 
-	if err := l.sessionRepo.Create(ctx, sessionItem); err != nil {
-		slog.Error("failed to create session",
+	if l.config.Auth.RequireVerifiedEmail && account.EmailVerifiedAt == nil {
+		slog.Warn("login rejected for unverified email", slog.Uint64("user_id", account.ID))
+		l.throttle.Release(ctx, attempt)
+		return nil, helper.ErrEmailNotVerified
+	}
+
+	mfaItem, err := l.mfaRepo.FindByUserID(ctx, account.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("failed to find mfa enrollment",
 			slog.Uint64("user_id", account.ID),
 			slog.String("error", err.Error()))
+		l.throttle.Release(ctx, attempt)
 		return nil, err
 	}
+	if mfaItem != nil && mfaItem.Enabled {
+		// earlier failures are kept until the second factor succeeds, so new
+		// challenges cannot buy more code guesses
+		l.throttle.Release(ctx, attempt)
+		return l.challenge(ctx, account)
+	}
 
-	accessToken, refreshToken, accessExp, err := l.tokenManager.GenerateTokens(account.ID, sessionItem.ID, refreshJTI)
+	l.throttle.Reset(ctx, attempt)
+	return l.issuer.issue(ctx, account, req.UserAgent, req.IPAddress)
+}
+
+// fail records a failed attempt and logs any lockout it triggers. It always
+// returns ErrInvalidUserOrPwd so callers cannot tell whether the account exists.
+func (l loginByUsrnameAndPwdQuery) fail(
+	ctx context.Context,
+	req *userdto.LoginByUserPassReq,
+	attempt helper.LoginAttempt,
+	account *models.User,
+) error {
+	result := l.throttle.RecordFailure(ctx, attempt)
+	if result.IPLocked {
+		slog.Warn("login locked out for ip address", slog.String("ip_address", req.IPAddress))
+	}
+	if !result.UserLocked {
+		return helper.ErrInvalidUserOrPwd
+	}
+
+	slog.Warn("login locked out for username",
+		slog.String("username", req.Username),
+		slog.String("ip_address", req.IPAddress))
+
+	if account != nil {
+		if err := l.securityEventRepo.Create(ctx, &models.SecurityEvent{
+			UserID:    account.ID,
+			Type:      models.SecurityEventLoginLockout,
+			IPAddress: req.IPAddress,
+			UserAgent: req.UserAgent,
+			Details:   "too many failed login attempts",
+		}); err != nil {
+			slog.Error("failed to record login lockout event",
+				slog.Uint64("user_id", account.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return helper.ErrInvalidUserOrPwd
+}
+
+// challenge parks the login behind a short-lived token that must be
+// exchanged together with a second-factor code.
+func (l loginByUsrnameAndPwdQuery) challenge(ctx context.Context, account *models.User) (*userdto.LoginRes, error) {
+	ttl := l.config.Auth.MFAChallengeTTL
+	if ttl <= 0 {
+		ttl = defaultMFAChallengeTTL
+	}
+
+	token, _, err := security.GenerateOpaqueToken()
 	if err != nil {
-		slog.Error("failed to generate tokens for user",
+		return nil, err
+	}
+
+	payload, err := json.Marshal(&helper.MFAChallenge{
+		UserID:    account.ID,
+		Username:  account.Username,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.cache.Set(ctx, helper.MFAChallengeKey(token), payload, ttl); err != nil {
+		slog.Error("failed to store mfa challenge",
 			slog.Uint64("user_id", account.ID),
 			slog.String("error", err.Error()))
 		return nil, err
 	}
 
 	return &userdto.LoginRes{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    int64(time.Until(accessExp).Seconds()),
-		User: &userdto.UserProfileRes{
-			ID:        account.ID,
-			FirstName: account.FirstName,
-			LastName:  account.LastName,
-			Email:     account.Email,
-			Username:  account.Username,
-		},
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   int64(ttl.Seconds()),
 	}, nil
 }
+
+// upgradeHash re-hashes the password with the current algorithm and
+// parameters. Failures are logged only; the old hash keeps working.
+func (l loginByUsrnameAndPwdQuery) upgradeHash(ctx context.Context, account *models.User, password string) {
+	newHash, err := l.hasher.Hash(password)
+	if err != nil {
+		slog.Error("failed to rehash password", slog.Uint64("user_id", account.ID), slog.String("error", err.Error()))
+		return
+	}
+
+	if err := l.userRepo.UpgradePasswordHash(ctx, account.ID, account.PasswordHash, newHash); err != nil {
+		slog.Error("failed to store upgraded password hash",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	account.PasswordHash = newHash
+}
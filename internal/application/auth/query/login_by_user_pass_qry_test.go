@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
 	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
 	"github.com/tdatIT/backend-go/internal/domain/models"
@@ -15,23 +16,59 @@ import (
 	"gorm.io/gorm"
 )
 
+// testPasswordHasher matches the bcrypt fixtures so logins do not trigger a rehash
+var testPasswordHasher = security.NewPasswordHasher(security.PasswordHashConfig{
+	Algorithm:  security.PasswordAlgorithmBcrypt,
+	BcryptCost: security.DefaultCost,
+})
+
+// newUnthrottledCache returns a cache on which no login key is blocked and
+// every reserved login is counted as the given attempt.
+func newUnthrottledCache(attempt int64) *mocks.MockCache {
+	cacheClient := new(mocks.MockCache)
+	cacheClient.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	cacheClient.On("Incr", mock.Anything, mock.Anything, 15*time.Minute).Return(attempt, nil)
+	cacheClient.On("Decr", mock.Anything, mock.Anything).Return(attempt-1, nil)
+	cacheClient.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	return cacheClient
+}
+
+// testAuthorizer grants the given roles to every user.
+func testAuthorizer(roles ...string) *mocks.MockAuthorizer {
+	authorizer := new(mocks.MockAuthorizer)
+	authorizer.On("Grants", mock.Anything, mock.Anything).
+		Return(&helper.Grants{Roles: roles}, nil).Maybe()
+	return authorizer
+}
+
+func testLoginMonitor() *mocks.MockLoginMonitor {
+	monitor := new(mocks.MockLoginMonitor)
+	monitor.On("Describe", mock.Anything).Maybe()
+	monitor.On("Check", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	return monitor
+}
+
 func TestLoginByUsrnameAndPwdQuery_Handle_Success(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	sessionRepo := new(mocks.MockSessionRepository)
 	tokenManager := new(mocks.MockTokenManager)
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := newUnthrottledCache(1)
 
 	passwordHash, err := security.HashPassword("pass1234", security.DefaultCost)
 	require.NoError(t, err)
 
-	account := &models.User{ID: 10, Username: "user", PasswordHash: passwordHash}
+	account := &models.User{ID: 10, IsActive: true, Username: "user", PasswordHash: passwordHash}
 	userRepo.On("FindByUsername", mock.Anything, "user").Return(account, nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return((*models.UserMFA)(nil), gorm.ErrRecordNotFound)
 	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
 
 	accessExp := time.Now().Add(time.Hour)
-	tokenManager.On("GenerateTokens", uint64(10), mock.Anything, mock.Anything).
+	tokenManager.On("GenerateTokens", uint64(10), mock.Anything, mock.Anything, security.AccessClaims{Roles: []string{"admin"}}).
 		Return("access", "refresh", accessExp, nil)
 
-	qry := NewLoginByUsrnameAndPwdQuery(userRepo, sessionRepo, tokenManager)
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, sessionRepo, mfaRepo,
+		new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer("admin"), testLoginMonitor(), cacheClient, testPasswordHasher)
 	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
 		Username:  "user",
 		Password:  "pass1234",
@@ -46,24 +83,249 @@ func TestLoginByUsrnameAndPwdQuery_Handle_Success(t *testing.T) {
 	userRepo.AssertExpectations(t)
 	sessionRepo.AssertExpectations(t)
 	tokenManager.AssertExpectations(t)
+	cacheClient.AssertCalled(t, "Delete", mock.Anything, "login:fail:user:user")
+	cacheClient.AssertNotCalled(t, "Delete", mock.Anything, "login:fail:ip:127.0.0.1")
+	cacheClient.AssertCalled(t, "Decr", mock.Anything, "login:fail:ip:127.0.0.1")
 }
 
 func TestLoginByUsrnameAndPwdQuery_Handle_UserNotFound(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	sessionRepo := new(mocks.MockSessionRepository)
 	tokenManager := new(mocks.MockTokenManager)
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := newUnthrottledCache(1)
 
 	userRepo.On("FindByUsername", mock.Anything, "user").
 		Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	cacheClient.On("Set", mock.Anything, mock.Anything, 1, time.Second).Return(nil)
+
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, sessionRepo, mfaRepo,
+		new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer(), testLoginMonitor(), cacheClient, testPasswordHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
+		Username:  "user",
+		Password:  "pass1234",
+		IPAddress: "127.0.0.1",
+	})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidUserOrPwd)
+
+	userRepo.AssertExpectations(t)
+	cacheClient.AssertCalled(t, "Incr", mock.Anything, "login:fail:user:user", 15*time.Minute)
+	cacheClient.AssertCalled(t, "Incr", mock.Anything, "login:fail:ip:127.0.0.1", 15*time.Minute)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_WrongPasswordBacksOff(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	cacheClient := newUnthrottledCache(3)
+
+	passwordHash, err := security.HashPassword("pass1234", security.DefaultCost)
+	require.NoError(t, err)
+
+	userRepo.On("FindByUsername", mock.Anything, "user").
+		Return(&models.User{ID: 10, IsActive: true, Username: "user", PasswordHash: passwordHash}, nil)
+	cacheClient.On("Set", mock.Anything, "login:block:user:user", 1, 4*time.Second).Return(nil)
+
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, new(mocks.MockSessionRepository),
+		new(mocks.MockMFARepository), new(mocks.MockSecurityEventRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, testPasswordHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
+		Username: "user",
+		Password: "wrong",
+	})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidUserOrPwd)
+	cacheClient.AssertCalled(t, "Set", mock.Anything, "login:block:user:user", 1, 4*time.Second)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_LockoutRecordsEvent(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+	cacheClient := newUnthrottledCache(3)
+
+	passwordHash, err := security.HashPassword("pass1234", security.DefaultCost)
+	require.NoError(t, err)
+
+	cfg := &config.ServiceConfig{Auth: config.Auth{LoginMaxAttempts: 3, LoginLockoutDuration: time.Hour}}
+	userRepo.On("FindByUsername", mock.Anything, "user").
+		Return(&models.User{ID: 10, IsActive: true, Username: "user", PasswordHash: passwordHash}, nil)
+	cacheClient.On("Set", mock.Anything, "login:block:user:user", 1, time.Hour).Return(nil)
+	cacheClient.On("Expire", mock.Anything, "login:fail:user:user", time.Hour).Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.SecurityEvent) bool {
+		return e.UserID == 10 && e.Type == models.SecurityEventLoginLockout
+	})).Return(nil)
+
+	qry := NewLoginByUsrnameAndPwdQuery(cfg, userRepo, new(mocks.MockSessionRepository),
+		new(mocks.MockMFARepository), securityEventRepo, new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, testPasswordHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
+		Username: "user",
+		Password: "wrong",
+	})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidUserOrPwd)
+	cacheClient.AssertCalled(t, "Set", mock.Anything, "login:block:user:user", 1, time.Hour)
+	cacheClient.AssertCalled(t, "Expire", mock.Anything, "login:fail:user:user", time.Hour)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_Locked(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	cacheClient := new(mocks.MockCache)
+
+	cacheClient.On("Exists", mock.Anything, "login:block:user:user").Return(true, nil)
+
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, new(mocks.MockSessionRepository),
+		new(mocks.MockMFARepository), new(mocks.MockSecurityEventRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, testPasswordHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
+		Username: "user",
+		Password: "pass1234",
+	})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrLoginLocked)
+	userRepo.AssertNotCalled(t, "FindByUsername", mock.Anything, mock.Anything)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_NoAttemptsLeft(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+
+	// a parallel burst has already reserved every attempt
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, new(mocks.MockSessionRepository),
+		new(mocks.MockMFARepository), new(mocks.MockSecurityEventRepository), new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), newUnthrottledCache(6), testPasswordHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
+		Username: "user",
+		Password: "pass1234",
+	})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrLoginLocked)
+	userRepo.AssertNotCalled(t, "FindByUsername", mock.Anything, mock.Anything)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_EmailNotVerified(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	mfaRepo := new(mocks.MockMFARepository)
+
+	passwordHash, err := security.HashPassword("pass1234", security.DefaultCost)
+	require.NoError(t, err)
+
+	cfg := &config.ServiceConfig{Auth: config.Auth{RequireVerifiedEmail: true}}
+	userRepo.On("FindByUsername", mock.Anything, "user").
+		Return(&models.User{ID: 10, IsActive: true, Username: "user", PasswordHash: passwordHash}, nil)
+
+	qry := NewLoginByUsrnameAndPwdQuery(cfg, userRepo, sessionRepo, mfaRepo,
+		new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer(), testLoginMonitor(), newUnthrottledCache(1), testPasswordHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
+		Username: "user",
+		Password: "pass1234",
+	})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrEmailNotVerified)
+
+	userRepo.AssertExpectations(t)
+	sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_MFARequired(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := newUnthrottledCache(1)
+
+	passwordHash, err := security.HashPassword("pass1234", security.DefaultCost)
+	require.NoError(t, err)
+
+	userRepo.On("FindByUsername", mock.Anything, "user").
+		Return(&models.User{ID: 10, IsActive: true, Username: "user", PasswordHash: passwordHash}, nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(&models.UserMFA{UserID: 10, Enabled: true}, nil)
+
+	var storedKey string
+	cacheClient.On("Set", mock.Anything, mock.Anything, mock.Anything, 5*time.Minute).
+		Run(func(args mock.Arguments) { storedKey = args.String(1) }).
+		Return(nil)
+
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, sessionRepo, mfaRepo,
+		new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer(), testLoginMonitor(), cacheClient, testPasswordHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
+		Username: "user",
+		Password: "pass1234",
+	})
+
+	require.NoError(t, err)
+	require.True(t, res.MFARequired)
+	require.NotEmpty(t, res.MFAToken)
+	require.Empty(t, res.AccessToken)
+	require.Nil(t, res.User)
+	require.Equal(t, helper.MFAChallengeKey(res.MFAToken), storedKey)
+
+	// the attempt is given back, but earlier failures stay until the code is accepted
+	cacheClient.AssertCalled(t, "Decr", mock.Anything, "login:fail:user:user")
+	cacheClient.AssertNotCalled(t, "Delete", mock.Anything, "login:fail:user:user")
+
+	sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	tokenManager.AssertNotCalled(t, "GenerateTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_UpgradesOldHash(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	mfaRepo := new(mocks.MockMFARepository)
+
+	passwordHash, err := security.HashPassword("pass1234", security.DefaultCost)
+	require.NoError(t, err)
+
+	argonHasher := security.NewPasswordHasher(security.PasswordHashConfig{
+		Argon2: security.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1},
+	})
+
+	account := &models.User{ID: 10, IsActive: true, Username: "user", PasswordHash: passwordHash}
+	userRepo.On("FindByUsername", mock.Anything, "user").Return(account, nil)
+	userRepo.On("UpgradePasswordHash", mock.Anything, uint64(10), passwordHash, mock.MatchedBy(func(newHash string) bool {
+		rehash, err := argonHasher.Verify(newHash, "pass1234")
+		return err == nil && !rehash
+	})).Return(nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return((*models.UserMFA)(nil), gorm.ErrRecordNotFound)
+	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
+	tokenManager.On("GenerateTokens", uint64(10), mock.Anything, mock.Anything, mock.Anything).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, sessionRepo, mfaRepo,
+		new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer(), testLoginMonitor(), newUnthrottledCache(1), argonHasher)
+	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{Username: "user", Password: "pass1234"})
+
+	require.NoError(t, err)
+	require.Equal(t, "access", res.AccessToken)
+	userRepo.AssertExpectations(t)
+}
+
+func TestLoginByUsrnameAndPwdQuery_Handle_AccountDisabled(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	mfaRepo := new(mocks.MockMFARepository)
+
+	passwordHash, err := security.HashPassword("pass1234", security.DefaultCost)
+	require.NoError(t, err)
+
+	userRepo.On("FindByUsername", mock.Anything, "user").
+		Return(&models.User{ID: 10, IsActive: false, Username: "user", PasswordHash: passwordHash}, nil)
 
-	qry := NewLoginByUsrnameAndPwdQuery(userRepo, sessionRepo, tokenManager)
+	qry := NewLoginByUsrnameAndPwdQuery(&config.ServiceConfig{}, userRepo, sessionRepo, mfaRepo,
+		new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer(), testLoginMonitor(), newUnthrottledCache(1), testPasswordHasher)
 	res, err := qry.Handle(context.Background(), &userdto.LoginByUserPassReq{
 		Username: "user",
 		Password: "pass1234",
 	})
 
 	require.Nil(t, res)
-	require.ErrorIs(t, err, helper.ErrUserNotFound)
+	require.ErrorIs(t, err, helper.ErrAccountDisabled)
 
 	userRepo.AssertExpectations(t)
+	sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
@@ -0,0 +1,186 @@
+package query
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IOAuthTokenQuery decorator.QueryHandler[*userdto.OAuthTokenReq, *userdto.OAuthTokenRes]
+
+type oauthTokenQuery struct {
+	oauthRepo    oauth.Repository
+	userRepo     user.Repository
+	issuer       sessionIssuer
+	refreshToken IRefreshTokenQuery
+}
+
+func NewOAuthTokenQuery(
+	oauthRepo oauth.Repository,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	tokenManager security.TokenManager,
+	authorizer helper.Authorizer,
+	monitor helper.LoginMonitor,
+	refreshToken IRefreshTokenQuery,
+) IOAuthTokenQuery {
+	return &oauthTokenQuery{
+		oauthRepo: oauthRepo,
+		userRepo:  userRepo,
+		issuer: sessionIssuer{
+			sessionRepo:  sessionRepo,
+			tokenManager: tokenManager,
+			authorizer:   authorizer,
+			monitor:      monitor,
+		},
+		refreshToken: refreshToken,
+	}
+}
+
+func (o oauthTokenQuery) Handle(ctx context.Context, req *userdto.OAuthTokenReq) (*userdto.OAuthTokenRes, error) {
+	client, err := o.authenticate(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case helper.OAuthGrantAuthorizationCode:
+		return o.exchangeCode(ctx, client, req)
+	case helper.OAuthGrantRefreshToken:
+		return o.refresh(ctx, client, req)
+	default:
+		return nil, helper.ErrOAuthUnsupportedGrantType
+	}
+}
+
+// authenticate accepts confidential clients by secret and public clients by
+// ID alone; a public client that sends a secret is rejected.
+func (o oauthTokenQuery) authenticate(ctx context.Context, clientID string, secret string) (*models.OAuthClient, error) {
+	if clientID == "" {
+		return nil, helper.ErrInvalidClient
+	}
+
+	client, err := o.oauthRepo.FindClientByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Warn("token requested by unknown client", slog.String("client_id", clientID))
+			return nil, helper.ErrInvalidClient
+		}
+		slog.Error("failed to find oauth client",
+			slog.String("client_id", clientID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if client.IsPublic() {
+		if secret != "" {
+			return nil, helper.ErrInvalidClient
+		}
+		return client, nil
+	}
+
+	if secret == "" || subtle.ConstantTimeCompare([]byte(security.HashOpaqueToken(secret)), []byte(client.SecretHash)) != 1 {
+		slog.Warn("token requested with invalid client secret", slog.String("client_id", clientID))
+		return nil, helper.ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+func (o oauthTokenQuery) exchangeCode(ctx context.Context, client *models.OAuthClient, req *userdto.OAuthTokenReq) (*userdto.OAuthTokenRes, error) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, helper.ErrOAuthInvalidRequest
+	}
+
+	code, err := o.oauthRepo.ConsumeCode(ctx, security.HashOpaqueToken(req.Code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Warn("invalid or used authorization code", slog.String("client_id", client.ID))
+			return nil, helper.ErrOAuthInvalidGrant
+		}
+		slog.Error("failed to consume authorization code",
+			slog.String("client_id", client.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if code.ClientID != client.ID || code.RedirectURI != req.RedirectURI ||
+		!helper.VerifyPKCE(req.CodeVerifier, code.CodeChallenge) {
+		slog.Warn("authorization code does not match token request",
+			slog.String("client_id", client.ID),
+			slog.Uint64("user_id", code.UserID))
+		return nil, helper.ErrOAuthInvalidGrant
+	}
+
+	account, err := o.userRepo.FindByID(ctx, code.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrOAuthInvalidGrant
+		}
+		slog.Error("failed to find user for authorization code",
+			slog.Uint64("user_id", code.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if !account.CanSignIn() {
+		slog.Warn("authorization code rejected for disabled account", slog.Uint64("user_id", account.ID))
+		return nil, helper.ErrOAuthInvalidGrant
+	}
+
+	tokens, err := o.issuer.open(ctx, &models.Session{
+		UserID:    code.UserID,
+		UserAgent: req.UserAgent,
+		IPAddress: req.IPAddress,
+		ClientID:  client.ID,
+		Scope:     code.Scope,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userdto.OAuthTokenRes{
+		AccessToken:  tokens.accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(tokens.accessExp).Seconds()),
+		RefreshToken: tokens.refreshToken,
+		Scope:        code.Scope,
+	}, nil
+}
+
+func (o oauthTokenQuery) refresh(ctx context.Context, client *models.OAuthClient, req *userdto.OAuthTokenReq) (*userdto.OAuthTokenRes, error) {
+	if req.RefreshToken == "" {
+		return nil, helper.ErrOAuthInvalidRequest
+	}
+
+	res, err := o.refreshToken.Handle(ctx, &userdto.RefreshTokenReq{
+		RefreshToken: req.RefreshToken,
+		UserAgent:    req.UserAgent,
+		IPAddress:    req.IPAddress,
+		ClientID:     client.ID,
+	})
+	if err != nil {
+		if errors.Is(err, helper.ErrInvalidToken) || errors.Is(err, helper.ErrRefreshTokenReused) {
+			return nil, helper.ErrOAuthInvalidGrant
+		}
+		return nil, err
+	}
+
+	return &userdto.OAuthTokenRes{
+		AccessToken:  res.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    res.ExpiresIn,
+		RefreshToken: res.RefreshToken,
+	}, nil
+}
@@ -0,0 +1,184 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+const (
+	testOAuthCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testOAuthCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func testOAuthCode() *models.OAuthAuthorizationCode {
+	return &models.OAuthAuthorizationCode{
+		ClientID:            "partner",
+		UserID:              1,
+		RedirectURI:         "https://partner.example.com/cb",
+		Scope:               "tasks:read",
+		CodeChallenge:       testOAuthCodeChallenge,
+		CodeChallengeMethod: helper.OAuthCodeChallengeMethodS256,
+	}
+}
+
+func testCodeTokenReq() *userdto.OAuthTokenReq {
+	return &userdto.OAuthTokenReq{
+		GrantType:    helper.OAuthGrantAuthorizationCode,
+		Code:         "code-1",
+		RedirectURI:  "https://partner.example.com/cb",
+		CodeVerifier: testOAuthCodeVerifier,
+		ClientID:     "partner",
+		ClientSecret: "secret",
+	}
+}
+
+func TestOAuthTokenQuery_Handle_AuthorizationCode(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").
+		Return(&models.OAuthClient{ID: "partner", SecretHash: security.HashOpaqueToken("secret")}, nil)
+	oauthRepo.On("ConsumeCode", mock.Anything, security.HashOpaqueToken("code-1")).Return(testOAuthCode(), nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: true}, nil)
+	sessionRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.Session) bool {
+		return item.UserID == 1 && item.ClientID == "partner" && item.Scope == "tasks:read"
+	})).Return(nil)
+	tokenManager.On("GenerateTokens", uint64(1), mock.Anything, mock.Anything,
+		security.AccessClaims{Roles: []string{"user"}, ClientID: "partner", Scope: "tasks:read"}).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+
+	qry := NewOAuthTokenQuery(oauthRepo, userRepo, sessionRepo, tokenManager, testAuthorizer("user"), testLoginMonitor(), nil)
+	res, err := qry.Handle(context.Background(), testCodeTokenReq())
+
+	require.NoError(t, err)
+	require.Equal(t, "access", res.AccessToken)
+	require.Equal(t, "refresh", res.RefreshToken)
+	require.Equal(t, "Bearer", res.TokenType)
+	require.Equal(t, "tasks:read", res.Scope)
+	sessionRepo.AssertExpectations(t)
+	tokenManager.AssertExpectations(t)
+}
+
+func TestOAuthTokenQuery_Handle_InvalidClientSecret(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").
+		Return(&models.OAuthClient{ID: "partner", SecretHash: security.HashOpaqueToken("secret")}, nil)
+
+	req := testCodeTokenReq()
+	req.ClientSecret = "wrong"
+
+	qry := NewOAuthTokenQuery(oauthRepo, nil, nil, nil, testAuthorizer(), testLoginMonitor(), nil)
+	res, err := qry.Handle(context.Background(), req)
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidClient)
+	oauthRepo.AssertNotCalled(t, "ConsumeCode", mock.Anything, mock.Anything)
+}
+
+func TestOAuthTokenQuery_Handle_PublicClientWithSecret(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{ID: "partner"}, nil)
+
+	qry := NewOAuthTokenQuery(oauthRepo, nil, nil, nil, testAuthorizer(), testLoginMonitor(), nil)
+	res, err := qry.Handle(context.Background(), testCodeTokenReq())
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidClient)
+}
+
+func TestOAuthTokenQuery_Handle_WrongCodeVerifier(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{ID: "partner"}, nil)
+	oauthRepo.On("ConsumeCode", mock.Anything, security.HashOpaqueToken("code-1")).Return(testOAuthCode(), nil)
+
+	req := testCodeTokenReq()
+	req.ClientSecret = ""
+	req.CodeVerifier = "not-the-verifier-used-for-the-challenge-0000"
+
+	qry := NewOAuthTokenQuery(oauthRepo, nil, sessionRepo, nil, testAuthorizer(), testLoginMonitor(), nil)
+	res, err := qry.Handle(context.Background(), req)
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrOAuthInvalidGrant)
+	sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOAuthTokenQuery_Handle_RedirectURIMismatch(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{ID: "partner"}, nil)
+	oauthRepo.On("ConsumeCode", mock.Anything, security.HashOpaqueToken("code-1")).Return(testOAuthCode(), nil)
+
+	req := testCodeTokenReq()
+	req.ClientSecret = ""
+	req.RedirectURI = "https://partner.example.com/other"
+
+	qry := NewOAuthTokenQuery(oauthRepo, nil, nil, nil, testAuthorizer(), testLoginMonitor(), nil)
+	_, err := qry.Handle(context.Background(), req)
+
+	require.ErrorIs(t, err, helper.ErrOAuthInvalidGrant)
+}
+
+func TestOAuthTokenQuery_Handle_UsedCode(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{ID: "partner"}, nil)
+	oauthRepo.On("ConsumeCode", mock.Anything, security.HashOpaqueToken("code-1")).Return(nil, gorm.ErrRecordNotFound)
+
+	req := testCodeTokenReq()
+	req.ClientSecret = ""
+
+	qry := NewOAuthTokenQuery(oauthRepo, nil, nil, nil, testAuthorizer(), testLoginMonitor(), nil)
+	_, err := qry.Handle(context.Background(), req)
+
+	require.ErrorIs(t, err, helper.ErrOAuthInvalidGrant)
+}
+
+func TestOAuthTokenQuery_Handle_RefreshTokenIsBoundToClient(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+	refreshToken := new(mocks.MockQueryHandler[*userdto.RefreshTokenReq, *userdto.RefreshTokenRes])
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{ID: "partner"}, nil)
+	refreshToken.On("Handle", mock.Anything, mock.MatchedBy(func(req *userdto.RefreshTokenReq) bool {
+		return req.RefreshToken == "refresh" && req.ClientID == "partner"
+	})).Return(&userdto.RefreshTokenRes{AccessToken: "access-2", RefreshToken: "refresh-2", ExpiresIn: 900}, nil)
+
+	qry := NewOAuthTokenQuery(oauthRepo, nil, nil, nil, testAuthorizer(), testLoginMonitor(), refreshToken)
+	res, err := qry.Handle(context.Background(), &userdto.OAuthTokenReq{
+		GrantType:    helper.OAuthGrantRefreshToken,
+		RefreshToken: "refresh",
+		ClientID:     "partner",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "access-2", res.AccessToken)
+	require.Equal(t, "refresh-2", res.RefreshToken)
+	refreshToken.AssertExpectations(t)
+}
+
+func TestOAuthTokenQuery_Handle_UnsupportedGrantType(t *testing.T) {
+	oauthRepo := new(mocks.MockOAuthRepository)
+
+	oauthRepo.On("FindClientByID", mock.Anything, "partner").Return(&models.OAuthClient{ID: "partner"}, nil)
+
+	qry := NewOAuthTokenQuery(oauthRepo, nil, nil, nil, testAuthorizer(), testLoginMonitor(), nil)
+	_, err := qry.Handle(context.Background(), &userdto.OAuthTokenReq{GrantType: "password", ClientID: "partner"})
+
+	require.ErrorIs(t, err, helper.ErrOAuthUnsupportedGrantType)
+}
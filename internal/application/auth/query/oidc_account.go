@@ -0,0 +1,171 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"gorm.io/gorm"
+)
+
+// externalIdentity is an identity asserted by a verified OpenID Connect ID token.
+type externalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Name          string
+}
+
+// oidcAccountResolver maps an external identity to a local account,
+// provisioning a new one when its verified email is not taken. An existing
+// account with that email is only linked for providers in linkByEmail;
+// anyone able to register the address at another provider could otherwise
+// take the account over.
+type oidcAccountResolver struct {
+	userRepo     user.Repository
+	identityRepo identity.Repository
+	linkByEmail  []string
+}
+
+func newOIDCAccountResolver(cfg *config.ServiceConfig, userRepo user.Repository, identityRepo identity.Repository) oidcAccountResolver {
+	return oidcAccountResolver{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		linkByEmail:  cfg.Auth.OIDCLinkByEmail,
+	}
+}
+
+func (r oidcAccountResolver) resolve(ctx context.Context, identity *externalIdentity) (*models.User, error) {
+	account, err := r.userRepo.FindByOIDC(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return account, r.markVerified(ctx, account, identity)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("failed to find user by OIDC subject",
+			slog.String("provider", identity.Provider),
+			slog.String("subject", identity.Subject),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// Linking by email is only safe when the provider vouches for the address
+	if identity.Email == "" || !identity.EmailVerified {
+		slog.Warn("rejecting OIDC login without a verified email",
+			slog.String("provider", identity.Provider),
+			slog.String("subject", identity.Subject))
+		return nil, helper.ErrInvalidToken
+	}
+
+	account, err = r.userRepo.FindByEmail(ctx, identity.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return r.create(ctx, identity)
+		}
+
+		slog.Error("failed to find user by email during OIDC login",
+			slog.String("email", identity.Email),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if !slices.Contains(r.linkByEmail, identity.Provider) {
+		slog.Warn("OIDC login matches an existing account by email; link required",
+			slog.Uint64("user_id", account.ID),
+			slog.String("provider", identity.Provider))
+		return nil, helper.ErrIdentityLinkRequired
+	}
+
+	// The provider is trusted to vouch for the address, so link this identity to the account
+	err = r.identityRepo.Create(ctx, &models.UserIdentity{
+		UserID:   account.ID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+	if err != nil && !errors.Is(err, gorm.ErrDuplicatedKey) {
+		slog.Error("failed to link user with OIDC identity",
+			slog.Uint64("user_id", account.ID),
+			slog.String("provider", identity.Provider),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if err != nil {
+		// a concurrent login linked the identity first; trust whoever owns it now
+		account, err = r.userRepo.FindByOIDC(ctx, identity.Provider, identity.Subject)
+		if err != nil {
+			slog.Error("failed to find user by OIDC subject after link conflict",
+				slog.String("provider", identity.Provider),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	return account, r.markVerified(ctx, account, identity)
+}
+
+// markVerified trusts the provider's verification of the account's own address.
+func (r oidcAccountResolver) markVerified(ctx context.Context, account *models.User, identity *externalIdentity) error {
+	if account.EmailVerifiedAt != nil || !identity.EmailVerified || !strings.EqualFold(account.Email, identity.Email) {
+		return nil
+	}
+
+	verifiedAt := time.Now()
+	if err := r.userRepo.MarkEmailVerified(ctx, account.ID, account.Email, verifiedAt); err != nil {
+		slog.Error("failed to mark email verified from OIDC identity",
+			slog.Uint64("user_id", account.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	account.EmailVerifiedAt = &verifiedAt
+	return nil
+}
+
+func (r oidcAccountResolver) create(ctx context.Context, identity *externalIdentity) (*models.User, error) {
+	firstName := strings.TrimSpace(identity.GivenName)
+	lastName := strings.TrimSpace(identity.FamilyName)
+	if firstName == "" {
+		firstName = strings.TrimSpace(identity.Name)
+	}
+	if firstName == "" {
+		firstName = strings.ToUpper(identity.Provider[:1]) + identity.Provider[1:]
+	}
+	if lastName == "" {
+		lastName = "User"
+	}
+
+	item := &models.User{
+		FirstName:       firstName,
+		LastName:        lastName,
+		Email:           identity.Email,
+		Username:        identity.Email,
+		IsActive:        true,
+		EmailVerifiedAt: new(time.Now()),
+		Identities: []*models.UserIdentity{{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		}},
+	}
+
+	if err := r.userRepo.Create(ctx, item); err != nil {
+		slog.Error("failed to create user from OIDC identity",
+			slog.String("provider", identity.Provider),
+			slog.String("email", identity.Email),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return item, nil
+}
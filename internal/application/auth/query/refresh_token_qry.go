@@ -9,7 +9,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
 	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
 	"github.com/tdatIT/backend-go/internal/infras/security"
 	"github.com/tdatIT/backend-go/pkgs/decorator"
 	"gorm.io/gorm"
@@ -18,14 +21,26 @@ import (
 type IRefreshTokenQuery decorator.QueryHandler[*userdto.RefreshTokenReq, *userdto.RefreshTokenRes]
 
 type refreshTokenQuery struct {
-	sessionRepo  session.Repository
-	tokenManager security.TokenManager
+	userRepo          user.Repository
+	sessionRepo       session.Repository
+	securityEventRepo securityevent.Repository
+	tokenManager      security.TokenManager
+	authorizer        helper.Authorizer
 }
 
-func NewRefreshTokenQuery(sessionRepo session.Repository, tokenManager security.TokenManager) IRefreshTokenQuery {
+func NewRefreshTokenQuery(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	securityEventRepo securityevent.Repository,
+	tokenManager security.TokenManager,
+	authorizer helper.Authorizer,
+) IRefreshTokenQuery {
 	return &refreshTokenQuery{
-		sessionRepo:  sessionRepo,
-		tokenManager: tokenManager,
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		securityEventRepo: securityEventRepo,
+		tokenManager:      tokenManager,
+		authorizer:        authorizer,
 	}
 }
 
@@ -35,6 +50,10 @@ func (r refreshTokenQuery) Handle(ctx context.Context, req *userdto.RefreshToken
 		slog.Warn("failed to verify refresh token", slog.String("error", err.Error()))
 		return nil, helper.ErrInvalidToken
 	}
+	if claims.TokenType != security.TokenTypeRefresh {
+		slog.Warn("unexpected token type for refresh token", slog.String("typ", claims.TokenType))
+		return nil, helper.ErrInvalidToken
+	}
 
 	sessionItem, err := r.sessionRepo.FindBySessionID(ctx, claims.SessionID)
 	if err != nil {
@@ -48,8 +67,22 @@ func (r refreshTokenQuery) Handle(ctx context.Context, req *userdto.RefreshToken
 		return nil, err
 	}
 
+	if sessionItem.IsActive && sessionItem.RefreshJTI != claims.ID {
+		reused, err := r.sessionRepo.IsRotatedRefreshJTI(ctx, sessionItem.ID, claims.ID)
+		if err != nil {
+			slog.Error("failed to check rotated refresh JTI",
+				slog.String("sess_id", sessionItem.ID),
+				slog.String("jti", claims.ID),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+		if reused {
+			return nil, r.revokeReusedSession(ctx, sessionItem, claims.ID, req)
+		}
+	}
+
 	if !sessionItem.IsActive || sessionItem.ID != claims.SessionID ||
-		claims.Subject == "" || sessionItem.RefreshJTI != claims.ID {
+		claims.Subject == "" || sessionItem.RefreshJTI != claims.ID || sessionItem.ClientID != req.ClientID {
 		slog.Warn("invalid session for refresh token",
 			slog.String("sess_id", sessionItem.ID),
 			slog.Uint64("user_id", sessionItem.UserID),
@@ -59,8 +92,37 @@ func (r refreshTokenQuery) Handle(ctx context.Context, req *userdto.RefreshToken
 		return nil, helper.ErrInvalidToken
 	}
 
+	account, err := r.userRepo.FindByID(ctx, sessionItem.UserID)
+	if err != nil {
+		slog.Error("failed to find user for refresh token",
+			slog.Uint64("user_id", sessionItem.UserID),
+			slog.String("error", err.Error()))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !account.CanSignIn() {
+		slog.Warn("refresh rejected for disabled account",
+			slog.String("sess_id", sessionItem.ID),
+			slog.Uint64("user_id", account.ID))
+		return nil, helper.ErrAccountDisabled
+	}
+
+	grants, err := r.authorizer.Grants(ctx, sessionItem.UserID)
+	if err != nil {
+		slog.Error("failed to load user grants",
+			slog.Uint64("user_id", sessionItem.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
 	newRefreshJTI := uuid.NewString()
-	accessToken, refreshToken, accessExp, err := r.tokenManager.GenerateTokens(sessionItem.UserID, sessionItem.ID, newRefreshJTI)
+	accessToken, refreshToken, accessExp, err := r.tokenManager.GenerateTokens(sessionItem.UserID, sessionItem.ID, newRefreshJTI, security.AccessClaims{
+		Roles:    grants.Roles,
+		ClientID: sessionItem.ClientID,
+		Scope:    sessionItem.Scope,
+	})
 	if err != nil {
 		slog.Error("failed to generate new tokens",
 			slog.Uint64("user_id", sessionItem.UserID),
@@ -70,6 +132,10 @@ func (r refreshTokenQuery) Handle(ctx context.Context, req *userdto.RefreshToken
 	}
 
 	if err := r.sessionRepo.RotateRefreshJTI(ctx, sessionItem.ID, sessionItem.RefreshJTI, newRefreshJTI); err != nil {
+		// a concurrent refresh with the same token rotated it first
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, r.revokeReusedSession(ctx, sessionItem, claims.ID, req)
+		}
 		slog.Error("failed to rotate refresh JTI",
 			slog.String("sess_id", sessionItem.ID),
 			slog.String("old_jti", sessionItem.RefreshJTI),
@@ -87,3 +153,34 @@ func (r refreshTokenQuery) Handle(ctx context.Context, req *userdto.RefreshToken
 		ExpiresIn:    int64(time.Until(accessExp).Seconds()),
 	}, nil
 }
+
+// revokeReusedSession handles a replayed refresh token: the whole session is
+// treated as compromised, so it is deactivated and a security event is recorded.
+func (r refreshTokenQuery) revokeReusedSession(ctx context.Context, sessionItem *models.Session, jti string, req *userdto.RefreshTokenReq) error {
+	slog.Warn("refresh token reuse detected, revoking session",
+		slog.String("sess_id", sessionItem.ID),
+		slog.Uint64("user_id", sessionItem.UserID),
+		slog.String("jti", jti))
+
+	if err := r.sessionRepo.Deactivate(ctx, sessionItem.ID); err != nil {
+		slog.Error("failed to deactivate session after refresh token reuse",
+			slog.String("sess_id", sessionItem.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := r.securityEventRepo.Create(ctx, &models.SecurityEvent{
+		UserID:    sessionItem.UserID,
+		SessionID: sessionItem.ID,
+		Type:      models.SecurityEventRefreshTokenReuse,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Details:   "reused refresh token jti: " + jti,
+	}); err != nil {
+		slog.Error("failed to record refresh token reuse event",
+			slog.String("sess_id", sessionItem.ID),
+			slog.String("error", err.Error()))
+	}
+
+	return helper.ErrRefreshTokenReused
+}
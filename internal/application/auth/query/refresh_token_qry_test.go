@@ -21,6 +21,7 @@ func TestRefreshTokenQuery_Handle_Success(t *testing.T) {
 	tokenManager := new(mocks.MockTokenManager)
 
 	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeRefresh,
 		SessionID: "sess-1",
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject: "1",
@@ -39,12 +40,15 @@ func TestRefreshTokenQuery_Handle_Success(t *testing.T) {
 	sessionRepo.On("FindBySessionID", mock.Anything, claims.SessionID).Return(sessionItem, nil)
 
 	accessExp := time.Now().Add(time.Hour)
-	tokenManager.On("GenerateTokens", uint64(1), sessionItem.ID, mock.Anything).
+	tokenManager.On("GenerateTokens", uint64(1), sessionItem.ID, mock.Anything, mock.Anything).
 		Return("access", "refresh-new", accessExp, nil)
 
 	sessionRepo.On("RotateRefreshJTI", mock.Anything, sessionItem.ID, sessionItem.RefreshJTI, mock.Anything).Return(nil)
 
-	qry := NewRefreshTokenQuery(sessionRepo, tokenManager)
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: true}, nil)
+
+	qry := NewRefreshTokenQuery(userRepo, sessionRepo, new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer())
 	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "refresh"})
 
 	require.NoError(t, err)
@@ -61,7 +65,7 @@ func TestRefreshTokenQuery_Handle_InvalidToken(t *testing.T) {
 
 	tokenManager.On("VerifyToken", "bad").Return((*security.CustomClaims)(nil), gorm.ErrRecordNotFound)
 
-	qry := NewRefreshTokenQuery(sessionRepo, tokenManager)
+	qry := NewRefreshTokenQuery(new(mocks.MockUserRepository), sessionRepo, new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer())
 	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "bad"})
 
 	require.Nil(t, res)
@@ -69,3 +73,191 @@ func TestRefreshTokenQuery_Handle_InvalidToken(t *testing.T) {
 
 	tokenManager.AssertExpectations(t)
 }
+
+func TestRefreshTokenQuery_Handle_AccessTokenRejected(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeAccess,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "1",
+			ID:      "access-1",
+		},
+	}
+	tokenManager.On("VerifyToken", "access").Return(claims, nil)
+
+	qry := NewRefreshTokenQuery(new(mocks.MockUserRepository), sessionRepo, new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer())
+	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "access"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+	sessionRepo.AssertNotCalled(t, "FindBySessionID", mock.Anything, mock.Anything)
+}
+
+func TestRefreshTokenQuery_Handle_ReusedTokenRevokesSession(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeRefresh,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "1",
+			ID:      "refresh-old",
+		},
+	}
+
+	sessionItem := &models.Session{
+		ID:         "sess-1",
+		UserID:     1,
+		RefreshJTI: "refresh-current",
+		IsActive:   true,
+	}
+
+	tokenManager.On("VerifyToken", "refresh").Return(claims, nil)
+	sessionRepo.On("FindBySessionID", mock.Anything, claims.SessionID).Return(sessionItem, nil)
+	sessionRepo.On("IsRotatedRefreshJTI", mock.Anything, sessionItem.ID, "refresh-old").Return(true, nil)
+	sessionRepo.On("Deactivate", mock.Anything, sessionItem.ID).Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.Type == models.SecurityEventRefreshTokenReuse && item.SessionID == sessionItem.ID
+	})).Return(nil)
+
+	qry := NewRefreshTokenQuery(new(mocks.MockUserRepository), sessionRepo, securityEventRepo, tokenManager, testAuthorizer())
+	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "refresh"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrRefreshTokenReused)
+
+	sessionRepo.AssertExpectations(t)
+	securityEventRepo.AssertExpectations(t)
+	tokenManager.AssertNotCalled(t, "GenerateTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefreshTokenQuery_Handle_ConcurrentRefreshIsReuse(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	securityEventRepo := new(mocks.MockSecurityEventRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeRefresh,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "1",
+			ID:      "refresh-1",
+		},
+	}
+	sessionItem := &models.Session{ID: "sess-1", UserID: 1, RefreshJTI: "refresh-1", IsActive: true}
+
+	tokenManager.On("VerifyToken", "refresh").Return(claims, nil)
+	sessionRepo.On("FindBySessionID", mock.Anything, "sess-1").Return(sessionItem, nil)
+	tokenManager.On("GenerateTokens", uint64(1), "sess-1", mock.Anything, mock.Anything).
+		Return("access", "refresh-new", time.Now().Add(time.Hour), nil)
+	// another request rotated the same token between the read and the swap
+	sessionRepo.On("RotateRefreshJTI", mock.Anything, "sess-1", "refresh-1", mock.Anything).Return(gorm.ErrDuplicatedKey)
+	sessionRepo.On("Deactivate", mock.Anything, "sess-1").Return(nil)
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.SecurityEvent) bool {
+		return item.Type == models.SecurityEventRefreshTokenReuse && item.SessionID == "sess-1"
+	})).Return(nil)
+
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: true}, nil)
+
+	qry := NewRefreshTokenQuery(userRepo, sessionRepo, securityEventRepo, tokenManager, testAuthorizer())
+	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "refresh"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrRefreshTokenReused)
+	sessionRepo.AssertExpectations(t)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestRefreshTokenQuery_Handle_UnknownJTI(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeRefresh,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "1",
+			ID:      "refresh-unknown",
+		},
+	}
+
+	tokenManager.On("VerifyToken", "refresh").Return(claims, nil)
+	sessionRepo.On("FindBySessionID", mock.Anything, claims.SessionID).
+		Return(&models.Session{ID: "sess-1", UserID: 1, RefreshJTI: "refresh-current", IsActive: true}, nil)
+	sessionRepo.On("IsRotatedRefreshJTI", mock.Anything, "sess-1", "refresh-unknown").Return(false, nil)
+
+	qry := NewRefreshTokenQuery(new(mocks.MockUserRepository), sessionRepo, new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer())
+	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "refresh"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestRefreshTokenQuery_Handle_OAuthSessionRequiresItsClient(t *testing.T) {
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeRefresh,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "1",
+			ID:      "refresh-1",
+		},
+	}
+
+	tokenManager.On("VerifyToken", "refresh").Return(claims, nil)
+	sessionRepo.On("FindBySessionID", mock.Anything, claims.SessionID).Return(&models.Session{
+		ID:         "sess-1",
+		UserID:     1,
+		RefreshJTI: "refresh-1",
+		IsActive:   true,
+		ClientID:   "partner",
+	}, nil)
+
+	qry := NewRefreshTokenQuery(new(mocks.MockUserRepository), sessionRepo, new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer())
+	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "refresh"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+	tokenManager.AssertNotCalled(t, "GenerateTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefreshTokenQuery_Handle_SuspendedUser(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeRefresh,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "1",
+			ID:      "refresh-1",
+		},
+	}
+
+	tokenManager.On("VerifyToken", "refresh").Return(claims, nil)
+	sessionRepo.On("FindBySessionID", mock.Anything, claims.SessionID).Return(&models.Session{
+		ID:         "sess-1",
+		UserID:     1,
+		RefreshJTI: "refresh-1",
+		IsActive:   true,
+	}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: false}, nil)
+
+	qry := NewRefreshTokenQuery(userRepo, sessionRepo, new(mocks.MockSecurityEventRepository), tokenManager, testAuthorizer())
+	res, err := qry.Handle(context.Background(), &userdto.RefreshTokenReq{RefreshToken: "refresh"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrAccountDisabled)
+	tokenManager.AssertNotCalled(t, "GenerateTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,104 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/utils/genid"
+)
+
+// sessionIssuer opens a session for an authenticated user and mints its tokens.
+type sessionIssuer struct {
+	sessionRepo  session.Repository
+	tokenManager security.TokenManager
+	authorizer   helper.Authorizer
+	monitor      helper.LoginMonitor
+}
+
+// issuedTokens is the first token pair of a new session.
+type issuedTokens struct {
+	accessToken  string
+	refreshToken string
+	accessExp    time.Time
+}
+
+func (s sessionIssuer) issue(ctx context.Context, account *models.User, userAgent string, ipAddress string) (*userdto.LoginRes, error) {
+	if !account.CanSignIn() {
+		slog.Warn("sign-in rejected for disabled account", slog.Uint64("user_id", account.ID))
+		return nil, helper.ErrAccountDisabled
+	}
+
+	sessionItem := &models.Session{
+		UserID:    account.ID,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+	}
+	tokens, err := s.open(ctx, sessionItem)
+	if err != nil {
+		return nil, err
+	}
+	s.monitor.Check(ctx, account, sessionItem)
+
+	return &userdto.LoginRes{
+		AccessToken:  tokens.accessToken,
+		RefreshToken: tokens.refreshToken,
+		ExpiresIn:    int64(time.Until(tokens.accessExp).Seconds()),
+		User: &userdto.UserProfileRes{
+			ID:        account.ID,
+			FirstName: account.FirstName,
+			LastName:  account.LastName,
+			Email:     account.Email,
+			Username:  account.Username,
+		},
+	}, nil
+}
+
+// open persists sessionItem, filling in its ID and refresh JTI, and mints
+// its tokens.
+func (s sessionIssuer) open(ctx context.Context, sessionItem *models.Session) (*issuedTokens, error) {
+	grants, err := s.authorizer.Grants(ctx, sessionItem.UserID)
+	if err != nil {
+		slog.Error("failed to load user grants",
+			slog.Uint64("user_id", sessionItem.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	sessionItem.ID = genid.GenerateNanoID()
+	sessionItem.RefreshJTI = uuid.NewString()
+	sessionItem.IsActive = true
+	sessionItem.LastUsedAt = new(time.Now())
+	s.monitor.Describe(sessionItem)
+
+	if err := s.sessionRepo.Create(ctx, sessionItem); err != nil {
+		slog.Error("failed to create session",
+			slog.Uint64("user_id", sessionItem.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	accessToken, refreshToken, accessExp, err := s.tokenManager.GenerateTokens(sessionItem.UserID, sessionItem.ID, sessionItem.RefreshJTI, security.AccessClaims{
+		Roles:    grants.Roles,
+		ClientID: sessionItem.ClientID,
+		Scope:    sessionItem.Scope,
+	})
+	if err != nil {
+		slog.Error("failed to generate tokens for user",
+			slog.Uint64("user_id", sessionItem.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &issuedTokens{
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+		accessExp:    accessExp,
+	}, nil
+}
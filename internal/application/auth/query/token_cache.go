@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+)
+
+// cachedTokenVerifier remembers active access tokens for a short time so that
+// gateways checking every request do not hit the database each time. Only
+// active results are cached; a revoked session may pass until the entry expires.
+// Entries of a user whose account status changed are ignored, so suspension
+// and deletion apply at once.
+type cachedTokenVerifier struct {
+	cache       cache.Cache
+	verifyToken IVerifyTokenQuery
+	ttl         time.Duration
+}
+
+func newCachedTokenVerifier(cacheClient cache.Cache, verifyToken IVerifyTokenQuery, ttl time.Duration) cachedTokenVerifier {
+	return cachedTokenVerifier{
+		cache:       cacheClient,
+		verifyToken: verifyToken,
+		ttl:         ttl,
+	}
+}
+
+// verify returns helper.ErrInvalidToken for inactive tokens, including those
+// of suspended or deleted accounts.
+func (v cachedTokenVerifier) verify(ctx context.Context, token string) (*userdto.VerifyTokenRes, error) {
+	key := "token:active:" + security.HashOpaqueToken(token)
+	now := time.Now()
+
+	if raw, err := v.cache.Get(ctx, key); err == nil {
+		cached := new(userdto.VerifyTokenRes)
+		if err := json.Unmarshal(raw, cached); err == nil && cached.ExpiresAt > now.Unix() && !v.stale(ctx, cached) {
+			return cached, nil
+		}
+	}
+
+	res, err := v.verifyToken.Handle(ctx, &userdto.VerifyTokenReq{AccessToken: token})
+	if err != nil {
+		if errors.Is(err, helper.ErrAccountDisabled) {
+			return nil, helper.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	ttl := min(v.ttl, time.Unix(res.ExpiresAt, 0).Sub(now))
+	if ttl <= 0 {
+		return nil, helper.ErrInvalidToken
+	}
+
+	raw, err := json.Marshal(res)
+	if err == nil {
+		err = v.cache.Set(ctx, key, raw, ttl)
+	}
+	if err != nil {
+		slog.Error("failed to cache token verification", slog.String("error", err.Error()))
+	}
+
+	return res, nil
+}
+
+// stale reports whether the account of a cached result changed status since
+// it was cached. Errors count as stale so the token is verified again.
+func (v cachedTokenVerifier) stale(ctx context.Context, res *userdto.VerifyTokenRes) bool {
+	userID, err := strconv.ParseUint(res.Sub, 10, 64)
+	if err != nil {
+		return true
+	}
+
+	stale, err := v.cache.Exists(ctx, helper.StaleTokensKey(userID))
+	if err != nil {
+		slog.Error("failed to check cached token status", slog.String("error", err.Error()))
+		return true
+	}
+	return stale
+}
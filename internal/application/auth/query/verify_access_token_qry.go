@@ -0,0 +1,95 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/accesstoken"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+// lastUsedResolution limits last_used_at writes to one per token per interval.
+const lastUsedResolution = time.Minute
+
+type IVerifyPersonalAccessTokenQuery decorator.QueryHandler[*userdto.VerifyPersonalAccessTokenReq, *userdto.VerifyPersonalAccessTokenRes]
+
+type verifyPersonalAccessTokenQuery struct {
+	userRepo   user.Repository
+	tokenRepo  accesstoken.Repository
+	authorizer helper.Authorizer
+	now        func() time.Time
+}
+
+func NewVerifyPersonalAccessTokenQuery(
+	userRepo user.Repository,
+	tokenRepo accesstoken.Repository,
+	authorizer helper.Authorizer,
+) IVerifyPersonalAccessTokenQuery {
+	return &verifyPersonalAccessTokenQuery{
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		authorizer: authorizer,
+		now:        time.Now,
+	}
+}
+
+func (v verifyPersonalAccessTokenQuery) Handle(ctx context.Context, req *userdto.VerifyPersonalAccessTokenReq) (*userdto.VerifyPersonalAccessTokenRes, error) {
+	item, err := v.tokenRepo.FindActiveByHash(ctx, security.HashOpaqueToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Warn("unknown, revoked or expired personal access token")
+			return nil, helper.ErrInvalidToken
+		}
+		slog.Error("failed to find personal access token", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	account, err := v.userRepo.FindByID(ctx, item.UserID)
+	if err != nil {
+		slog.Error("failed to find user for personal access token",
+			slog.Uint64("user_id", item.UserID),
+			slog.Uint64("token_id", item.ID),
+			slog.String("error", err.Error()))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !account.CanSignIn() {
+		slog.Warn("personal access token rejected for disabled account",
+			slog.Uint64("user_id", account.ID),
+			slog.Uint64("token_id", item.ID))
+		return nil, helper.ErrAccountDisabled
+	}
+
+	grants, err := v.authorizer.Grants(ctx, item.UserID)
+	if err != nil {
+		slog.Error("failed to load user grants",
+			slog.Uint64("user_id", item.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	now := v.now()
+	if item.LastUsedAt == nil || now.Sub(*item.LastUsedAt) >= lastUsedResolution {
+		if err := v.tokenRepo.TouchLastUsed(ctx, item.ID, now); err != nil {
+			slog.Error("failed to update personal access token last use",
+				slog.Uint64("token_id", item.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return &userdto.VerifyPersonalAccessTokenRes{
+		UserID:  item.UserID,
+		TokenID: item.ID,
+		Scopes:  item.Scopes,
+		Roles:   grants.Roles,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestVerifyPersonalAccessTokenQuery_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+
+	token := "pat_secret"
+	tokenRepo.On("FindActiveByHash", mock.Anything, security.HashOpaqueToken(token)).
+		Return(&models.PersonalAccessToken{ID: 7, UserID: 1, Scopes: []string{"tasks:read"}}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: true}, nil)
+	tokenRepo.On("TouchLastUsed", mock.Anything, uint64(7), mock.Anything).Return(nil)
+
+	qry := NewVerifyPersonalAccessTokenQuery(userRepo, tokenRepo, testAuthorizer("editor"))
+	res, err := qry.Handle(context.Background(), &userdto.VerifyPersonalAccessTokenReq{Token: token})
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.UserID)
+	require.Equal(t, uint64(7), res.TokenID)
+	require.Equal(t, []string{"tasks:read"}, res.Scopes)
+	require.Equal(t, []string{"editor"}, res.Roles)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestVerifyPersonalAccessTokenQuery_Handle_RecentlyUsed(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+
+	tokenRepo.On("FindActiveByHash", mock.Anything, mock.Anything).
+		Return(&models.PersonalAccessToken{ID: 7, UserID: 1, LastUsedAt: new(time.Now())}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: true}, nil)
+
+	qry := NewVerifyPersonalAccessTokenQuery(userRepo, tokenRepo, testAuthorizer())
+	_, err := qry.Handle(context.Background(), &userdto.VerifyPersonalAccessTokenReq{Token: "pat_secret"})
+
+	require.NoError(t, err)
+	tokenRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyPersonalAccessTokenQuery_Handle_Unknown(t *testing.T) {
+	tokenRepo := new(mocks.MockAccessTokenRepository)
+	tokenRepo.On("FindActiveByHash", mock.Anything, mock.Anything).
+		Return((*models.PersonalAccessToken)(nil), gorm.ErrRecordNotFound)
+
+	qry := NewVerifyPersonalAccessTokenQuery(new(mocks.MockUserRepository), tokenRepo, testAuthorizer())
+	res, err := qry.Handle(context.Background(), &userdto.VerifyPersonalAccessTokenReq{Token: "pat_secret"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+}
@@ -0,0 +1,158 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IVerifyMFAQuery decorator.QueryHandler[*userdto.VerifyMFAReq, *userdto.LoginRes]
+
+type verifyMFAQuery struct {
+	userRepo user.Repository
+	mfaRepo  mfa.Repository
+	cache    cache.Cache
+	verifier helper.MFACodeVerifier
+	throttle helper.LoginThrottle
+	issuer   sessionIssuer
+}
+
+func NewVerifyMFAQuery(
+	config *config.ServiceConfig,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	mfaRepo mfa.Repository,
+	tokenManager security.TokenManager,
+	authorizer helper.Authorizer,
+	monitor helper.LoginMonitor,
+	cacheClient cache.Cache,
+	box *security.SecretBox,
+) IVerifyMFAQuery {
+	return &verifyMFAQuery{
+		userRepo: userRepo,
+		mfaRepo:  mfaRepo,
+		cache:    cacheClient,
+		verifier: helper.MFACodeVerifier{
+			MFARepo: mfaRepo,
+			Box:     box,
+		},
+		throttle: helper.NewLoginThrottle(config, cacheClient),
+		issuer: sessionIssuer{
+			sessionRepo:  sessionRepo,
+			tokenManager: tokenManager,
+			authorizer:   authorizer,
+			monitor:      monitor,
+		},
+	}
+}
+
+// Handle exchanges a login challenge and a TOTP or recovery code for tokens.
+// A challenge is single-use and is discarded after too many wrong codes.
+// Attempts are counted before the code is checked, so concurrent guesses
+// cannot exceed the limit. Wrong codes are also failed logins for the
+// username, and its failures are only cleared once a code is accepted.
+func (v verifyMFAQuery) Handle(ctx context.Context, req *userdto.VerifyMFAReq) (*userdto.LoginRes, error) {
+	key := helper.MFAChallengeKey(req.MFAToken)
+	raw, err := v.cache.Get(ctx, key)
+	if err != nil {
+		return nil, helper.ErrInvalidMFAChallenge
+	}
+
+	challenge := new(helper.MFAChallenge)
+	if err := json.Unmarshal(raw, challenge); err != nil || challenge.Username == "" ||
+		!time.Now().Before(challenge.ExpiresAt) {
+		v.discard(ctx, key)
+		return nil, helper.ErrInvalidMFAChallenge
+	}
+
+	item, err := v.mfaRepo.FindByUserID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			v.discard(ctx, key)
+			return nil, helper.ErrInvalidMFAChallenge
+		}
+
+		slog.Error("failed to find mfa enrollment",
+			slog.Uint64("user_id", challenge.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if !item.Enabled {
+		v.discard(ctx, key)
+		return nil, helper.ErrInvalidMFAChallenge
+	}
+
+	attempt, err := v.throttle.Reserve(ctx, challenge.Username, req.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the challenge expiry so retries cannot extend it
+	attempts, err := v.cache.Incr(ctx, helper.MFAAttemptsKey(key), time.Until(challenge.ExpiresAt))
+	if err != nil {
+		slog.Error("failed to count mfa attempt",
+			slog.Uint64("user_id", challenge.UserID),
+			slog.String("error", err.Error()))
+		v.throttle.Release(ctx, attempt)
+		return nil, err
+	}
+	if attempts > helper.MaxMFAAttempts {
+		v.throttle.Release(ctx, attempt)
+		v.discard(ctx, key)
+		return nil, helper.ErrInvalidMFAChallenge
+	}
+
+	if err := v.verifier.Verify(ctx, item, req.Code); err != nil {
+		if !errors.Is(err, helper.ErrInvalidMFACode) {
+			v.throttle.Release(ctx, attempt)
+			return nil, err
+		}
+
+		if result := v.throttle.RecordFailure(ctx, attempt); result.UserLocked {
+			slog.Warn("login locked out after wrong mfa codes", slog.Uint64("user_id", challenge.UserID))
+		}
+		if attempts == helper.MaxMFAAttempts {
+			slog.Warn("mfa challenge discarded after too many attempts", slog.Uint64("user_id", challenge.UserID))
+			v.discard(ctx, key)
+		}
+		return nil, err
+	}
+
+	v.discard(ctx, key)
+	v.throttle.Reset(ctx, attempt)
+
+	account, err := v.userRepo.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrUserNotFound
+		}
+
+		slog.Error("failed to find user for mfa login",
+			slog.Uint64("user_id", challenge.UserID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return v.issuer.issue(ctx, account, req.UserAgent, req.IPAddress)
+}
+
+func (v verifyMFAQuery) discard(ctx context.Context, key string) {
+	for _, k := range []string{key, helper.MFAAttemptsKey(key)} {
+		if err := v.cache.Delete(ctx, k); err != nil {
+			slog.Error("failed to delete mfa challenge", slog.String("error", err.Error()))
+		}
+	}
+}
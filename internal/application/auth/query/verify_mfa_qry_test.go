@@ -0,0 +1,255 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func newMFAEnrollment(t *testing.T, box *security.SecretBox) (*models.UserMFA, string) {
+	t.Helper()
+
+	secret, err := security.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	ciphertext, err := box.Seal(secret)
+	require.NoError(t, err)
+
+	return &models.UserMFA{UserID: 10, SecretCiphertext: ciphertext, Enabled: true}, secret
+}
+
+func challengePayload(t *testing.T) []byte {
+	t.Helper()
+
+	payload, err := json.Marshal(&helper.MFAChallenge{
+		UserID:    10,
+		Username:  "user",
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	return payload
+}
+
+// throttleLogin lets the login throttle count the code as the given attempt
+// for the challenge's username.
+func throttleLogin(cacheClient *mocks.MockCache, attempt int64) {
+	cacheClient.On("Exists", mock.Anything, "login:block:user:user").Return(false, nil)
+	cacheClient.On("Incr", mock.Anything, "login:fail:user:user", 15*time.Minute).Return(attempt, nil)
+	cacheClient.On("Decr", mock.Anything, "login:fail:user:user").Return(attempt-1, nil).Maybe()
+	cacheClient.On("Set", mock.Anything, "login:block:user:user", 1, mock.Anything).Return(nil).Maybe()
+	cacheClient.On("Expire", mock.Anything, "login:fail:user:user", mock.Anything).Return(nil).Maybe()
+	cacheClient.On("Delete", mock.Anything, "login:fail:user:user").Return(nil).Maybe()
+	cacheClient.On("Delete", mock.Anything, "login:block:user:user").Return(nil).Maybe()
+}
+
+func TestVerifyMFAQuery_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	mfaRepo := new(mocks.MockMFARepository)
+	tokenManager := new(mocks.MockTokenManager)
+	cacheClient := new(mocks.MockCache)
+	box := security.NewSecretBox("key")
+
+	item, secret := newMFAEnrollment(t, box)
+	code, err := security.TOTPCode(secret, security.TOTPStep(time.Now()))
+	require.NoError(t, err)
+
+	key := helper.MFAChallengeKey("challenge")
+	cacheClient.On("Get", mock.Anything, key).Return(challengePayload(t), nil)
+	throttleLogin(cacheClient, 1)
+	cacheClient.On("Incr", mock.Anything, helper.MFAAttemptsKey(key), mock.Anything).Return(int64(1), nil)
+	cacheClient.On("Delete", mock.Anything, key).Return(nil)
+	cacheClient.On("Delete", mock.Anything, helper.MFAAttemptsKey(key)).Return(nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(item, nil)
+	mfaRepo.On("MarkStepUsed", mock.Anything, uint64(10), mock.AnythingOfType("int64")).Return(true, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(10)).Return(&models.User{ID: 10, IsActive: true, Username: "user"}, nil)
+	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
+	tokenManager.On("GenerateTokens", uint64(10), mock.Anything, mock.Anything, mock.Anything).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, userRepo, sessionRepo, mfaRepo, tokenManager, testAuthorizer(), testLoginMonitor(), cacheClient, box)
+	res, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "challenge", Code: code})
+
+	require.NoError(t, err)
+	require.Equal(t, "access", res.AccessToken)
+	require.Equal(t, uint64(10), res.User.ID)
+
+	cacheClient.AssertExpectations(t)
+	cacheClient.AssertCalled(t, "Delete", mock.Anything, "login:fail:user:user")
+	mfaRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestVerifyMFAQuery_Handle_RecoveryCode(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	mfaRepo := new(mocks.MockMFARepository)
+	tokenManager := new(mocks.MockTokenManager)
+	cacheClient := new(mocks.MockCache)
+	box := security.NewSecretBox("key")
+
+	item, _ := newMFAEnrollment(t, box)
+
+	key := helper.MFAChallengeKey("challenge")
+	cacheClient.On("Get", mock.Anything, key).Return(challengePayload(t), nil)
+	throttleLogin(cacheClient, 1)
+	cacheClient.On("Incr", mock.Anything, helper.MFAAttemptsKey(key), mock.Anything).Return(int64(1), nil)
+	cacheClient.On("Delete", mock.Anything, key).Return(nil)
+	cacheClient.On("Delete", mock.Anything, helper.MFAAttemptsKey(key)).Return(nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(item, nil)
+	mfaRepo.On("ConsumeRecoveryCode", mock.Anything, uint64(10), security.HashRecoveryCode("abcd-efgh-ijkl-mnop")).Return(nil)
+	userRepo.On("FindByID", mock.Anything, uint64(10)).Return(&models.User{ID: 10, IsActive: true}, nil)
+	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Session")).Return(nil)
+	tokenManager.On("GenerateTokens", uint64(10), mock.Anything, mock.Anything, mock.Anything).
+		Return("access", "refresh", time.Now().Add(time.Hour), nil)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, userRepo, sessionRepo, mfaRepo, tokenManager, testAuthorizer(), testLoginMonitor(), cacheClient, box)
+	res, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "challenge", Code: "ABCDEFGHIJKLMNOP"})
+
+	require.NoError(t, err)
+	require.Equal(t, "access", res.AccessToken)
+	mfaRepo.AssertExpectations(t)
+}
+
+func TestVerifyMFAQuery_Handle_InvalidCodeCountsAttempt(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := new(mocks.MockCache)
+	box := security.NewSecretBox("key")
+
+	item, _ := newMFAEnrollment(t, box)
+
+	key := helper.MFAChallengeKey("challenge")
+	cacheClient.On("Get", mock.Anything, key).Return(challengePayload(t), nil)
+	throttleLogin(cacheClient, 1)
+	cacheClient.On("Incr", mock.Anything, helper.MFAAttemptsKey(key), mock.Anything).Return(int64(1), nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(item, nil)
+	mfaRepo.On("ConsumeRecoveryCode", mock.Anything, uint64(10), mock.Anything).Return(gorm.ErrRecordNotFound)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository), mfaRepo,
+		new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, box)
+	res, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "challenge", Code: "000000x"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidMFACode)
+	cacheClient.AssertExpectations(t)
+	cacheClient.AssertCalled(t, "Set", mock.Anything, "login:block:user:user", 1, time.Second)
+	cacheClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestVerifyMFAQuery_Handle_WrongCodesLockLoginAcrossChallenges(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := new(mocks.MockCache)
+	box := security.NewSecretBox("key")
+
+	item, _ := newMFAEnrollment(t, box)
+
+	// a fresh challenge, but earlier challenges already used up the login attempts
+	key := helper.MFAChallengeKey("challenge")
+	cacheClient.On("Get", mock.Anything, key).Return(challengePayload(t), nil)
+	throttleLogin(cacheClient, 5)
+	cacheClient.On("Incr", mock.Anything, helper.MFAAttemptsKey(key), mock.Anything).Return(int64(1), nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(item, nil)
+	mfaRepo.On("ConsumeRecoveryCode", mock.Anything, uint64(10), mock.Anything).Return(gorm.ErrRecordNotFound)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository), mfaRepo,
+		new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, box)
+	_, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "challenge", Code: "bad"})
+
+	require.ErrorIs(t, err, helper.ErrInvalidMFACode)
+	cacheClient.AssertCalled(t, "Set", mock.Anything, "login:block:user:user", 1, 15*time.Minute)
+	cacheClient.AssertNotCalled(t, "Delete", mock.Anything, "login:fail:user:user")
+}
+
+func TestVerifyMFAQuery_Handle_LockedLoginRejectsCode(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := new(mocks.MockCache)
+	box := security.NewSecretBox("key")
+
+	item, _ := newMFAEnrollment(t, box)
+
+	key := helper.MFAChallengeKey("challenge")
+	cacheClient.On("Get", mock.Anything, key).Return(challengePayload(t), nil)
+	cacheClient.On("Exists", mock.Anything, "login:block:user:user").Return(true, nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(item, nil)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository), mfaRepo,
+		new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, box)
+	res, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "challenge", Code: "123456"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrLoginLocked)
+	mfaRepo.AssertNotCalled(t, "MarkStepUsed", mock.Anything, mock.Anything, mock.Anything)
+	mfaRepo.AssertNotCalled(t, "ConsumeRecoveryCode", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyMFAQuery_Handle_TooManyAttemptsDiscardsChallenge(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := new(mocks.MockCache)
+	box := security.NewSecretBox("key")
+
+	item, _ := newMFAEnrollment(t, box)
+
+	key := helper.MFAChallengeKey("challenge")
+	cacheClient.On("Get", mock.Anything, key).Return(challengePayload(t), nil)
+	throttleLogin(cacheClient, 1)
+	cacheClient.On("Incr", mock.Anything, helper.MFAAttemptsKey(key), mock.Anything).Return(int64(helper.MaxMFAAttempts), nil)
+	cacheClient.On("Delete", mock.Anything, key).Return(nil)
+	cacheClient.On("Delete", mock.Anything, helper.MFAAttemptsKey(key)).Return(nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(item, nil)
+	mfaRepo.On("ConsumeRecoveryCode", mock.Anything, uint64(10), mock.Anything).Return(gorm.ErrRecordNotFound)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository), mfaRepo,
+		new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, box)
+	_, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "challenge", Code: "bad"})
+
+	require.ErrorIs(t, err, helper.ErrInvalidMFACode)
+	cacheClient.AssertExpectations(t)
+}
+
+func TestVerifyMFAQuery_Handle_AttemptsExhaustedSkipsVerification(t *testing.T) {
+	mfaRepo := new(mocks.MockMFARepository)
+	cacheClient := new(mocks.MockCache)
+	box := security.NewSecretBox("key")
+
+	item, _ := newMFAEnrollment(t, box)
+
+	key := helper.MFAChallengeKey("challenge")
+	cacheClient.On("Get", mock.Anything, key).Return(challengePayload(t), nil)
+	throttleLogin(cacheClient, 1)
+	cacheClient.On("Incr", mock.Anything, helper.MFAAttemptsKey(key), mock.Anything).Return(int64(helper.MaxMFAAttempts+1), nil)
+	cacheClient.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	mfaRepo.On("FindByUserID", mock.Anything, uint64(10)).Return(item, nil)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository), mfaRepo,
+		new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, box)
+	res, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "challenge", Code: "123456"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidMFAChallenge)
+	mfaRepo.AssertNotCalled(t, "MarkStepUsed", mock.Anything, mock.Anything, mock.Anything)
+	mfaRepo.AssertNotCalled(t, "ConsumeRecoveryCode", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyMFAQuery_Handle_UnknownChallenge(t *testing.T) {
+	cacheClient := new(mocks.MockCache)
+	cacheClient.On("Get", mock.Anything, helper.MFAChallengeKey("missing")).Return(nil, redis.Nil)
+
+	qry := NewVerifyMFAQuery(&config.ServiceConfig{}, new(mocks.MockUserRepository), new(mocks.MockSessionRepository), new(mocks.MockMFARepository),
+		new(mocks.MockTokenManager), testAuthorizer(), testLoginMonitor(), cacheClient, security.NewSecretBox("key"))
+	res, err := qry.Handle(context.Background(), &userdto.VerifyMFAReq{MFAToken: "missing", Code: "123456"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidMFAChallenge)
+}
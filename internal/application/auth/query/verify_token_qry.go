@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
 	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	"github.com/tdatIT/backend-go/internal/infras/repository/user"
 	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/pkgs/cache"
 	"github.com/tdatIT/backend-go/pkgs/decorator"
 	"gorm.io/gorm"
 )
@@ -16,14 +20,23 @@ import (
 type IVerifyTokenQuery decorator.QueryHandler[*userdto.VerifyTokenReq, *userdto.VerifyTokenRes]
 
 type verifyTokenQuery struct {
+	userRepo     user.Repository
 	sessionRepo  session.Repository
 	tokenManager security.TokenManager
+	states       helper.AccountStates
 }
 
-func NewVerifyTokenQuery(sessionRepo session.Repository, tokenManager security.TokenManager) IVerifyTokenQuery {
+func NewVerifyTokenQuery(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	tokenManager security.TokenManager,
+	cacheClient cache.Cache,
+) IVerifyTokenQuery {
 	return &verifyTokenQuery{
+		userRepo:     userRepo,
 		sessionRepo:  sessionRepo,
 		tokenManager: tokenManager,
+		states:       helper.AccountStates{Cache: cacheClient},
 	}
 }
 
@@ -33,6 +46,10 @@ func (v verifyTokenQuery) Handle(ctx context.Context, req *userdto.VerifyTokenRe
 		slog.Warn("failed to verify access token", slog.String("error", err.Error()))
 		return nil, helper.ErrInvalidToken
 	}
+	if claims.TokenType != security.TokenTypeAccess {
+		slog.Warn("unexpected token type for access token", slog.String("typ", claims.TokenType))
+		return nil, helper.ErrInvalidToken
+	}
 
 	sessionItem, err := v.sessionRepo.FindByID(ctx, claims.SessionID)
 	if err != nil {
@@ -44,17 +61,72 @@ func (v verifyTokenQuery) Handle(ctx context.Context, req *userdto.VerifyTokenRe
 		}
 		return nil, err
 	}
-	if !sessionItem.IsActive || claims.Subject == "" {
+	if !sessionItem.IsActive || claims.Subject != strconv.FormatUint(sessionItem.UserID, 10) {
 		slog.Warn("invalid session for access token",
 			slog.String("sess_id", claims.SessionID),
 			slog.Uint64("user_id", sessionItem.UserID))
 		return nil, helper.ErrInvalidToken
 	}
 
+	state, err := v.accountState(ctx, sessionItem.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !state.CanSignIn {
+		slog.Warn("access token rejected for disabled account",
+			slog.String("sess_id", claims.SessionID),
+			slog.Uint64("user_id", sessionItem.UserID))
+		return nil, helper.ErrAccountDisabled
+	}
+	if issuedBeforePasswordChange(claims, state.PasswordChangedAt) {
+		slog.Warn("access token issued before password change",
+			slog.String("sess_id", claims.SessionID),
+			slog.Uint64("user_id", sessionItem.UserID))
+		return nil, helper.ErrInvalidToken
+	}
+
 	return &userdto.VerifyTokenRes{
 		Sub:       claims.Subject,
 		SessionID: claims.SessionID,
 		JTI:       claims.ID,
 		ExpiresAt: claims.ExpiresAt.Time.Unix(),
+		Roles:     claims.Roles,
+		ClientID:  claims.ClientID,
+		Scope:     claims.Scope,
 	}, nil
 }
+
+// accountState returns the cached state of the account, reading the user
+// row only on a miss.
+func (v verifyTokenQuery) accountState(ctx context.Context, userID uint64) (*helper.AccountState, error) {
+	if state, ok := v.states.Load(ctx, userID); ok {
+		return state, nil
+	}
+
+	account, err := v.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		slog.Error("failed to find user for access token",
+			slog.Uint64("user_id", userID),
+			slog.String("error", err.Error()))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helper.ErrInvalidToken
+		}
+		return nil, err
+	}
+	v.states.Fill(ctx, account)
+
+	return helper.NewAccountState(account), nil
+}
+
+// issuedBeforePasswordChange compares at second precision because iat is
+// truncated to whole seconds; tokens without iat are treated as old.
+func issuedBeforePasswordChange(claims *security.CustomClaims, changedAt *time.Time) bool {
+	if changedAt == nil {
+		return false
+	}
+	if claims.IssuedAt == nil {
+		return true
+	}
+
+	return claims.IssuedAt.Time.Before(changedAt.Truncate(time.Second))
+}
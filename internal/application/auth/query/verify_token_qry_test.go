@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tdatIT/backend-go/internal/application/auth/helper"
@@ -16,11 +17,22 @@ import (
 	"gorm.io/gorm"
 )
 
+// uncachedAccountState returns a cache without the account state, which
+// accepts the state read from the database.
+func uncachedAccountState(userID uint64) *mocks.MockCache {
+	cacheClient := new(mocks.MockCache)
+	cacheClient.On("Get", mock.Anything, helper.AccountStateKey(userID)).Return(nil, redis.Nil)
+	cacheClient.On("SetNX", mock.Anything, helper.AccountStateKey(userID), mock.Anything, helper.AccountStateTTL).Return(true, nil)
+	return cacheClient
+}
+
 func TestVerifyTokenQuery_Handle_Success(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
 	sessionRepo := new(mocks.MockSessionRepository)
 	tokenManager := new(mocks.MockTokenManager)
 
 	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeAccess,
 		SessionID: "sess-1",
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   "1",
@@ -31,9 +43,10 @@ func TestVerifyTokenQuery_Handle_Success(t *testing.T) {
 
 	sessionRepo.On("FindByID", mock.Anything, claims.SessionID).
 		Return(&models.Session{ID: claims.SessionID, UserID: 1, IsActive: true}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: true}, nil)
 	tokenManager.On("VerifyToken", "access").Return(claims, nil)
 
-	qry := NewVerifyTokenQuery(sessionRepo, tokenManager)
+	qry := NewVerifyTokenQuery(userRepo, sessionRepo, tokenManager, uncachedAccountState(1))
 	res, err := qry.Handle(context.Background(), &userdto.VerifyTokenReq{AccessToken: "access"})
 
 	require.NoError(t, err)
@@ -46,12 +59,13 @@ func TestVerifyTokenQuery_Handle_Success(t *testing.T) {
 }
 
 func TestVerifyTokenQuery_Handle_InvalidToken(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
 	sessionRepo := new(mocks.MockSessionRepository)
 	tokenManager := new(mocks.MockTokenManager)
 
 	tokenManager.On("VerifyToken", "bad").Return((*security.CustomClaims)(nil), gorm.ErrRecordNotFound)
 
-	qry := NewVerifyTokenQuery(sessionRepo, tokenManager)
+	qry := NewVerifyTokenQuery(userRepo, sessionRepo, tokenManager, new(mocks.MockCache))
 	res, err := qry.Handle(context.Background(), &userdto.VerifyTokenReq{AccessToken: "bad"})
 
 	require.Nil(t, res)
@@ -59,3 +73,122 @@ func TestVerifyTokenQuery_Handle_InvalidToken(t *testing.T) {
 
 	tokenManager.AssertExpectations(t)
 }
+
+func TestVerifyTokenQuery_Handle_RefreshTokenRejected(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeRefresh,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ID:        "refresh-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	tokenManager.On("VerifyToken", "refresh").Return(claims, nil)
+
+	qry := NewVerifyTokenQuery(userRepo, sessionRepo, tokenManager, new(mocks.MockCache))
+	res, err := qry.Handle(context.Background(), &userdto.VerifyTokenReq{AccessToken: "refresh"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+	sessionRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestVerifyTokenQuery_Handle_SubjectMismatch(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeAccess,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "2",
+			ID:        "access-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	sessionRepo.On("FindByID", mock.Anything, claims.SessionID).
+		Return(&models.Session{ID: claims.SessionID, UserID: 1, IsActive: true}, nil)
+	tokenManager.On("VerifyToken", "access").Return(claims, nil)
+
+	qry := NewVerifyTokenQuery(userRepo, sessionRepo, tokenManager, new(mocks.MockCache))
+	res, err := qry.Handle(context.Background(), &userdto.VerifyTokenReq{AccessToken: "access"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+
+	sessionRepo.AssertExpectations(t)
+	tokenManager.AssertExpectations(t)
+}
+
+func TestVerifyTokenQuery_Handle_IssuedBeforePasswordChange(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+
+	changedAt := time.Now()
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeAccess,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ID:        "access-1",
+			IssuedAt:  jwt.NewNumericDate(changedAt.Add(-time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(changedAt.Add(time.Hour)),
+		},
+	}
+
+	sessionRepo.On("FindByID", mock.Anything, claims.SessionID).
+		Return(&models.Session{ID: claims.SessionID, UserID: 1, IsActive: true}, nil)
+	userRepo.On("FindByID", mock.Anything, uint64(1)).Return(&models.User{ID: 1, IsActive: true, PasswordChangedAt: &changedAt}, nil)
+	tokenManager.On("VerifyToken", "access").Return(claims, nil)
+
+	qry := NewVerifyTokenQuery(userRepo, sessionRepo, tokenManager, uncachedAccountState(1))
+	res, err := qry.Handle(context.Background(), &userdto.VerifyTokenReq{AccessToken: "access"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrInvalidToken)
+
+	// a token minted in the same second as the change is still accepted
+	claims.IssuedAt = jwt.NewNumericDate(changedAt)
+	res, err = qry.Handle(context.Background(), &userdto.VerifyTokenReq{AccessToken: "access"})
+
+	require.NoError(t, err)
+	require.Equal(t, "1", res.Sub)
+}
+
+func TestVerifyTokenQuery_Handle_CachedAccountState(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockSessionRepository)
+	tokenManager := new(mocks.MockTokenManager)
+	cacheClient := new(mocks.MockCache)
+
+	claims := &security.CustomClaims{
+		TokenType: security.TokenTypeAccess,
+		SessionID: "sess-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ID:        "access-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	sessionRepo.On("FindByID", mock.Anything, claims.SessionID).
+		Return(&models.Session{ID: claims.SessionID, UserID: 1, IsActive: true}, nil)
+	tokenManager.On("VerifyToken", "access").Return(claims, nil)
+	cacheClient.On("Get", mock.Anything, helper.AccountStateKey(1)).
+		Return([]byte(`{"can_sign_in":false}`), nil)
+
+	qry := NewVerifyTokenQuery(userRepo, sessionRepo, tokenManager, cacheClient)
+	res, err := qry.Handle(context.Background(), &userdto.VerifyTokenReq{AccessToken: "access"})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrAccountDisabled)
+	userRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
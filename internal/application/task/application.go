@@ -0,0 +1,44 @@
+package task
+
+import (
+	"github.com/tdatIT/backend-go/internal/application/task/command"
+	"github.com/tdatIT/backend-go/internal/application/task/query"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+)
+
+type queries struct {
+	GetTask   query.IGetTaskQuery
+	ListTasks query.IListTasksQuery
+}
+
+type commands struct {
+	CreateTask       command.ICreateTaskCommand
+	UpdateTask       command.IUpdateTaskCommand
+	ChangeTaskStatus command.IChangeTaskStatusCommand
+	MoveTask         command.IMoveTaskCommand
+	ReorderTasks     command.IReorderTasksCommand
+	DeleteTask       command.IDeleteTaskCommand
+}
+
+type Application struct {
+	Queries  *queries
+	Commands *commands
+}
+
+func NewApplication(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) *Application {
+	return &Application{
+		Queries: &queries{
+			GetTask:   query.NewGetTaskQuery(taskGroupRepo, taskRepo),
+			ListTasks: query.NewListTasksQuery(taskGroupRepo, taskRepo),
+		},
+		Commands: &commands{
+			CreateTask:       command.NewCreateTaskCommand(taskGroupRepo, taskRepo),
+			UpdateTask:       command.NewUpdateTaskCommand(taskGroupRepo, taskRepo),
+			ChangeTaskStatus: command.NewChangeTaskStatusCommand(taskGroupRepo, taskRepo),
+			MoveTask:         command.NewMoveTaskCommand(taskGroupRepo, taskRepo),
+			ReorderTasks:     command.NewReorderTasksCommand(taskGroupRepo, taskRepo),
+			DeleteTask:       command.NewDeleteTaskCommand(taskGroupRepo, taskRepo),
+		},
+	}
+}
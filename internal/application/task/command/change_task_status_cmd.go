@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IChangeTaskStatusCommand decorator.CommandReturnHandler[*taskdto.ChangeTaskStatusReq, *taskdto.TaskRes]
+
+type changeTaskStatusCommand struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewChangeTaskStatusCommand(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) IChangeTaskStatusCommand {
+	return &changeTaskStatusCommand{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+func (c changeTaskStatusCommand) Handle(ctx context.Context, req *taskdto.ChangeTaskStatusReq) (*taskdto.TaskRes, error) {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := helper.FindOwnedTask(ctx, c.taskGroupRepo, c.taskRepo, userID, req.GroupID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status == req.Status {
+		return helper.ToTaskRes(item), nil
+	}
+
+	fromStatus := item.Status
+	if err := helper.TransitionTask(item, req.Status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// the transition was checked against fromStatus, so it only applies if
+	// no other request has changed the status since
+	if err := c.taskRepo.ChangeStatus(ctx, item, fromStatus); err != nil {
+		if errors.Is(err, task.ErrStatusChanged) {
+			return nil, helper.ErrTaskStatusOutdated
+		}
+		slog.Error("failed to change task status",
+			slog.Uint64("task_id", item.ID),
+			slog.String("status", req.Status),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return helper.ToTaskRes(item), nil
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestChangeTaskStatusCommand_Handle_Done(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).
+		Return(&models.Task{ID: 9, GroupID: 7, Status: models.TaskStatusInProgress}, nil)
+	taskRepo.On("ChangeStatus", mock.Anything, mock.MatchedBy(func(item *models.Task) bool {
+		return item.Status == models.TaskStatusDone && item.CompletedAt != nil
+	}), models.TaskStatusInProgress).Return(nil)
+
+	cmd := NewChangeTaskStatusCommand(taskGroupRepo, taskRepo)
+	res, err := cmd.Handle(ctx, &taskdto.ChangeTaskStatusReq{GroupID: 7, TaskID: 9, Status: models.TaskStatusDone})
+
+	require.NoError(t, err)
+	require.Equal(t, models.TaskStatusDone, res.Status)
+	require.NotNil(t, res.CompletedAt)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestChangeTaskStatusCommand_Handle_Reopen(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).
+		Return(&models.Task{ID: 9, GroupID: 7, Status: models.TaskStatusDone, CompletedAt: new(time.Now())}, nil)
+	taskRepo.On("ChangeStatus", mock.Anything, mock.MatchedBy(func(item *models.Task) bool {
+		return item.Status == models.TaskStatusPending && item.CompletedAt == nil
+	}), models.TaskStatusDone).Return(nil)
+
+	cmd := NewChangeTaskStatusCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.ChangeTaskStatusReq{GroupID: 7, TaskID: 9, Status: models.TaskStatusPending})
+
+	require.NoError(t, err)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestChangeTaskStatusCommand_Handle_InvalidTransition(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).
+		Return(&models.Task{ID: 9, GroupID: 7, Status: models.TaskStatusCancelled}, nil)
+
+	cmd := NewChangeTaskStatusCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.ChangeTaskStatusReq{GroupID: 7, TaskID: 9, Status: models.TaskStatusDone})
+
+	require.ErrorIs(t, err, helper.ErrInvalidTaskTransition)
+	taskRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangeTaskStatusCommand_Handle_SameStatus(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).
+		Return(&models.Task{ID: 9, GroupID: 7, Status: models.TaskStatusInProgress}, nil)
+
+	cmd := NewChangeTaskStatusCommand(taskGroupRepo, taskRepo)
+	res, err := cmd.Handle(ctx, &taskdto.ChangeTaskStatusReq{GroupID: 7, TaskID: 9, Status: models.TaskStatusInProgress})
+
+	require.NoError(t, err)
+	require.Equal(t, models.TaskStatusInProgress, res.Status)
+	taskRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangeTaskStatusCommand_Handle_ConcurrentChange(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).
+		Return(&models.Task{ID: 9, GroupID: 7, Status: models.TaskStatusInProgress}, nil)
+	taskRepo.On("ChangeStatus", mock.Anything, mock.Anything, models.TaskStatusInProgress).Return(task.ErrStatusChanged)
+
+	cmd := NewChangeTaskStatusCommand(taskGroupRepo, taskRepo)
+	res, err := cmd.Handle(ctx, &taskdto.ChangeTaskStatusReq{GroupID: 7, TaskID: 9, Status: models.TaskStatusDone})
+
+	require.Nil(t, res)
+	require.ErrorIs(t, err, helper.ErrTaskStatusOutdated)
+}
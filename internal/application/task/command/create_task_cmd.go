@@ -0,0 +1,64 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type ICreateTaskCommand decorator.CommandReturnHandler[*taskdto.CreateTaskReq, *taskdto.TaskRes]
+
+type createTaskCommand struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewCreateTaskCommand(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) ICreateTaskCommand {
+	return &createTaskCommand{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+func (c createTaskCommand) Handle(ctx context.Context, req *taskdto.CreateTaskReq) (*taskdto.TaskRes, error) {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, helper.ErrInvalidTaskTitle
+	}
+
+	group, err := groupHelper.FindOwnedGroup(ctx, c.taskGroupRepo, userID, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &models.Task{
+		GroupID:     group.ID,
+		CreatedBy:   userID,
+		Title:       title,
+		Description: strings.TrimSpace(req.Description),
+		Status:      models.TaskStatusPending,
+		Priority:    req.Priority,
+		DueAt:       req.DueAt,
+	}
+	if err := c.taskRepo.Create(ctx, item); err != nil {
+		slog.Error("failed to create task",
+			slog.Uint64("group_id", group.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return helper.ToTaskRes(item), nil
+}
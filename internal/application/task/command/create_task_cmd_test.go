@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestCreateTaskCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.Task) bool {
+		return item.GroupID == 7 && item.CreatedBy == 1 && item.Title == "Buy milk" &&
+			item.Status == models.TaskStatusPending && item.CompletedAt == nil
+	})).Return(nil)
+
+	cmd := NewCreateTaskCommand(taskGroupRepo, taskRepo)
+	res, err := cmd.Handle(ctx, &taskdto.CreateTaskReq{GroupID: 7, Title: " Buy milk ", Priority: 2})
+
+	require.NoError(t, err)
+	require.Equal(t, models.TaskStatusPending, res.Status)
+	require.Equal(t, 2, res.Priority)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestCreateTaskCommand_Handle_OtherUsersGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 2}, nil)
+
+	cmd := NewCreateTaskCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.CreateTaskReq{GroupID: 7, Title: "Buy milk"})
+
+	require.ErrorIs(t, err, groupHelper.ErrTaskGroupNotFound)
+	taskRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateTaskCommand_Handle_BlankTitle(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	cmd := NewCreateTaskCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.CreateTaskReq{GroupID: 7, Title: " "})
+
+	require.ErrorIs(t, err, helper.ErrInvalidTaskTitle)
+}
@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IDeleteTaskCommand decorator.CommandHandler[*taskdto.DeleteTaskReq]
+
+type deleteTaskCommand struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewDeleteTaskCommand(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) IDeleteTaskCommand {
+	return &deleteTaskCommand{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+func (d deleteTaskCommand) Handle(ctx context.Context, req *taskdto.DeleteTaskReq) error {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	item, err := helper.FindOwnedTask(ctx, d.taskGroupRepo, d.taskRepo, userID, req.GroupID, req.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if err := d.taskRepo.Delete(ctx, item.ID); err != nil {
+		slog.Error("failed to delete task",
+			slog.Uint64("task_id", item.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestDeleteTaskCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(&models.Task{ID: 9, GroupID: 7}, nil)
+	taskRepo.On("Delete", mock.Anything, uint64(9)).Return(nil)
+
+	cmd := NewDeleteTaskCommand(taskGroupRepo, taskRepo)
+	err := cmd.Handle(ctx, &taskdto.DeleteTaskReq{GroupID: 7, TaskID: 9})
+
+	require.NoError(t, err)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestDeleteTaskCommand_Handle_OtherUsersGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 2}, nil)
+
+	cmd := NewDeleteTaskCommand(taskGroupRepo, taskRepo)
+	err := cmd.Handle(ctx, &taskdto.DeleteTaskReq{GroupID: 7, TaskID: 9})
+
+	require.ErrorIs(t, err, groupHelper.ErrTaskGroupNotFound)
+	taskRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	taskRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IMoveTaskCommand decorator.CommandReturnHandler[*taskdto.MoveTaskReq, *taskdto.TaskRes]
+
+type moveTaskCommand struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewMoveTaskCommand(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) IMoveTaskCommand {
+	return &moveTaskCommand{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+func (m moveTaskCommand) Handle(ctx context.Context, req *taskdto.MoveTaskReq) (*taskdto.TaskRes, error) {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := helper.FindOwnedTask(ctx, m.taskGroupRepo, m.taskRepo, userID, req.GroupID, req.TaskID); err != nil {
+		return nil, err
+	}
+
+	targetID := req.GroupID
+	if req.TargetGroupID != 0 && req.TargetGroupID != req.GroupID {
+		target, err := groupHelper.FindOwnedGroup(ctx, m.taskGroupRepo, userID, req.TargetGroupID)
+		if err != nil {
+			return nil, err
+		}
+		targetID = target.ID
+	}
+
+	item, err := m.taskRepo.Move(ctx, req.TaskID, req.GroupID, task.Position{
+		GroupID:  targetID,
+		BeforeID: req.BeforeID,
+		AfterID:  req.AfterID,
+	})
+	switch {
+	case errors.Is(err, task.ErrInvalidPosition):
+		return nil, helper.ErrInvalidTaskPosition
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// the task or a group went away since it was looked up
+		return nil, helper.ErrTaskNotFound
+	case err != nil:
+		slog.Error("failed to move task",
+			slog.Uint64("task_id", req.TaskID),
+			slog.Uint64("group_id", targetID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return helper.ToTaskRes(item), nil
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestMoveTaskCommand_Handle_WithinGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(&models.Task{ID: 9, GroupID: 7}, nil)
+	taskRepo.On("Move", mock.Anything, uint64(9), uint64(7), task.Position{GroupID: 7, BeforeID: 4}).
+		Return(&models.Task{ID: 9, GroupID: 7, Order: 98304}, nil)
+
+	cmd := NewMoveTaskCommand(taskGroupRepo, taskRepo)
+	res, err := cmd.Handle(ctx, &taskdto.MoveTaskReq{GroupID: 7, TaskID: 9, BeforeID: 4})
+
+	require.NoError(t, err)
+	require.Equal(t, 98304, res.Order)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestMoveTaskCommand_Handle_ToAnotherGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(8)).Return(&models.TaskGroup{ID: 8, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(&models.Task{ID: 9, GroupID: 7}, nil)
+	taskRepo.On("Move", mock.Anything, uint64(9), uint64(7), task.Position{GroupID: 8, AfterID: 5}).
+		Return(&models.Task{ID: 9, GroupID: 8}, nil)
+
+	cmd := NewMoveTaskCommand(taskGroupRepo, taskRepo)
+	res, err := cmd.Handle(ctx, &taskdto.MoveTaskReq{GroupID: 7, TaskID: 9, TargetGroupID: 8, AfterID: 5})
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), res.GroupID)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestMoveTaskCommand_Handle_TargetGroupOfOtherUser(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(8)).Return(&models.TaskGroup{ID: 8, UserID: 2}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(&models.Task{ID: 9, GroupID: 7}, nil)
+
+	cmd := NewMoveTaskCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.MoveTaskReq{GroupID: 7, TaskID: 9, TargetGroupID: 8})
+
+	require.ErrorIs(t, err, groupHelper.ErrTaskGroupNotFound)
+	taskRepo.AssertNotCalled(t, "Move", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMoveTaskCommand_Handle_InvalidPosition(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(&models.Task{ID: 9, GroupID: 7}, nil)
+	taskRepo.On("Move", mock.Anything, uint64(9), uint64(7), mock.Anything).Return(nil, task.ErrInvalidPosition)
+
+	cmd := NewMoveTaskCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.MoveTaskReq{GroupID: 7, TaskID: 9, BeforeID: 40})
+
+	require.ErrorIs(t, err, helper.ErrInvalidTaskPosition)
+}
+
+func TestMoveTaskCommand_Handle_MovedConcurrently(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(&models.Task{ID: 9, GroupID: 7}, nil)
+	taskRepo.On("Move", mock.Anything, uint64(9), uint64(7), mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	cmd := NewMoveTaskCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.MoveTaskReq{GroupID: 7, TaskID: 9})
+
+	require.ErrorIs(t, err, helper.ErrTaskNotFound)
+}
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"gorm.io/gorm"
+)
+
+type IReorderTasksCommand decorator.CommandHandler[*taskdto.ReorderTasksReq]
+
+type reorderTasksCommand struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewReorderTasksCommand(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) IReorderTasksCommand {
+	return &reorderTasksCommand{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+// Handle rewrites the order of a whole group, as a drag-and-drop board does
+// after a drop. A list that no longer matches the group is rejected so one
+// client cannot silently undo another's changes.
+func (r reorderTasksCommand) Handle(ctx context.Context, req *taskdto.ReorderTasksReq) error {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	group, err := groupHelper.FindOwnedGroup(ctx, r.taskGroupRepo, userID, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	err = r.taskRepo.Reorder(ctx, group.ID, req.TaskIDs)
+	switch {
+	case errors.Is(err, task.ErrStaleOrder):
+		return helper.ErrTaskOrderOutdated
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return groupHelper.ErrTaskGroupNotFound
+	case err != nil:
+		slog.Error("failed to reorder tasks",
+			slog.Uint64("group_id", group.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
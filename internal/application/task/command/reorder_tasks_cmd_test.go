@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestReorderTasksCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("Reorder", mock.Anything, uint64(7), []uint64{3, 1, 2}).Return(nil)
+
+	cmd := NewReorderTasksCommand(taskGroupRepo, taskRepo)
+	err := cmd.Handle(ctx, &taskdto.ReorderTasksReq{GroupID: 7, TaskIDs: []uint64{3, 1, 2}})
+
+	require.NoError(t, err)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestReorderTasksCommand_Handle_StaleOrder(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("Reorder", mock.Anything, uint64(7), []uint64{3, 1}).Return(task.ErrStaleOrder)
+
+	cmd := NewReorderTasksCommand(taskGroupRepo, taskRepo)
+	err := cmd.Handle(ctx, &taskdto.ReorderTasksReq{GroupID: 7, TaskIDs: []uint64{3, 1}})
+
+	require.ErrorIs(t, err, helper.ErrTaskOrderOutdated)
+}
+
+func TestReorderTasksCommand_Handle_OtherUsersGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 2}, nil)
+
+	cmd := NewReorderTasksCommand(taskGroupRepo, taskRepo)
+	err := cmd.Handle(ctx, &taskdto.ReorderTasksReq{GroupID: 7, TaskIDs: []uint64{3, 1}})
+
+	require.ErrorIs(t, err, groupHelper.ErrTaskGroupNotFound)
+	taskRepo.AssertNotCalled(t, "Reorder", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IUpdateTaskCommand decorator.CommandReturnHandler[*taskdto.UpdateTaskReq, *taskdto.TaskRes]
+
+type updateTaskCommand struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewUpdateTaskCommand(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) IUpdateTaskCommand {
+	return &updateTaskCommand{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+func (u updateTaskCommand) Handle(ctx context.Context, req *taskdto.UpdateTaskReq) (*taskdto.TaskRes, error) {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title == nil && req.Description == nil && req.Priority == nil && req.DueAt == nil && !req.ClearDueAt {
+		return nil, helper.ErrNoTaskChanges
+	}
+
+	var title string
+	if req.Title != nil {
+		title = strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, helper.ErrInvalidTaskTitle
+		}
+	}
+
+	item, err := helper.FindOwnedTask(ctx, u.taskGroupRepo, u.taskRepo, userID, req.GroupID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		item.Title = title
+	}
+	if req.Description != nil {
+		item.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Priority != nil {
+		item.Priority = *req.Priority
+	}
+	if req.ClearDueAt {
+		item.DueAt = nil
+	} else if req.DueAt != nil {
+		item.DueAt = req.DueAt
+	}
+
+	if err := u.taskRepo.Update(ctx, item); err != nil {
+		slog.Error("failed to update task",
+			slog.Uint64("task_id", item.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return helper.ToTaskRes(item), nil
+}
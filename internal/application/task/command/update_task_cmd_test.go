@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestUpdateTaskCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).
+		Return(&models.Task{ID: 9, GroupID: 7, Title: "Buy milk", Status: models.TaskStatusPending, DueAt: new(time.Now())}, nil)
+	taskRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *models.Task) bool {
+		return item.Title == "Buy oat milk" && item.Priority == 3 && item.DueAt == nil &&
+			item.Status == models.TaskStatusPending
+	})).Return(nil)
+
+	cmd := NewUpdateTaskCommand(taskGroupRepo, taskRepo)
+	res, err := cmd.Handle(ctx, &taskdto.UpdateTaskReq{
+		GroupID:    7,
+		TaskID:     9,
+		Title:      new("Buy oat milk"),
+		Priority:   new(3),
+		ClearDueAt: true,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "Buy oat milk", res.Title)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestUpdateTaskCommand_Handle_TaskInAnotherGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(&models.Task{ID: 9, GroupID: 8}, nil)
+
+	cmd := NewUpdateTaskCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.UpdateTaskReq{GroupID: 7, TaskID: 9, Title: new("Buy oat milk")})
+
+	require.ErrorIs(t, err, helper.ErrTaskNotFound)
+	taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTaskCommand_Handle_NoChanges(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	cmd := NewUpdateTaskCommand(taskGroupRepo, taskRepo)
+	_, err := cmd.Handle(ctx, &taskdto.UpdateTaskReq{GroupID: 7, TaskID: 9})
+
+	require.ErrorIs(t, err, helper.ErrNoTaskChanges)
+}
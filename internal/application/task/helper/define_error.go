@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"net/http"
+
+	"github.com/tdatIT/backend-go/pkgs/svcerr"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ErrTaskNotFound = &svcerr.Error{
+		Message:    "Task not found",
+		VIMessage:  "Không tìm thấy công việc",
+		Code:       "TASK-001",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrInvalidTaskTitle = &svcerr.Error{
+		Message:    "Task title must not be blank",
+		VIMessage:  "Tiêu đề công việc không được để trống",
+		Code:       "TASK-002",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrNoTaskChanges = &svcerr.Error{
+		Message:    "Nothing to update: provide title, description, priority or due date",
+		VIMessage:  "Không có thông tin cần cập nhật: hãy cung cấp tiêu đề, mô tả, độ ưu tiên hoặc hạn chót",
+		Code:       "TASK-003",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrUnknownTaskStatus = &svcerr.Error{
+		Message:    "Unknown task status",
+		VIMessage:  "Trạng thái công việc không hợp lệ",
+		Code:       "TASK-004",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrInvalidTaskTransition = &svcerr.Error{
+		Message:    "Task cannot move to this status from its current status",
+		VIMessage:  "Không thể chuyển công việc sang trạng thái này từ trạng thái hiện tại",
+		Code:       "TASK-005",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.FailedPrecondition,
+	}
+
+	ErrInvalidTaskPosition = &svcerr.Error{
+		Message:    "Task can only be placed next to another task of the target group",
+		VIMessage:  "Chỉ có thể đặt công việc cạnh một công việc khác trong nhóm đích",
+		Code:       "TASK-007",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrTaskOrderOutdated = &svcerr.Error{
+		Message:    "Task order is out of date: list every task of the group",
+		VIMessage:  "Thứ tự công việc đã thay đổi: hãy liệt kê đầy đủ các công việc của nhóm",
+		Code:       "TASK-008",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.Aborted,
+	}
+
+	ErrTaskStatusOutdated = &svcerr.Error{
+		Message:    "Task status was changed by another request",
+		VIMessage:  "Trạng thái công việc đã bị thay đổi bởi một yêu cầu khác",
+		Code:       "TASK-009",
+		HTTPStatus: http.StatusConflict,
+		GRPCCode:   codes.Aborted,
+	}
+)
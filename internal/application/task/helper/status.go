@@ -0,0 +1,48 @@
+package helper
+
+import (
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// taskTransitions lists the statuses each status may move to. Done and
+// cancelled tasks are reopened by moving them back to pending.
+var taskTransitions = map[string][]string{
+	models.TaskStatusPending:    {models.TaskStatusInProgress, models.TaskStatusCancelled},
+	models.TaskStatusInProgress: {models.TaskStatusPending, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusDone:       {models.TaskStatusPending},
+	models.TaskStatusCancelled:  {models.TaskStatusPending},
+}
+
+// TransitionTask moves item to status and keeps CompletedAt in step: it is
+// set when the task becomes done and cleared when it leaves done. Moving to
+// the current status is a no-op.
+func TransitionTask(item *models.Task, status string, now time.Time) error {
+	if _, ok := taskTransitions[status]; !ok {
+		return ErrUnknownTaskStatus
+	}
+	if item.Status == status {
+		return nil
+	}
+
+	allowed := false
+	for _, next := range taskTransitions[item.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return ErrInvalidTaskTransition
+	}
+
+	item.Status = status
+	if status == models.TaskStatusDone {
+		item.CompletedAt = &now
+	} else {
+		item.CompletedAt = nil
+	}
+
+	return nil
+}
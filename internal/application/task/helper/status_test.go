@@ -0,0 +1,79 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+func TestTransitionTask_AllowedTransitions(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		from, to      string
+		wantCompleted bool
+	}{
+		{models.TaskStatusPending, models.TaskStatusInProgress, false},
+		{models.TaskStatusPending, models.TaskStatusCancelled, false},
+		{models.TaskStatusInProgress, models.TaskStatusDone, true},
+		{models.TaskStatusInProgress, models.TaskStatusPending, false},
+		{models.TaskStatusInProgress, models.TaskStatusCancelled, false},
+		{models.TaskStatusDone, models.TaskStatusPending, false},
+		{models.TaskStatusCancelled, models.TaskStatusPending, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.from+"->"+tc.to, func(t *testing.T) {
+			item := &models.Task{Status: tc.from}
+			if tc.from == models.TaskStatusDone {
+				item.CompletedAt = new(now.Add(-time.Hour))
+			}
+
+			require.NoError(t, helper.TransitionTask(item, tc.to, now))
+			require.Equal(t, tc.to, item.Status)
+			if tc.wantCompleted {
+				require.Equal(t, &now, item.CompletedAt)
+			} else {
+				require.Nil(t, item.CompletedAt)
+			}
+		})
+	}
+}
+
+func TestTransitionTask_InvalidTransitions(t *testing.T) {
+	cases := []struct{ from, to string }{
+		{models.TaskStatusPending, models.TaskStatusDone},
+		{models.TaskStatusDone, models.TaskStatusInProgress},
+		{models.TaskStatusDone, models.TaskStatusCancelled},
+		{models.TaskStatusCancelled, models.TaskStatusDone},
+		{models.TaskStatusCancelled, models.TaskStatusInProgress},
+	}
+	for _, tc := range cases {
+		t.Run(tc.from+"->"+tc.to, func(t *testing.T) {
+			item := &models.Task{Status: tc.from}
+
+			err := helper.TransitionTask(item, tc.to, time.Now())
+
+			require.ErrorIs(t, err, helper.ErrInvalidTaskTransition)
+			require.Equal(t, tc.from, item.Status)
+		})
+	}
+}
+
+func TestTransitionTask_UnknownStatus(t *testing.T) {
+	item := &models.Task{Status: models.TaskStatusPending}
+
+	err := helper.TransitionTask(item, "archived", time.Now())
+
+	require.ErrorIs(t, err, helper.ErrUnknownTaskStatus)
+}
+
+func TestTransitionTask_SameStatusKeepsCompletedAt(t *testing.T) {
+	completedAt := time.Now().Add(-time.Hour)
+	item := &models.Task{Status: models.TaskStatusDone, CompletedAt: &completedAt}
+
+	require.NoError(t, helper.TransitionTask(item, models.TaskStatusDone, time.Now()))
+	require.Equal(t, &completedAt, item.CompletedAt)
+}
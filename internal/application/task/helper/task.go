@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"gorm.io/gorm"
+)
+
+// FindOwnedTask loads a task of a group owned by userID. Tasks filed under a
+// different group are reported as missing, like groups of other users.
+func FindOwnedTask(
+	ctx context.Context,
+	taskGroupRepo taskgroup.Repository,
+	taskRepo task.Repository,
+	userID uint64,
+	groupID uint64,
+	taskID uint64,
+) (*models.Task, error) {
+	if _, err := groupHelper.FindOwnedGroup(ctx, taskGroupRepo, userID, groupID); err != nil {
+		return nil, err
+	}
+
+	item, err := taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		slog.Error("failed to find task",
+			slog.Uint64("task_id", taskID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if item.GroupID != groupID {
+		return nil, ErrTaskNotFound
+	}
+
+	return item, nil
+}
+
+func ToTaskRes(item *models.Task) *taskdto.TaskRes {
+	return &taskdto.TaskRes{
+		ID:          item.ID,
+		GroupID:     item.GroupID,
+		Title:       item.Title,
+		Description: item.Description,
+		Status:      item.Status,
+		Priority:    item.Priority,
+		DueAt:       item.DueAt,
+		CompletedAt: item.CompletedAt,
+		Order:       item.Order,
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
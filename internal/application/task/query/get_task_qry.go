@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IGetTaskQuery decorator.QueryHandler[*taskdto.GetTaskReq, *taskdto.TaskRes]
+
+type getTaskQuery struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewGetTaskQuery(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) IGetTaskQuery {
+	return &getTaskQuery{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+func (g getTaskQuery) Handle(ctx context.Context, req *taskdto.GetTaskReq) (*taskdto.TaskRes, error) {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := helper.FindOwnedTask(ctx, g.taskGroupRepo, g.taskRepo, userID, req.GroupID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToTaskRes(item), nil
+}
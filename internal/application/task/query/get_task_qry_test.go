@@ -0,0 +1,45 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestGetTaskQuery_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).
+		Return(&models.Task{ID: 9, GroupID: 7, Title: "Buy milk", Status: models.TaskStatusPending}, nil)
+
+	qry := NewGetTaskQuery(taskGroupRepo, taskRepo)
+	res, err := qry.Handle(ctx, &taskdto.GetTaskReq{GroupID: 7, TaskID: 9})
+
+	require.NoError(t, err)
+	require.Equal(t, "Buy milk", res.Title)
+}
+
+func TestGetTaskQuery_Handle_NotFound(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindByID", mock.Anything, uint64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	qry := NewGetTaskQuery(taskGroupRepo, taskRepo)
+	_, err := qry.Handle(ctx, &taskdto.GetTaskReq{GroupID: 7, TaskID: 9})
+
+	require.ErrorIs(t, err, helper.ErrTaskNotFound)
+}
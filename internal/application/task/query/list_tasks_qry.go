@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/task/helper"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+type IListTasksQuery decorator.QueryHandler[*taskdto.ListTasksReq, *pageable.ListResponse]
+
+type listTasksQuery struct {
+	taskGroupRepo taskgroup.Repository
+	taskRepo      task.Repository
+}
+
+func NewListTasksQuery(taskGroupRepo taskgroup.Repository, taskRepo task.Repository) IListTasksQuery {
+	return &listTasksQuery{
+		taskGroupRepo: taskGroupRepo,
+		taskRepo:      taskRepo,
+	}
+}
+
+func (l listTasksQuery) Handle(ctx context.Context, req *taskdto.ListTasksReq) (*pageable.ListResponse, error) {
+	userID, err := groupHelper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := groupHelper.FindOwnedGroup(ctx, l.taskGroupRepo, userID, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := l.taskRepo.FindAllBy(ctx, &task.GetListParams{
+		ListQuery: req.ListQuery,
+		UserID:    userID,
+		GroupID:   group.ID,
+		Status:    req.Status,
+	})
+	if err != nil {
+		if errors.Is(err, pageable.ErrInvalidSortField) {
+			return nil, err
+		}
+		slog.Error("failed to list tasks",
+			slog.Uint64("group_id", group.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := make([]*taskdto.TaskRes, 0, len(items))
+	for _, item := range items {
+		res = append(res, helper.ToTaskRes(item))
+	}
+
+	return pageable.NewListResponse(&req.ListQuery, res, total), nil
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	groupHelper "github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/task"
+	"github.com/tdatIT/backend-go/mocks"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+func TestListTasksQuery_Handle_FiltersByGroupAndStatus(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindAllBy", mock.Anything, mock.MatchedBy(func(params *task.GetListParams) bool {
+		return params.UserID == 1 && params.GroupID == 7 && params.Status == models.TaskStatusDone
+	})).Return([]*models.Task{{ID: 9, GroupID: 7, Status: models.TaskStatusDone}}, int64(1), nil)
+
+	qry := NewListTasksQuery(taskGroupRepo, taskRepo)
+	res, err := qry.Handle(ctx, &taskdto.ListTasksReq{GroupID: 7, Status: models.TaskStatusDone})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Total)
+	require.Len(t, res.Items.([]*taskdto.TaskRes), 1)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestListTasksQuery_Handle_OtherUsersGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 2}, nil)
+
+	qry := NewListTasksQuery(taskGroupRepo, taskRepo)
+	_, err := qry.Handle(ctx, &taskdto.ListTasksReq{GroupID: 7})
+
+	require.ErrorIs(t, err, groupHelper.ErrTaskGroupNotFound)
+	taskRepo.AssertNotCalled(t, "FindAllBy", mock.Anything, mock.Anything)
+}
+
+func TestListTasksQuery_Handle_InvalidSortField(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	taskRepo := new(mocks.MockTaskRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskRepo.On("FindAllBy", mock.Anything, mock.Anything).Return(nil, int64(0), pageable.ErrInvalidSortField)
+
+	qry := NewListTasksQuery(taskGroupRepo, taskRepo)
+	_, err := qry.Handle(ctx, &taskdto.ListTasksReq{GroupID: 7, ListQuery: pageable.ListQuery{SortField: new("group_id")}})
+
+	require.ErrorIs(t, err, pageable.ErrInvalidSortField)
+}
@@ -0,0 +1,39 @@
+package taskgroup
+
+import (
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/command"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/query"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+)
+
+type queries struct {
+	GetTaskGroup   query.IGetTaskGroupQuery
+	ListTaskGroups query.IListTaskGroupsQuery
+}
+
+type commands struct {
+	CreateTaskGroup command.ICreateTaskGroupCommand
+	RenameTaskGroup command.IRenameTaskGroupCommand
+	UpdateTaskGroup command.IUpdateTaskGroupCommand
+	DeleteTaskGroup command.IDeleteTaskGroupCommand
+}
+
+type Application struct {
+	Queries  *queries
+	Commands *commands
+}
+
+func NewApplication(taskGroupRepo taskgroup.Repository) *Application {
+	return &Application{
+		Queries: &queries{
+			GetTaskGroup:   query.NewGetTaskGroupQuery(taskGroupRepo),
+			ListTaskGroups: query.NewListTaskGroupsQuery(taskGroupRepo),
+		},
+		Commands: &commands{
+			CreateTaskGroup: command.NewCreateTaskGroupCommand(taskGroupRepo),
+			RenameTaskGroup: command.NewRenameTaskGroupCommand(taskGroupRepo),
+			UpdateTaskGroup: command.NewUpdateTaskGroupCommand(taskGroupRepo),
+			DeleteTaskGroup: command.NewDeleteTaskGroupCommand(taskGroupRepo),
+		},
+	}
+}
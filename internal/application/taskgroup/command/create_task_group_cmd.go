@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type ICreateTaskGroupCommand decorator.CommandReturnHandler[*taskgroupdto.CreateTaskGroupReq, *taskgroupdto.TaskGroupRes]
+
+type createTaskGroupCommand struct {
+	taskGroupRepo taskgroup.Repository
+}
+
+func NewCreateTaskGroupCommand(taskGroupRepo taskgroup.Repository) ICreateTaskGroupCommand {
+	return &createTaskGroupCommand{
+		taskGroupRepo: taskGroupRepo,
+	}
+}
+
+func (c createTaskGroupCommand) Handle(ctx context.Context, req *taskgroupdto.CreateTaskGroupReq) (*taskgroupdto.TaskGroupRes, error) {
+	userID, err := helper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, helper.ErrInvalidTaskGroupName
+	}
+
+	item := &models.TaskGroup{
+		UserID:      userID,
+		CreatedBy:   userID,
+		Name:        name,
+		Icon:        strings.TrimSpace(req.Icon),
+		Description: strings.TrimSpace(req.Description),
+	}
+	if err := c.taskGroupRepo.Create(ctx, item); err != nil {
+		slog.Error("failed to create task group",
+			slog.Uint64("user_id", userID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return helper.ToTaskGroupRes(item), nil
+}
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	authHelper "github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestCreateTaskGroupCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.TaskGroup) bool {
+		return item.UserID == 1 && item.CreatedBy == 1 && item.Name == "Work" && item.Icon == "briefcase"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.TaskGroup).ID = 7
+	}).Return(nil)
+
+	cmd := NewCreateTaskGroupCommand(taskGroupRepo)
+	res, err := cmd.Handle(ctx, &taskgroupdto.CreateTaskGroupReq{Name: " Work ", Icon: "briefcase"})
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), res.ID)
+	require.Equal(t, "Work", res.Name)
+	taskGroupRepo.AssertExpectations(t)
+}
+
+func TestCreateTaskGroupCommand_Handle_BlankName(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	cmd := NewCreateTaskGroupCommand(taskGroupRepo)
+	_, err := cmd.Handle(ctx, &taskgroupdto.CreateTaskGroupReq{Name: "   "})
+
+	require.ErrorIs(t, err, helper.ErrInvalidTaskGroupName)
+	taskGroupRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateTaskGroupCommand_Handle_Unauthenticated(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+
+	cmd := NewCreateTaskGroupCommand(taskGroupRepo)
+	_, err := cmd.Handle(context.Background(), &taskgroupdto.CreateTaskGroupReq{Name: "Work"})
+
+	require.ErrorIs(t, err, authHelper.ErrInvalidToken)
+}
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IDeleteTaskGroupCommand decorator.CommandHandler[*taskgroupdto.DeleteTaskGroupReq]
+
+type deleteTaskGroupCommand struct {
+	taskGroupRepo taskgroup.Repository
+}
+
+func NewDeleteTaskGroupCommand(taskGroupRepo taskgroup.Repository) IDeleteTaskGroupCommand {
+	return &deleteTaskGroupCommand{
+		taskGroupRepo: taskGroupRepo,
+	}
+}
+
+// Handle deletes the group and every task in it.
+func (d deleteTaskGroupCommand) Handle(ctx context.Context, req *taskgroupdto.DeleteTaskGroupReq) error {
+	userID, err := helper.CallerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	item, err := helper.FindOwnedGroup(ctx, d.taskGroupRepo, userID, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err := d.taskGroupRepo.Delete(ctx, item.ID); err != nil {
+		slog.Error("failed to delete task group",
+			slog.Uint64("group_id", item.ID),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestDeleteTaskGroupCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 1}, nil)
+	taskGroupRepo.On("Delete", mock.Anything, uint64(7)).Return(nil)
+
+	cmd := NewDeleteTaskGroupCommand(taskGroupRepo)
+	err := cmd.Handle(ctx, &taskgroupdto.DeleteTaskGroupReq{GroupID: 7})
+
+	require.NoError(t, err)
+	taskGroupRepo.AssertExpectations(t)
+}
+
+func TestDeleteTaskGroupCommand_Handle_OtherUsersGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(&models.TaskGroup{ID: 7, UserID: 2}, nil)
+
+	cmd := NewDeleteTaskGroupCommand(taskGroupRepo)
+	err := cmd.Handle(ctx, &taskgroupdto.DeleteTaskGroupReq{GroupID: 7})
+
+	require.ErrorIs(t, err, helper.ErrTaskGroupNotFound)
+	taskGroupRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IRenameTaskGroupCommand decorator.CommandReturnHandler[*taskgroupdto.RenameTaskGroupReq, *taskgroupdto.TaskGroupRes]
+
+type renameTaskGroupCommand struct {
+	taskGroupRepo taskgroup.Repository
+}
+
+func NewRenameTaskGroupCommand(taskGroupRepo taskgroup.Repository) IRenameTaskGroupCommand {
+	return &renameTaskGroupCommand{
+		taskGroupRepo: taskGroupRepo,
+	}
+}
+
+func (r renameTaskGroupCommand) Handle(ctx context.Context, req *taskgroupdto.RenameTaskGroupReq) (*taskgroupdto.TaskGroupRes, error) {
+	userID, err := helper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, helper.ErrInvalidTaskGroupName
+	}
+
+	item, err := helper.FindOwnedGroup(ctx, r.taskGroupRepo, userID, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Name = name
+	if err := r.taskGroupRepo.Update(ctx, item, "name"); err != nil {
+		slog.Error("failed to rename task group",
+			slog.Uint64("group_id", item.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return helper.ToTaskGroupRes(item), nil
+}
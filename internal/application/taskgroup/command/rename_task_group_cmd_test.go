@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+	"gorm.io/gorm"
+)
+
+func TestRenameTaskGroupCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).
+		Return(&models.TaskGroup{ID: 7, UserID: 1, Name: "Work", Icon: "briefcase"}, nil)
+	taskGroupRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *models.TaskGroup) bool {
+		return item.Name == "Office" && item.Icon == "briefcase"
+	}), []string{"name"}).Return(nil)
+
+	cmd := NewRenameTaskGroupCommand(taskGroupRepo)
+	res, err := cmd.Handle(ctx, &taskgroupdto.RenameTaskGroupReq{GroupID: 7, Name: "Office "})
+
+	require.NoError(t, err)
+	require.Equal(t, "Office", res.Name)
+	taskGroupRepo.AssertExpectations(t)
+}
+
+func TestRenameTaskGroupCommand_Handle_OtherUsersGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).
+		Return(&models.TaskGroup{ID: 7, UserID: 2, Name: "Work"}, nil)
+
+	cmd := NewRenameTaskGroupCommand(taskGroupRepo)
+	_, err := cmd.Handle(ctx, &taskgroupdto.RenameTaskGroupReq{GroupID: 7, Name: "Office"})
+
+	require.ErrorIs(t, err, helper.ErrTaskGroupNotFound)
+	taskGroupRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRenameTaskGroupCommand_Handle_NotFound(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).Return(nil, gorm.ErrRecordNotFound)
+
+	cmd := NewRenameTaskGroupCommand(taskGroupRepo)
+	_, err := cmd.Handle(ctx, &taskgroupdto.RenameTaskGroupReq{GroupID: 7, Name: "Office"})
+
+	require.ErrorIs(t, err, helper.ErrTaskGroupNotFound)
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IUpdateTaskGroupCommand decorator.CommandReturnHandler[*taskgroupdto.UpdateTaskGroupReq, *taskgroupdto.TaskGroupRes]
+
+type updateTaskGroupCommand struct {
+	taskGroupRepo taskgroup.Repository
+}
+
+func NewUpdateTaskGroupCommand(taskGroupRepo taskgroup.Repository) IUpdateTaskGroupCommand {
+	return &updateTaskGroupCommand{
+		taskGroupRepo: taskGroupRepo,
+	}
+}
+
+// Handle updates the icon and description of a group. Only the fields in the
+// request are written, so concurrent edits of the others are kept.
+func (u updateTaskGroupCommand) Handle(ctx context.Context, req *taskgroupdto.UpdateTaskGroupReq) (*taskgroupdto.TaskGroupRes, error) {
+	userID, err := helper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Icon == nil && req.Description == nil {
+		return nil, helper.ErrNoTaskGroupChanges
+	}
+
+	item, err := helper.FindOwnedGroup(ctx, u.taskGroupRepo, userID, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, 2)
+	if req.Icon != nil {
+		item.Icon = strings.TrimSpace(*req.Icon)
+		columns = append(columns, "icon")
+	}
+	if req.Description != nil {
+		item.Description = strings.TrimSpace(*req.Description)
+		columns = append(columns, "description")
+	}
+	if err := u.taskGroupRepo.Update(ctx, item, columns...); err != nil {
+		slog.Error("failed to update task group",
+			slog.Uint64("group_id", item.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return helper.ToTaskGroupRes(item), nil
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestUpdateTaskGroupCommand_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).
+		Return(&models.TaskGroup{ID: 7, UserID: 1, Name: "Work", Icon: "briefcase", Description: "Office"}, nil)
+	taskGroupRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *models.TaskGroup) bool {
+		return item.Name == "Work" && item.Icon == "laptop" && item.Description == "Office"
+	}), []string{"icon"}).Return(nil)
+
+	cmd := NewUpdateTaskGroupCommand(taskGroupRepo)
+	res, err := cmd.Handle(ctx, &taskgroupdto.UpdateTaskGroupReq{GroupID: 7, Icon: new("laptop")})
+
+	require.NoError(t, err)
+	require.Equal(t, "laptop", res.Icon)
+	taskGroupRepo.AssertExpectations(t)
+}
+
+func TestUpdateTaskGroupCommand_Handle_ClearDescription(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).
+		Return(&models.TaskGroup{ID: 7, UserID: 1, Name: "Work", Description: "Office"}, nil)
+	taskGroupRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *models.TaskGroup) bool {
+		return item.Description == ""
+	}), []string{"description"}).Return(nil)
+
+	cmd := NewUpdateTaskGroupCommand(taskGroupRepo)
+	_, err := cmd.Handle(ctx, &taskgroupdto.UpdateTaskGroupReq{GroupID: 7, Description: new("")})
+
+	require.NoError(t, err)
+	taskGroupRepo.AssertExpectations(t)
+}
+
+func TestUpdateTaskGroupCommand_Handle_NoChanges(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	cmd := NewUpdateTaskGroupCommand(taskGroupRepo)
+	_, err := cmd.Handle(ctx, &taskgroupdto.UpdateTaskGroupReq{GroupID: 7})
+
+	require.ErrorIs(t, err, helper.ErrNoTaskGroupChanges)
+	taskGroupRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
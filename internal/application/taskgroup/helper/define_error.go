@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"net/http"
+
+	"github.com/tdatIT/backend-go/pkgs/svcerr"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ErrTaskGroupNotFound = &svcerr.Error{
+		Message:    "Task group not found",
+		VIMessage:  "Không tìm thấy nhóm công việc",
+		Code:       "TASKGROUP-001",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   codes.NotFound,
+	}
+
+	ErrInvalidTaskGroupName = &svcerr.Error{
+		Message:    "Task group name must not be blank",
+		VIMessage:  "Tên nhóm công việc không được để trống",
+		Code:       "TASKGROUP-002",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+
+	ErrNoTaskGroupChanges = &svcerr.Error{
+		Message:    "Nothing to update: provide icon or description",
+		VIMessage:  "Không có thông tin cần cập nhật: hãy cung cấp biểu tượng hoặc mô tả",
+		Code:       "TASKGROUP-003",
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+	}
+)
@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	authHelper "github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"gorm.io/gorm"
+)
+
+// CallerID returns the ID of the authenticated user in ctx.
+func CallerID(ctx context.Context) (uint64, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok {
+		return 0, authHelper.ErrInvalidToken
+	}
+	return caller.UserID, nil
+}
+
+// FindOwnedGroup loads a task group of userID. Groups of other users are
+// reported as missing so their existence is not leaked.
+func FindOwnedGroup(ctx context.Context, repo taskgroup.Repository, userID uint64, groupID uint64) (*models.TaskGroup, error) {
+	item, err := repo.FindByID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskGroupNotFound
+		}
+		slog.Error("failed to find task group",
+			slog.Uint64("group_id", groupID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if item.UserID != userID {
+		return nil, ErrTaskGroupNotFound
+	}
+
+	return item, nil
+}
+
+func ToTaskGroupRes(item *models.TaskGroup) *taskgroupdto.TaskGroupRes {
+	return &taskgroupdto.TaskGroupRes{
+		ID:          item.ID,
+		Name:        item.Name,
+		Icon:        item.Icon,
+		Description: item.Description,
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+)
+
+type IGetTaskGroupQuery decorator.QueryHandler[*taskgroupdto.GetTaskGroupReq, *taskgroupdto.TaskGroupRes]
+
+type getTaskGroupQuery struct {
+	taskGroupRepo taskgroup.Repository
+}
+
+func NewGetTaskGroupQuery(taskGroupRepo taskgroup.Repository) IGetTaskGroupQuery {
+	return &getTaskGroupQuery{
+		taskGroupRepo: taskGroupRepo,
+	}
+}
+
+func (g getTaskGroupQuery) Handle(ctx context.Context, req *taskgroupdto.GetTaskGroupReq) (*taskgroupdto.TaskGroupRes, error) {
+	userID, err := helper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := helper.FindOwnedGroup(ctx, g.taskGroupRepo, userID, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToTaskGroupRes(item), nil
+}
@@ -0,0 +1,41 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/mocks"
+)
+
+func TestGetTaskGroupQuery_Handle_Success(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).
+		Return(&models.TaskGroup{ID: 7, UserID: 1, Name: "Work"}, nil)
+
+	qry := NewGetTaskGroupQuery(taskGroupRepo)
+	res, err := qry.Handle(ctx, &taskgroupdto.GetTaskGroupReq{GroupID: 7})
+
+	require.NoError(t, err)
+	require.Equal(t, "Work", res.Name)
+}
+
+func TestGetTaskGroupQuery_Handle_OtherUsersGroup(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindByID", mock.Anything, uint64(7)).
+		Return(&models.TaskGroup{ID: 7, UserID: 2, Name: "Work"}, nil)
+
+	qry := NewGetTaskGroupQuery(taskGroupRepo)
+	_, err := qry.Handle(ctx, &taskgroupdto.GetTaskGroupReq{GroupID: 7})
+
+	require.ErrorIs(t, err, helper.ErrTaskGroupNotFound)
+}
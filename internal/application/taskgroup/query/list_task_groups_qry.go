@@ -0,0 +1,53 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/application/taskgroup/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/pkgs/decorator"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+type IListTaskGroupsQuery decorator.QueryHandler[*taskgroupdto.ListTaskGroupsReq, *pageable.ListResponse]
+
+type listTaskGroupsQuery struct {
+	taskGroupRepo taskgroup.Repository
+}
+
+func NewListTaskGroupsQuery(taskGroupRepo taskgroup.Repository) IListTaskGroupsQuery {
+	return &listTaskGroupsQuery{
+		taskGroupRepo: taskGroupRepo,
+	}
+}
+
+func (l listTaskGroupsQuery) Handle(ctx context.Context, req *taskgroupdto.ListTaskGroupsReq) (*pageable.ListResponse, error) {
+	userID, err := helper.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := l.taskGroupRepo.FindAllBy(ctx, &taskgroup.GetListParams{
+		ListQuery: req.ListQuery,
+		UserID:    userID,
+	})
+	if err != nil {
+		if errors.Is(err, pageable.ErrInvalidSortField) {
+			return nil, err
+		}
+		slog.Error("failed to list task groups",
+			slog.Uint64("user_id", userID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	res := make([]*taskgroupdto.TaskGroupRes, 0, len(items))
+	for _, item := range items {
+		res = append(res, helper.ToTaskGroupRes(item))
+	}
+
+	return pageable.NewListResponse(&req.ListQuery, res, total), nil
+}
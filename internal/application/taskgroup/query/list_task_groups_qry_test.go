@@ -0,0 +1,47 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
+	"github.com/tdatIT/backend-go/mocks"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+func TestListTaskGroupsQuery_Handle_ScopedToCaller(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindAllBy", mock.Anything, mock.MatchedBy(func(params *taskgroup.GetListParams) bool {
+		return params.UserID == 1 && params.GetOffset() == 2 && params.GetLimit() == 2
+	})).Return([]*models.TaskGroup{{ID: 3, UserID: 1, Name: "Home"}}, int64(3), nil)
+
+	qry := NewListTaskGroupsQuery(taskGroupRepo)
+	res, err := qry.Handle(ctx, &taskgroupdto.ListTaskGroupsReq{ListQuery: pageable.ListQuery{Page: 2, Size: 2}})
+
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Total)
+	require.False(t, res.HasMore)
+	items := res.Items.([]*taskgroupdto.TaskGroupRes)
+	require.Len(t, items, 1)
+	require.Equal(t, "Home", items[0].Name)
+	taskGroupRepo.AssertExpectations(t)
+}
+
+func TestListTaskGroupsQuery_Handle_InvalidSortField(t *testing.T) {
+	taskGroupRepo := new(mocks.MockTaskGroupRepository)
+	ctx := principal.NewContext(context.Background(), &principal.Principal{UserID: 1})
+
+	taskGroupRepo.On("FindAllBy", mock.Anything, mock.Anything).Return(nil, int64(0), pageable.ErrInvalidSortField)
+
+	qry := NewListTaskGroupsQuery(taskGroupRepo)
+	_, err := qry.Handle(ctx, &taskgroupdto.ListTaskGroupsReq{ListQuery: pageable.ListQuery{SortField: new("user_id")}})
+
+	require.ErrorIs(t, err, pageable.ErrInvalidSortField)
+}
@@ -0,0 +1,81 @@
+package taskdto
+
+import (
+	"time"
+
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+type TaskRes struct {
+	ID          uint64     `json:"id"`
+	GroupID     uint64     `json:"group_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Order       int        `json:"order"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// CreateTaskReq adds a task to a group; new tasks always start as pending.
+type CreateTaskReq struct {
+	GroupID     uint64     `param:"id" json:"-" validate:"required"`
+	Title       string     `json:"title" validate:"required,max=200"`
+	Description string     `json:"description" validate:"max=2000"`
+	Priority    int        `json:"priority" validate:"min=0"`
+	DueAt       *time.Time `json:"due_at"`
+}
+
+// UpdateTaskReq changes the fields that are present. The status has its own
+// request so every change goes through the task state machine.
+type UpdateTaskReq struct {
+	GroupID     uint64     `param:"id" json:"-" validate:"required"`
+	TaskID      uint64     `param:"taskId" json:"-" validate:"required"`
+	Title       *string    `json:"title" validate:"omitempty,max=200"`
+	Description *string    `json:"description" validate:"omitempty,max=2000"`
+	Priority    *int       `json:"priority" validate:"omitempty,min=0"`
+	DueAt       *time.Time `json:"due_at"`
+	ClearDueAt  bool       `json:"clear_due_at"`
+}
+
+type ChangeTaskStatusReq struct {
+	GroupID uint64 `param:"id" json:"-" validate:"required"`
+	TaskID  uint64 `param:"taskId" json:"-" validate:"required"`
+	Status  string `json:"status" validate:"required,oneof=pending in_progress done cancelled"`
+}
+
+// MoveTaskReq places a task right before or right after a sibling, at most
+// one of which may be given; with neither the task goes to the end. The
+// sibling must be in the target group, which defaults to the current one.
+type MoveTaskReq struct {
+	GroupID       uint64 `param:"id" json:"-" validate:"required"`
+	TaskID        uint64 `param:"taskId" json:"-" validate:"required"`
+	TargetGroupID uint64 `json:"group_id"`
+	BeforeID      uint64 `json:"before_id" validate:"excluded_with=AfterID"`
+	AfterID       uint64 `json:"after_id"`
+}
+
+// ReorderTasksReq lists every task of a group in its new order.
+type ReorderTasksReq struct {
+	GroupID uint64   `param:"id" json:"-" validate:"required"`
+	TaskIDs []uint64 `json:"task_ids" validate:"required,min=1,max=1000,unique,dive,required"`
+}
+
+type DeleteTaskReq struct {
+	GroupID uint64 `param:"id" json:"-" validate:"required"`
+	TaskID  uint64 `param:"taskId" json:"-" validate:"required"`
+}
+
+type GetTaskReq struct {
+	GroupID uint64 `param:"id" json:"-" validate:"required"`
+	TaskID  uint64 `param:"taskId" json:"-" validate:"required"`
+}
+
+type ListTasksReq struct {
+	pageable.ListQuery
+	GroupID uint64 `param:"id" json:"-" validate:"required"`
+	Status  string `query:"status" validate:"omitempty,oneof=pending in_progress done cancelled"`
+}
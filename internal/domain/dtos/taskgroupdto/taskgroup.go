@@ -0,0 +1,47 @@
+package taskgroupdto
+
+import (
+	"time"
+
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+type TaskGroupRes struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	Icon        string    `json:"icon,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateTaskGroupReq struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Icon        string `json:"icon" validate:"max=100"`
+	Description string `json:"description" validate:"max=2000"`
+}
+
+type RenameTaskGroupReq struct {
+	GroupID uint64 `param:"id" json:"-" validate:"required"`
+	Name    string `json:"name" validate:"required,max=100"`
+}
+
+// UpdateTaskGroupReq changes the fields that are present; an empty string
+// clears the field.
+type UpdateTaskGroupReq struct {
+	GroupID     uint64  `param:"id" json:"-" validate:"required"`
+	Icon        *string `json:"icon" validate:"omitempty,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=2000"`
+}
+
+type DeleteTaskGroupReq struct {
+	GroupID uint64 `param:"id" json:"-" validate:"required"`
+}
+
+type GetTaskGroupReq struct {
+	GroupID uint64 `param:"id" json:"-" validate:"required"`
+}
+
+type ListTaskGroupsReq struct {
+	pageable.ListQuery
+}
@@ -0,0 +1,47 @@
+package userdto
+
+import "time"
+
+type CreatePersonalAccessTokenReq struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"omitempty,dive,required,max=100"`
+	// ExpiresInDays defaults to the configured maximum lifetime.
+	ExpiresInDays int `json:"expires_in_days,omitempty" validate:"omitempty,min=1"`
+}
+
+// CreatePersonalAccessTokenRes is the only response that carries the token.
+type CreatePersonalAccessTokenRes struct {
+	Token string                  `json:"token"`
+	Item  *PersonalAccessTokenRes `json:"item"`
+}
+
+type PersonalAccessTokenRes struct {
+	ID         uint64     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ListPersonalAccessTokensReq struct{}
+
+type ListPersonalAccessTokensRes struct {
+	Items []*PersonalAccessTokenRes `json:"items"`
+}
+
+type RevokePersonalAccessTokenReq struct {
+	ID uint64 `param:"id" json:"-" validate:"required"`
+}
+
+type VerifyPersonalAccessTokenReq struct {
+	Token string `validate:"required"`
+}
+
+type VerifyPersonalAccessTokenRes struct {
+	UserID  uint64
+	TokenID uint64
+	Scopes  []string
+	Roles   []string
+}
@@ -0,0 +1,16 @@
+package userdto
+
+type SuspendUserReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type ReactivateUserReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type DeleteUserReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
@@ -0,0 +1,52 @@
+package userdto
+
+import (
+	"time"
+
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
+)
+
+type ListUsersReq struct {
+	pageable.ListQuery
+	IsActive *bool  `query:"isActive"`
+	Provider string `query:"provider" validate:"omitempty,max=50"`
+}
+
+type AdminUserRes struct {
+	ID              uint64     `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	FirstName       string     `json:"first_name"`
+	LastName        string     `json:"last_name"`
+	Providers       []string   `json:"providers"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	LastLoginAt     *time.Time `json:"last_login_at,omitempty"`
+	IsActive        bool       `json:"is_active"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	StatusReason    string     `json:"status_reason,omitempty"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type GetUserReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+}
+
+type UserDetailRes struct {
+	*AdminUserRes
+	Sessions []*SessionRes `json:"sessions"`
+}
+
+type UpdateUserReq struct {
+	UserID    uint64 `param:"id" json:"-" validate:"required"`
+	FirstName string `json:"first_name" validate:"required,max=100"`
+	LastName  string `json:"last_name" validate:"required,max=100"`
+}
+
+type ForceLogoutReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+}
+
+type SendPasswordResetReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+}
@@ -2,19 +2,47 @@ package userdto
 
 type LoginByGoogleReq struct {
 	IDToken   string `json:"id_token" validate:"required"`
+	Nonce     string `json:"nonce" validate:"required"`
 	UserAgent string `json:"user_agent,omitempty"`
 	IPAddress string `json:"ip_address,omitempty"`
 }
 
+type LoginByOIDCReq struct {
+	Provider  string `param:"provider" json:"-" validate:"required"`
+	IDToken   string `json:"id_token" validate:"required"`
+	Nonce     string `json:"nonce" validate:"required"`
+	UserAgent string `json:"user_agent,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+type IssueOIDCNonceReq struct{}
+
+// OIDCNonceRes is the nonce to send to the identity provider; the ID token it
+// returns is accepted here once.
+type OIDCNonceRes struct {
+	Nonce     string `json:"nonce"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
 type VerifyTokenReq struct {
 	AccessToken string `json:"access_token" validate:"required"`
 }
 
 type VerifyTokenRes struct {
-	Sub       string `json:"sub"`
-	SessionID string `json:"sid"`
-	JTI       string `json:"jti"`
-	ExpiresAt int64  `json:"exp"`
+	Sub       string   `json:"sub"`
+	SessionID string   `json:"sid"`
+	JTI       string   `json:"jti"`
+	ExpiresAt int64    `json:"exp"`
+	Roles     []string `json:"roles,omitempty"`
+	ClientID  string   `json:"client_id,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+}
+
+type AuthInfoRes struct {
+	UserID    uint64   `json:"user_id"`
+	SessionID string   `json:"sid"`
+	JTI       string   `json:"jti"`
+	Roles     []string `json:"roles"`
 }
 
 type RegisterReq struct {
@@ -33,10 +61,46 @@ type LogoutReq struct {
 
 type RefreshTokenReq struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
+	UserAgent    string `json:"user_agent,omitempty"`
+	IPAddress    string `json:"ip_address,omitempty"`
+	// ClientID is the authenticated OAuth client; sessions opened through
+	// OAuth can only be refreshed by their own client.
+	ClientID string `json:"-"`
 }
 
 type RefreshTokenRes struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken string `json:"access_token"`
+	// RefreshToken is left out in cookie session mode.
+	RefreshToken string `json:"refresh_token,omitempty"`
 	ExpiresIn    int64  `json:"expires_in"`
 }
+
+type GetJWKSReq struct{}
+
+type RequestPasswordResetReq struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ConfirmPasswordResetReq struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type ChangePasswordReq struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,nefield=CurrentPassword"`
+	UserAgent       string `json:"-"`
+	IPAddress       string `json:"-"`
+}
+
+type VerifyEmailReq struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendEmailVerificationReq struct{}
+
+type ChangeEmailReq struct {
+	Email           string `json:"email" validate:"required,email"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+	IPAddress       string `json:"-"`
+}
@@ -0,0 +1,27 @@
+package userdto
+
+import "time"
+
+type ListIdentitiesReq struct{}
+
+type IdentityRes struct {
+	ID        uint64    `json:"id"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListIdentitiesRes struct {
+	Items       []*IdentityRes `json:"items"`
+	HasPassword bool           `json:"has_password"`
+}
+
+type LinkIdentityReq struct {
+	Provider string `param:"provider" json:"-" validate:"required"`
+	IDToken  string `json:"id_token" validate:"required"`
+	Nonce    string `json:"nonce" validate:"required"`
+}
+
+type UnlinkIdentityReq struct {
+	ID uint64 `param:"id" json:"-" validate:"required"`
+}
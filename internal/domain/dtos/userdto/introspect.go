@@ -0,0 +1,34 @@
+package userdto
+
+// IntrospectReq is an RFC 7662 introspection request. Client credentials come
+// from HTTP Basic auth or the client_id/client_secret form fields.
+type IntrospectReq struct {
+	Token         string `form:"token" validate:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// IntrospectRes only carries claims when Active is true.
+type IntrospectRes struct {
+	Active    bool   `json:"active"`
+	Sub       string `json:"sub,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+	JTI       string `json:"jti,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	// Roles are the ones embedded in the token when it was issued.
+	Roles []string `json:"roles,omitempty"`
+}
+
+type ForwardAuthReq struct {
+	AccessToken string `validate:"required"`
+}
+
+type ForwardAuthRes struct {
+	UserID    string
+	SessionID string
+	Roles     []string
+}
@@ -8,10 +8,12 @@ type LoginByUserPassReq struct {
 }
 
 type LoginRes struct {
-	AccessToken  string          `json:"access_token"`
-	RefreshToken string          `json:"refresh_token"`
-	ExpiresIn    int64           `json:"expires_in"`
-	User         *UserProfileRes `json:"user"`
+	AccessToken  string          `json:"access_token,omitempty"`
+	RefreshToken string          `json:"refresh_token,omitempty"`
+	ExpiresIn    int64           `json:"expires_in,omitempty"`
+	MFARequired  bool            `json:"mfa_required,omitempty"`
+	MFAToken     string          `json:"mfa_token,omitempty"`
+	User         *UserProfileRes `json:"user,omitempty"`
 }
 
 type UserProfileRes struct {
@@ -0,0 +1,27 @@
+package userdto
+
+type EnrollTOTPReq struct{}
+
+type EnrollTOTPRes struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type ConfirmTOTPReq struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type ConfirmTOTPRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type DisableTOTPReq struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type VerifyMFAReq struct {
+	MFAToken  string `json:"mfa_token" validate:"required"`
+	Code      string `json:"code" validate:"required"`
+	UserAgent string `json:"user_agent,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
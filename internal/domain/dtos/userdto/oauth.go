@@ -0,0 +1,90 @@
+package userdto
+
+import "time"
+
+// AuthorizeReq is an RFC 6749 authorization request. GET /oauth/authorize
+// reads it from the query string; the consent page posts it back as JSON.
+type AuthorizeReq struct {
+	ResponseType        string `query:"response_type" json:"response_type"`
+	ClientID            string `query:"client_id" json:"client_id" validate:"required"`
+	RedirectURI         string `query:"redirect_uri" json:"redirect_uri"`
+	Scope               string `query:"scope" json:"scope"`
+	State               string `query:"state" json:"state"`
+	CodeChallenge       string `query:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `query:"code_challenge_method" json:"code_challenge_method"`
+}
+
+type OAuthClientRes struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	FirstParty bool   `json:"first_party"`
+}
+
+// AuthorizeInfoRes describes a valid authorization request. RedirectTo is set
+// when a consent page is configured.
+type AuthorizeInfoRes struct {
+	Client     *OAuthClientRes `json:"client"`
+	Scopes     []string        `json:"scopes"`
+	RedirectTo string          `json:"redirect_to,omitempty"`
+}
+
+// AuthorizeDecisionReq is posted by the consent page on behalf of the signed
+// in user. Approve is left out to ask whether consent is still needed.
+type AuthorizeDecisionReq struct {
+	AuthorizeReq
+	Approve *bool `json:"approve,omitempty"`
+}
+
+// AuthorizeDecisionRes either asks for consent or carries the redirect back
+// to the client with a code or an access_denied error.
+type AuthorizeDecisionRes struct {
+	ConsentRequired bool            `json:"consent_required"`
+	Client          *OAuthClientRes `json:"client,omitempty"`
+	Scopes          []string        `json:"scopes,omitempty"`
+	RedirectTo      string          `json:"redirect_to,omitempty"`
+}
+
+// OAuthTokenReq is a form-encoded RFC 6749 token request. Client credentials
+// come from HTTP Basic auth or the client_id/client_secret form fields.
+type OAuthTokenReq struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	UserAgent    string `form:"-"`
+	IPAddress    string `form:"-"`
+}
+
+type OAuthTokenRes struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// OAuthErrorRes is the RFC 6749 5.2 error body of the token endpoint.
+type OAuthErrorRes struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+type ListConsentsReq struct{}
+
+type ConsentRes struct {
+	Client    *OAuthClientRes `json:"client"`
+	Scopes    []string        `json:"scopes"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type ListConsentsRes struct {
+	Items []*ConsentRes `json:"items"`
+}
+
+type RevokeConsentReq struct {
+	ClientID string `param:"client_id" json:"-" validate:"required"`
+}
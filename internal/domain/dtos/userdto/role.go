@@ -0,0 +1,32 @@
+package userdto
+
+type ListRolesReq struct{}
+
+type RoleRes struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+type ListRolesRes struct {
+	Items []*RoleRes `json:"items"`
+}
+
+type ListUserRolesReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+}
+
+type UserRolesRes struct {
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+type AssignRoleReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+	Role   string `json:"role" validate:"required,max=50"`
+}
+
+type RevokeRoleReq struct {
+	UserID uint64 `param:"id" json:"-" validate:"required"`
+	Role   string `param:"role" json:"-" validate:"required,max=50"`
+}
@@ -0,0 +1,33 @@
+package userdto
+
+import "time"
+
+type ListSessionsReq struct{}
+
+type SessionRes struct {
+	ID         string     `json:"id"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	IPAddress  string     `json:"ip_address,omitempty"`
+	Browser    string     `json:"browser,omitempty"`
+	OS         string     `json:"os,omitempty"`
+	DeviceType string     `json:"device_type,omitempty"`
+	Country    string     `json:"country,omitempty"`
+	City       string     `json:"city,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	IsCurrent  bool       `json:"is_current"`
+}
+
+type ListSessionsRes struct {
+	Items []*SessionRes `json:"items"`
+}
+
+type RevokeSessionReq struct {
+	SessionID string `param:"id" json:"-" validate:"required"`
+}
+
+type RevokeOtherSessionsReq struct{}
+
+type RevokeSessionsRes struct {
+	Revoked int64 `json:"revoked"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// OAuthClient is an application allowed to obtain tokens on behalf of users
+// through the authorization code flow. Public clients have no secret.
+type OAuthClient struct {
+	ID           string    `json:"id" gorm:"primaryKey;size:64"`
+	Name         string    `json:"name" gorm:"size:100;not null"`
+	SecretHash   string    `json:"-" gorm:"size:64"`
+	RedirectURIs []string  `json:"redirect_uris" gorm:"serializer:json;type:text"`
+	Scopes       []string  `json:"scopes" gorm:"serializer:json;type:text"`
+	FirstParty   bool      `json:"first_party" gorm:"not null;default:false"` // trusted, skips the consent prompt
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// IsPublic reports whether the client cannot keep a secret, e.g. a SPA or
+// mobile app.
+func (c *OAuthClient) IsPublic() bool {
+	return c.SecretHash == ""
+}
+
+// OAuthConsent records the scopes a user has granted to a client.
+type OAuthConsent struct {
+	UserID    uint64    `json:"user_id" gorm:"primaryKey"`
+	ClientID  string    `json:"client_id" gorm:"primaryKey;size:64"`
+	Scopes    []string  `json:"scopes" gorm:"serializer:json;type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Client *OAuthClient `json:"client,omitempty" gorm:"foreignKey:ClientID;references:ID"`
+}
+
+func (OAuthConsent) TableName() string {
+	return "oauth_consents"
+}
+
+// OAuthAuthorizationCode is a single-use code issued by /oauth/authorize.
+// Only the SHA-256 hash of the code is stored.
+type OAuthAuthorizationCode struct {
+	ID                  uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	CodeHash            string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ClientID            string     `json:"client_id" gorm:"size:64;not null"`
+	UserID              uint64     `json:"user_id" gorm:"not null;index"`
+	RedirectURI         string     `json:"redirect_uri" gorm:"size:2048;not null"`
+	Scope               string     `json:"scope" gorm:"size:1024"`
+	CodeChallenge       string     `json:"-" gorm:"size:128;not null"`
+	CodeChallengeMethod string     `json:"-" gorm:"size:10;not null"`
+	ExpiresAt           time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt              *time.Time `json:"used_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
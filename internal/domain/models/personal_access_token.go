@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// PersonalAccessToken is a long-lived credential for scripts and CI jobs.
+// Only the SHA-256 hash of the token is stored; Prefix is kept in clear so
+// users can tell their tokens apart.
+type PersonalAccessToken struct {
+	ID         uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID     uint64     `json:"user_id" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"size:100;not null"`
+	Prefix     string     `json:"prefix" gorm:"size:20;not null"`
+	TokenHash  string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json;type:text"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// PermissionAll grants every permission.
+const PermissionAll = "*"
+
+// Role is a named set of permissions assigned to users.
+type Role struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:50;not null;uniqueIndex"`
+	Description string    `json:"description,omitempty" gorm:"size:255"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Permissions []*Permission `json:"permissions,omitempty" gorm:"many2many:role_permissions"`
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
+
+// Permission names an action as "<resource>:<action>", e.g. "tasks:admin".
+// "<resource>:*" grants every action on the resource.
+type Permission struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:100;not null;uniqueIndex"`
+	Description string    `json:"description,omitempty" gorm:"size:255"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// UserRole assigns a role to a user.
+type UserRole struct {
+	UserID     uint64    `json:"user_id" gorm:"primaryKey"`
+	RoleID     uint64    `json:"role_id" gorm:"primaryKey;index"`
+	AssignedBy *uint64   `json:"assigned_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	Role *Role `json:"role,omitempty" gorm:"foreignKey:RoleID;references:ID"`
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	SecurityEventRefreshTokenReuse  = "refresh_token_reuse"
+	SecurityEventLoginLockout       = "login_lockout"
+	SecurityEventPasswordChanged    = "password_changed"
+	SecurityEventAccountSuspended   = "account_suspended"
+	SecurityEventAccountReactivated = "account_reactivated"
+	SecurityEventAccountDeleted     = "account_deleted"
+	SecurityEventForcedLogout       = "forced_logout"
+	SecurityEventPasswordResetSent  = "password_reset_sent"
+)
+
+// SecurityEvent is an audit record of a security-relevant occurrence on an account.
+type SecurityEvent struct {
+	ID        uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `json:"user_id" gorm:"index"`
+	ActorID   *uint64   `json:"actor_id,omitempty" gorm:"index"` // the admin who acted, when not the user
+	SessionID string    `json:"session_id,omitempty" gorm:"size:20;index"`
+	Type      string    `json:"type" gorm:"size:50;not null;index"`
+	IPAddress string    `json:"ip_address,omitempty" gorm:"size:50"`
+	UserAgent string    `json:"user_agent,omitempty" gorm:"size:255"`
+	Details   string    `json:"details,omitempty" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (SecurityEvent) TableName() string {
+	return "security_events"
+}
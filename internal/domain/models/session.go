@@ -8,12 +8,22 @@ import (
 )
 
 type Session struct {
-	ID         string     `json:"id" gorm:"primaryKey;size:20"`
-	UserID     uint64     `json:"user_id" gorm:"not null;index"`
-	RefreshJTI string     `json:"refresh_jti" gorm:"size:255;not null;uniqueIndex"`
-	UserAgent  string     `json:"user_agent,omitempty" gorm:"size:255"`
-	IPAddress  string     `json:"ip_address,omitempty" gorm:"size:50"`
-	IsActive   bool       `json:"is_active" gorm:"not null;default:true"`
+	ID         string `json:"id" gorm:"primaryKey;size:20"`
+	UserID     uint64 `json:"user_id" gorm:"not null;index"`
+	RefreshJTI string `json:"refresh_jti" gorm:"size:255;not null;uniqueIndex"`
+	UserAgent  string `json:"user_agent,omitempty" gorm:"size:255"`
+	IPAddress  string `json:"ip_address,omitempty" gorm:"size:50"`
+	IsActive   bool   `json:"is_active" gorm:"not null;default:true"`
+	// Device and location are derived from UserAgent and IPAddress when the
+	// session is opened.
+	Browser    string `json:"browser,omitempty" gorm:"size:50"`
+	OS         string `json:"os,omitempty" gorm:"size:50"`
+	DeviceType string `json:"device_type,omitempty" gorm:"size:20"`
+	Country    string `json:"country,omitempty" gorm:"size:2"`
+	City       string `json:"city,omitempty" gorm:"size:100"`
+	// ClientID and Scope are set for sessions opened through OAuth.
+	ClientID   string     `json:"client_id,omitempty" gorm:"size:64;index"`
+	Scope      string     `json:"scope,omitempty" gorm:"size:1024"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
@@ -26,3 +36,16 @@ func (s *Session) BeforeCreate(tx *gorm.DB) error {
 
 	return nil
 }
+
+// RotatedRefreshToken records a refresh token JTI that has already been
+// exchanged, so a replay of it can be detected.
+type RotatedRefreshToken struct {
+	ID        uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID string    `json:"session_id" gorm:"size:20;not null;index"`
+	JTI       string    `json:"jti" gorm:"size:255;not null;uniqueIndex"`
+	RotatedAt time.Time `json:"rotated_at" gorm:"not null;index"`
+}
+
+func (RotatedRefreshToken) TableName() string {
+	return "rotated_refresh_tokens"
+}
@@ -2,6 +2,13 @@ package models
 
 import "time"
 
+const (
+	TaskStatusPending    = "pending"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+	TaskStatusCancelled  = "cancelled"
+)
+
 // TaskGroup represents a collection of tasks in a todo list.
 type TaskGroup struct {
 	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
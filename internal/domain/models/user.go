@@ -8,18 +8,38 @@ type User struct {
 	LastName          string     `json:"last_name" gorm:"size:100;not null"`
 	Email             string     `json:"email" gorm:"size:255;uniqueIndex;not null"`
 	Username          string     `json:"username" gorm:"size:50;uniqueIndex;not null"`
-	PasswordHash      string     `json:"-" gorm:"size:255;not null"`
-	OidcProvider      string     `json:"oidc_provider,omitempty" gorm:"size:50;index:idx_oidc_provider_subject"`
-	OidcSubject       string     `json:"oidc_subject,omitempty" gorm:"size:255;index:idx_oidc_provider_subject"`
+	PasswordHash      string     `json:"-" gorm:"size:255;not null"` // empty for accounts created through an external identity
 	PasswordChangedAt *time.Time `json:"password_changed_at,omitempty"`
 	EmailVerifiedAt   *time.Time `json:"email_verified_at,omitempty"`
 	LastLoginAt       *time.Time `json:"last_login_at,omitempty"`
-	IsActive          bool       `json:"is_active" gorm:"not null;default:true"`
-	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	IsActive          bool       `json:"is_active" gorm:"not null;default:true"` // false while suspended
+	// DeletedAt is a plain column rather than gorm.DeletedAt so lookups still
+	// return deleted accounts and sign-in can reject them explicitly.
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	StatusReason    string     `json:"status_reason,omitempty" gorm:"size:255"`
+	StatusChangedBy *uint64    `json:"status_changed_by,omitempty"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 
 	// Relationships
-	TaskGroups []*TaskGroup `json:"task_groups,omitempty" gorm:"foreignKey:UserID;references:ID"`
+	TaskGroups []*TaskGroup    `json:"task_groups,omitempty" gorm:"foreignKey:UserID;references:ID"`
+	Identities []*UserIdentity `json:"identities,omitempty" gorm:"foreignKey:UserID;references:ID"`
+}
+
+// HasPassword reports whether the user can sign in with a password.
+func (u *User) HasPassword() bool {
+	return u.PasswordHash != ""
+}
+
+// IsDeleted reports whether the account has been soft-deleted.
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// CanSignIn reports whether the account is neither suspended nor deleted.
+func (u *User) CanSignIn() bool {
+	return u.IsActive && !u.IsDeleted()
 }
 
 func (User) TableName() string {
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// UserIdentity links a user to an account at an external OpenID Connect
+// provider. A (provider, subject) pair belongs to at most one user.
+type UserIdentity struct {
+	ID        uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `json:"user_id" gorm:"not null;index"`
+	Provider  string    `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_user_identity_provider_subject"`
+	Subject   string    `json:"subject" gorm:"size:255;not null;uniqueIndex:idx_user_identity_provider_subject"`
+	Email     string    `json:"email,omitempty" gorm:"size:255"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// UserMFA holds a user's TOTP enrollment. The secret is encrypted at rest and
+// the enrollment only takes effect once Enabled is set by a confirmed code.
+type UserMFA struct {
+	UserID           uint64     `json:"user_id" gorm:"primaryKey"`
+	SecretCiphertext string     `json:"-" gorm:"size:255;not null"`
+	Enabled          bool       `json:"enabled" gorm:"not null;default:false"`
+	LastUsedStep     int64      `json:"-" gorm:"not null;default:0"` // last accepted TOTP time step, blocks code replay
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+// MFARecoveryCode is a one-time fallback for a lost authenticator. Only the
+// SHA-256 hash of the code is stored.
+type MFARecoveryCode struct {
+	ID        uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uint64     `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
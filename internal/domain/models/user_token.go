@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+const (
+	UserTokenPurposePasswordReset     = "password_reset"
+	UserTokenPurposeEmailVerification = "email_verification"
+)
+
+// UserToken is a single-use secret sent to a user out of band. Only the
+// SHA-256 hash of the token is stored.
+type UserToken struct {
+	ID        uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uint64     `json:"user_id" gorm:"not null;index"`
+	Purpose   string     `json:"purpose" gorm:"size:50;not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Target    string     `json:"target,omitempty" gorm:"size:255"` // e.g. the email address being verified
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
@@ -0,0 +1,38 @@
+package principal
+
+import "context"
+
+type contextKey struct{}
+
+// Principal identifies the authenticated caller of a request. Callers using a
+// personal access token have no session; TokenID is set instead. ClientID is
+// set for access tokens issued to an OAuth client.
+type Principal struct {
+	UserID    uint64
+	SessionID string
+	JTI       string
+	TokenID   uint64
+	ClientID  string
+	// Scopes limit the permissions of personal access tokens and OAuth
+	// tokens; such a token without scopes passes no permission check.
+	Scopes []string
+	// Roles come from the access token and may be stale; use the authorizer
+	// for permission checks.
+	Roles []string
+}
+
+// Scoped reports whether the caller is limited to Scopes.
+func (p *Principal) Scoped() bool {
+	return p.TokenID != 0 || p.ClientID != ""
+}
+
+// NewContext returns a copy of ctx carrying the given principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,88 @@
+package device
+
+import (
+	"log/slog"
+	"net/netip"
+	"strings"
+
+	"github.com/oschwald/geoip2-golang/v2"
+)
+
+// Location is where an IP address is registered. Fields are empty when the
+// address is private or not in the database.
+type Location struct {
+	Country string // ISO 3166-1 alpha-2 code
+	City    string
+}
+
+// Locator resolves IP addresses to locations.
+type Locator interface {
+	Locate(ip string) Location
+	// Close releases the database; Locate must not be called afterwards.
+	Close() error
+}
+
+// NewLocator opens the MaxMind-format (.mmdb) City or Country database at
+// path. Lookups are disabled when path is empty.
+func NewLocator(path string) (Locator, error) {
+	if path == "" {
+		return NoopLocator{}, nil
+	}
+
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geoIPLocator{
+		reader:  reader,
+		hasCity: strings.Contains(reader.Metadata().DatabaseType, "City"),
+	}, nil
+}
+
+// NoopLocator resolves nothing; used when no GeoIP database is configured.
+type NoopLocator struct{}
+
+func (NoopLocator) Locate(string) Location {
+	return Location{}
+}
+
+func (NoopLocator) Close() error {
+	return nil
+}
+
+type geoIPLocator struct {
+	reader  *geoip2.Reader
+	hasCity bool
+}
+
+func (g *geoIPLocator) Locate(ip string) Location {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return Location{}
+	}
+	addr = addr.Unmap()
+
+	if !g.hasCity {
+		record, err := g.reader.Country(addr)
+		if err != nil {
+			slog.Warn("geoip country lookup failed", slog.String("error", err.Error()))
+			return Location{}
+		}
+		return Location{Country: record.Country.ISOCode}
+	}
+
+	record, err := g.reader.City(addr)
+	if err != nil {
+		slog.Warn("geoip city lookup failed", slog.String("error", err.Error()))
+		return Location{}
+	}
+	return Location{
+		Country: record.Country.ISOCode,
+		City:    record.City.Names.English,
+	}
+}
+
+func (g *geoIPLocator) Close() error {
+	return g.reader.Close()
+}
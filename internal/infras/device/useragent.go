@@ -0,0 +1,54 @@
+package device
+
+import (
+	"strings"
+
+	"github.com/mssola/useragent"
+)
+
+const (
+	TypeDesktop = "desktop"
+	TypeMobile  = "mobile"
+	TypeTablet  = "tablet"
+	TypeBot     = "bot"
+)
+
+// Info describes the client behind a User-Agent header. Versions are left out
+// so that browser and OS updates do not count as a new device.
+type Info struct {
+	Browser string
+	OS      string
+	Type    string
+}
+
+// ParseUserAgent extracts the browser, operating system and device type from
+// a User-Agent header. An empty header yields an empty Info.
+func ParseUserAgent(header string) Info {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return Info{}
+	}
+
+	ua := useragent.New(header)
+	browser, _ := ua.Browser()
+
+	return Info{
+		Browser: browser,
+		OS:      ua.OSInfo().Name,
+		Type:    deviceType(ua, header),
+	}
+}
+
+func deviceType(ua *useragent.UserAgent, header string) string {
+	switch {
+	case ua.Bot():
+		return TypeBot
+	case strings.Contains(header, "iPad") || strings.Contains(header, "Tablet") ||
+		(strings.Contains(header, "Android") && !strings.Contains(header, "Mobile")):
+		return TypeTablet
+	case ua.Mobile():
+		return TypeMobile
+	default:
+		return TypeDesktop
+	}
+}
@@ -0,0 +1,54 @@
+package device_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/infras/device"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected device.Info
+	}{
+		{
+			name:     "desktop chrome",
+			header:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected: device.Info{Browser: "Chrome", OS: "Windows", Type: device.TypeDesktop},
+		},
+		{
+			name:     "iphone safari",
+			header:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1",
+			expected: device.Info{Browser: "Safari", OS: "iPhone OS", Type: device.TypeMobile},
+		},
+		{
+			name:     "android tablet",
+			header:   "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected: device.Info{Browser: "Chrome", OS: "Android", Type: device.TypeTablet},
+		},
+		{
+			name:     "bot",
+			header:   "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected: device.Info{Browser: "Googlebot", OS: "", Type: device.TypeBot},
+		},
+		{
+			name:     "empty",
+			header:   "  ",
+			expected: device.Info{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, device.ParseUserAgent(test.header))
+		})
+	}
+}
+
+func TestNoopLocator(t *testing.T) {
+	locator, err := device.NewLocator("")
+	require.NoError(t, err)
+	require.Equal(t, device.Location{}, locator.Locate("8.8.8.8"))
+}
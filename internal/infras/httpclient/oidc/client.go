@@ -0,0 +1,21 @@
+package oidc
+
+import (
+	"github.com/tdatIT/backend-go/config"
+	"resty.dev/v3"
+)
+
+func newHTTPClient(config *config.ServiceConfig) *resty.Client {
+	transportSetting := &resty.TransportSettings{
+		DialerTimeout:       0,
+		DialerKeepAlive:     0,
+		IdleConnTimeout:     0,
+		MaxIdleConns:        0,
+		MaxIdleConnsPerHost: 0,
+		MaxConnsPerHost:     0,
+	}
+
+	return resty.NewWithTransportSettings(transportSetting).
+		SetDebug(config.Server.DebugMode).
+		SetTimeout(config.Server.HttpClientTimeout)
+}
@@ -2,67 +2,45 @@ package oidc
 
 import (
 	"context"
-	"log/slog"
 
 	"github.com/tdatIT/backend-go/config"
 	"resty.dev/v3"
 )
 
-type GoogleIDTokenInfo struct {
-	Email         string `json:"email"`
-	EmailVerified string `json:"email_verified"`
-	Subject       string `json:"sub"`
-	Audience      string `json:"aud"`
-	GivenName     string `json:"given_name"`
-	FamilyName    string `json:"family_name"`
-	Name          string `json:"name"`
-}
+// GoogleProviderName identifies Google identities stored on user accounts.
+const GoogleProviderName = "google"
+
+const googleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+// googleIssuers are both iss values Google documents for its ID tokens.
+var googleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
 
+// GoogleOIDCVerifier verifies Google ID tokens. The token must carry nonce.
 type GoogleOIDCVerifier interface {
-	VerifyIDToken(ctx context.Context, idToken string) (*GoogleIDTokenInfo, error)
+	VerifyIDToken(ctx context.Context, idToken string, nonce string) (*IDTokenClaims, error)
 }
 
+// GoogleOIDCProvider verifies Google ID tokens locally against Google's
+// published signing keys instead of calling the tokeninfo endpoint.
 type GoogleOIDCProvider struct {
-	client *resty.Client
+	provider *Provider
 }
 
 func NewGoogleOIDCProvider(config *config.ServiceConfig) *GoogleOIDCProvider {
-	transportSetting := &resty.TransportSettings{
-		DialerTimeout:       0,
-		DialerKeepAlive:     0,
-		IdleConnTimeout:     0,
-		MaxIdleConns:        0,
-		MaxIdleConnsPerHost: 0,
-		MaxConnsPerHost:     0,
-	}
-
-	newClient := resty.NewWithTransportSettings(transportSetting).
-		SetDebug(config.Server.DebugMode).
-		SetTimeout(config.Server.HttpClientTimeout)
-
-	return &GoogleOIDCProvider{
-		client: newClient,
-	}
+	return newGoogleOIDCProvider(newHTTPClient(config), googleJWKSURL, config.Auth.GoogleClientID)
 }
 
-func (p *GoogleOIDCProvider) VerifyIDToken(ctx context.Context, idToken string) (*GoogleIDTokenInfo, error) {
-	resp, err := p.client.R().
-		SetContext(ctx).
-		SetQueryParam("id_token", idToken).
-		SetResult(&GoogleIDTokenInfo{}).
-		Get("https://oauth2.googleapis.com/tokeninfo")
-	if err != nil {
-		slog.Error("failed to verify google id token", slog.String("error", err.Error()))
-		return nil, err
+func newGoogleOIDCProvider(client *resty.Client, jwksURL string, clientID string) *GoogleOIDCProvider {
+	var audiences []string
+	if clientID != "" {
+		audiences = []string{clientID}
 	}
 
-	if resp.IsError() {
-		slog.Error("google tokeninfo endpoint returned error",
-			slog.Int("status_code", resp.StatusCode()),
-			slog.String("response", resp.String()))
-		return nil, err
+	return &GoogleOIDCProvider{
+		provider: newStaticProvider(googleIssuers, audiences, jwksURL, []string{"RS256"}, client),
 	}
+}
 
-	info := resp.Result().(*GoogleIDTokenInfo)
-	return info, nil
+func (p *GoogleOIDCProvider) VerifyIDToken(ctx context.Context, idToken string, nonce string) (*IDTokenClaims, error) {
+	return p.provider.VerifyIDToken(ctx, idToken, nonce)
 }
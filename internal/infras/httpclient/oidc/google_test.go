@@ -0,0 +1,166 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"resty.dev/v3"
+)
+
+// googleKeyServer is a local stand-in for Google's certs endpoint.
+type googleKeyServer struct {
+	server *httptest.Server
+	key    *security.SigningKey
+	hits   atomic.Int32
+}
+
+func newGoogleKeyServer(t *testing.T, cacheControl string) *googleKeyServer {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	key, err := security.NewSigningKey("google-1", "RS256", private, time.Time{})
+	require.NoError(t, err)
+
+	ring, err := security.NewKeyRingFromKeys([]*security.SigningKey{key}, 0)
+	require.NoError(t, err)
+
+	s := &googleKeyServer{key: key}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", cacheControl)
+		_ = json.NewEncoder(w).Encode(ring.JWKS())
+	}))
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *googleKeyServer) sign(t *testing.T, mutate func(jwt.MapClaims)) string {
+	t.Helper()
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"sub":            "google-sub",
+		"aud":            "client-1",
+		"azp":            "client-1",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          "user@gmail.com",
+		"email_verified": true,
+		"nonce":          "nonce-1",
+	}
+	if mutate != nil {
+		mutate(claims)
+	}
+
+	token := jwt.NewWithClaims(s.key.Method, claims)
+	token.Header["kid"] = s.key.KID
+	signed, err := token.SignedString(s.key.PrivateKey)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestGoogleOIDCProvider_VerifyIDToken(t *testing.T) {
+	keys := newGoogleKeyServer(t, "public, max-age=600")
+	provider := newGoogleOIDCProvider(resty.New(), keys.server.URL, "client-1")
+
+	claims, err := provider.VerifyIDToken(context.Background(), keys.sign(t, nil), "nonce-1")
+	require.NoError(t, err)
+	require.Equal(t, "google-sub", claims.Subject)
+	require.True(t, claims.EmailVerified)
+
+	// the legacy issuer without scheme is also valid
+	_, err = provider.VerifyIDToken(context.Background(), keys.sign(t, func(c jwt.MapClaims) {
+		c["iss"] = "accounts.google.com"
+	}), "nonce-1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		nonce  string
+	}{
+		{name: "nonce mismatch", nonce: "other-nonce"},
+		{name: "missing nonce", mutate: func(c jwt.MapClaims) { delete(c, "nonce") }, nonce: "nonce-1"},
+		{name: "no nonce expected", nonce: ""},
+		{name: "wrong audience", mutate: func(c jwt.MapClaims) { c["aud"] = "other-client" }, nonce: "nonce-1"},
+		{name: "wrong issuer", mutate: func(c jwt.MapClaims) { c["iss"] = "https://accounts.example.com" }, nonce: "nonce-1"},
+		{name: "expired", mutate: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, nonce: "nonce-1"},
+		{name: "missing iat", mutate: func(c jwt.MapClaims) { delete(c, "iat") }, nonce: "nonce-1"},
+		{name: "issued in the future", mutate: func(c jwt.MapClaims) { c["iat"] = time.Now().Add(time.Hour).Unix() }, nonce: "nonce-1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := provider.VerifyIDToken(context.Background(), keys.sign(t, test.mutate), test.nonce)
+			require.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+
+	require.Equal(t, int32(1), keys.hits.Load())
+}
+
+func TestGoogleOIDCProvider_VerifyIDToken_NoClientID(t *testing.T) {
+	keys := newGoogleKeyServer(t, "max-age=600")
+	provider := newGoogleOIDCProvider(resty.New(), keys.server.URL, "")
+
+	_, err := provider.VerifyIDToken(context.Background(), keys.sign(t, nil), "nonce-1")
+	require.ErrorIs(t, err, ErrInvalidIDToken)
+	require.Equal(t, int32(0), keys.hits.Load())
+}
+
+func TestGoogleOIDCProvider_KeysRefreshInBackgroundAfterMaxAge(t *testing.T) {
+	keys := newGoogleKeyServer(t, "public, max-age=600")
+	provider := newGoogleOIDCProvider(resty.New(), keys.server.URL, "client-1")
+
+	var clock atomic.Int64
+	clock.Store(time.Now().UnixNano())
+	provider.provider.keySet.now = func() time.Time { return time.Unix(0, clock.Load()) }
+
+	token := keys.sign(t, nil)
+	_, err := provider.VerifyIDToken(context.Background(), token, "nonce-1")
+	require.NoError(t, err)
+
+	clock.Add(int64(5 * time.Minute))
+	_, err = provider.VerifyIDToken(context.Background(), token, "nonce-1")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), keys.hits.Load())
+
+	// past max-age the cached key still verifies while a refresh runs behind it
+	clock.Add(int64(6 * time.Minute))
+	_, err = provider.VerifyIDToken(context.Background(), token, "nonce-1")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return keys.hits.Load() == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestKeyTTL(t *testing.T) {
+	tests := []struct {
+		cacheControl string
+		expected     time.Duration
+	}{
+		{cacheControl: "public, max-age=19800, must-revalidate, no-transform", expected: 19800 * time.Second},
+		{cacheControl: "", expected: defaultKeyTTL},
+		{cacheControl: "max-age=5", expected: minKeyRefreshInterval},
+		{cacheControl: "max-age=604800", expected: maxKeyTTL},
+		{cacheControl: "no-store", expected: minKeyRefreshInterval},
+		{cacheControl: "max-age=abc", expected: defaultKeyTTL},
+	}
+	for _, test := range tests {
+		header := http.Header{}
+		header.Set("Cache-Control", test.cacheControl)
+		require.Equal(t, test.expected, keyTTL(header), test.cacheControl)
+	}
+}
@@ -0,0 +1,219 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"golang.org/x/sync/singleflight"
+	"resty.dev/v3"
+)
+
+const (
+	// minKeyRefreshInterval limits how often an unknown kid or a failing
+	// provider may trigger a JWKS refetch.
+	minKeyRefreshInterval = time.Minute
+	// defaultKeyTTL applies when the JWKS response has no usable max-age.
+	defaultKeyTTL = time.Hour
+	maxKeyTTL     = 24 * time.Hour
+	// maxKeyStaleness bounds how long expired keys keep being served while
+	// background refreshes fail.
+	maxKeyStaleness = 24 * time.Hour
+)
+
+var ErrKeyNotFound = errors.New("signing key not found")
+
+// remoteKeySet caches the signing keys published at a jwks_uri for as long as
+// the response's Cache-Control max-age allows. Expired keys are refreshed in
+// the background while the cached ones keep serving, so key expiry never adds
+// latency to a login. Fetches never run under mu, and concurrent callers that
+// need one share a single request.
+type remoteKeySet struct {
+	client *resty.Client
+	url    string
+	now    func() time.Time
+	group  singleflight.Group
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	expiresAt   time.Time
+	lastAttempt time.Time
+	refreshing  bool
+}
+
+func newRemoteKeySet(client *resty.Client, url string) *remoteKeySet {
+	return &remoteKeySet{
+		client: client,
+		url:    url,
+		now:    time.Now,
+	}
+}
+
+// key returns the public key for kid, refetching the set when kid is unknown
+// (the provider may have rotated keys since the last fetch).
+func (s *remoteKeySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := s.cached(kid); ok {
+		return key, nil
+	}
+
+	// one caller's cancellation must not fail the others sharing the fetch
+	_, err, _ := s.group.Do("jwks", func() (any, error) {
+		return nil, s.refresh(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// cached looks kid up in a usable set, starting a background refresh when the
+// set has expired.
+func (s *remoteKeySet) cached(kid string) (crypto.PublicKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	usable := s.keys != nil && now.Before(s.expiresAt.Add(maxKeyStaleness))
+	if usable && !now.Before(s.expiresAt) && !s.refreshing && now.Sub(s.lastAttempt) >= minKeyRefreshInterval {
+		s.refreshing = true
+		s.lastAttempt = now
+		go s.backgroundRefresh()
+	}
+
+	if !usable {
+		return nil, false
+	}
+	return s.lookup(kid)
+}
+
+// refresh fetches the set unless a fetch was attempted within
+// minKeyRefreshInterval.
+func (s *remoteKeySet) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	now := s.now()
+	if now.Sub(s.lastAttempt) < minKeyRefreshInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.lastAttempt = now
+	s.mu.Unlock()
+
+	keys, ttl, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store(keys, ttl)
+	return nil
+}
+
+func (s *remoteKeySet) backgroundRefresh() {
+	keys, ttl, err := s.fetch(context.Background())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshing = false
+	if err != nil {
+		slog.Warn("failed to refresh jwks, serving cached keys",
+			slog.String("jwks_uri", s.url),
+			slog.String("error", err.Error()))
+		return
+	}
+	s.store(keys, ttl)
+}
+
+// lookup finds kid in the cached set. A token without kid is accepted only
+// when the set holds a single key. Callers must hold mu.
+func (s *remoteKeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// store replaces the cached keys. Callers must hold mu.
+func (s *remoteKeySet) store(keys map[string]crypto.PublicKey, ttl time.Duration) {
+	s.keys = keys
+	s.expiresAt = s.now().Add(ttl)
+}
+
+func (s *remoteKeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, time.Duration, error) {
+	resp, err := s.client.R().
+		SetContext(ctx).
+		Get(s.url)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.IsError() {
+		return nil, 0, fmt.Errorf("fetch jwks %s: unexpected status %d", s.url, resp.StatusCode())
+	}
+
+	// decode explicitly: providers do not always label the body as JSON
+	set := new(security.JSONWebKeySet)
+	if err := json.Unmarshal(resp.Bytes(), set); err != nil {
+		return nil, 0, fmt.Errorf("decode jwks %s: %w", s.url, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.PublicKey()
+		if err != nil {
+			slog.Warn("skipping unsupported jwk",
+				slog.String("jwks_uri", s.url),
+				slog.String("kid", jwk.Kid),
+				slog.String("error", err.Error()))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, keyTTL(resp.Header()), nil
+}
+
+// keyTTL derives the cache lifetime from the Cache-Control max-age, clamped
+// so a misconfigured provider can neither pin keys for days nor force a
+// fetch on every request.
+func keyTTL(header http.Header) time.Duration {
+	ttl := defaultKeyTTL
+	for directive := range strings.SplitSeq(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return minKeyRefreshInterval
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				ttl = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	return min(max(ttl, minKeyRefreshInterval), maxKeyTTL)
+}
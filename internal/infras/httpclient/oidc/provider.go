@@ -0,0 +1,246 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tdatIT/backend-go/config"
+	"golang.org/x/sync/singleflight"
+	"resty.dev/v3"
+)
+
+// clockSkew is the leeway applied to exp, iat and nbf.
+const clockSkew = time.Minute
+
+var (
+	ErrUnknownProvider = errors.New("unknown oidc provider")
+	ErrInvalidIDToken  = errors.New("invalid id token")
+	ErrInvalidIssuer   = errors.New("discovery document issuer mismatch")
+)
+
+// supportedAlgs are the asymmetric algorithms accepted for ID tokens. HMAC
+// and "none" are never accepted even if a provider advertises them.
+var supportedAlgs = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// IDTokenClaims are the verified claims of an OpenID Connect ID token.
+type IDTokenClaims struct {
+	Issuer        string
+	Subject       string
+	Audience      []string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+	Nonce         string
+	IssuedAt      time.Time
+}
+
+type discoveryDocument struct {
+	Issuer             string   `json:"issuer"`
+	JWKSURI            string   `json:"jwks_uri"`
+	IDTokenSigningAlgs []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Provider verifies ID tokens of one OpenID Connect issuer. The discovery
+// document is fetched on first use and cached for the provider's lifetime.
+type Provider struct {
+	issuer    string
+	issuers   []string // accepted iss values
+	audiences []string
+	client    *resty.Client
+
+	group  singleflight.Group // shares one discovery fetch between callers
+	mu     sync.Mutex
+	algs   []string
+	keySet *remoteKeySet
+}
+
+func NewProvider(cfg config.OIDCProvider, client *resty.Client) *Provider {
+	audiences := make([]string, 0, len(cfg.ClientIDs)+len(cfg.Audiences))
+	audiences = append(audiences, cfg.ClientIDs...)
+	audiences = append(audiences, cfg.Audiences...)
+
+	return &Provider{
+		issuer:    cfg.Issuer,
+		issuers:   []string{cfg.Issuer},
+		audiences: audiences,
+		client:    client,
+	}
+}
+
+// newStaticProvider builds a provider with a known jwks_uri, skipping discovery.
+func newStaticProvider(issuers []string, audiences []string, jwksURL string, algs []string, client *resty.Client) *Provider {
+	return &Provider{
+		issuer:    issuers[0],
+		issuers:   issuers,
+		audiences: audiences,
+		client:    client,
+		algs:      algs,
+		keySet:    newRemoteKeySet(client, jwksURL),
+	}
+}
+
+// VerifyIDToken checks the signature against the provider's JWKS and
+// validates iss, aud, azp, exp, iat and nonce. The token must carry nonce;
+// an empty nonce is rejected rather than skipping the check.
+func (p *Provider) VerifyIDToken(ctx context.Context, idToken string, nonce string) (*IDTokenClaims, error) {
+	// with no audience the parser would accept tokens minted for any client
+	if len(p.audiences) == 0 {
+		return nil, fmt.Errorf("%w: no audience configured", ErrInvalidIDToken)
+	}
+
+	algs, keySet, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := new(idTokenClaims)
+	_, err = jwt.ParseWithClaims(idToken, claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return keySet.key(ctx, kid)
+		},
+		jwt.WithValidMethods(algs),
+		jwt.WithAudience(p.audiences...),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	if !slices.Contains(p.issuers, claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected iss", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" || claims.IssuedAt == nil {
+		return nil, fmt.Errorf("%w: missing sub or iat", ErrInvalidIDToken)
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	// azp names the client the token was issued to; it must be one of ours
+	if claims.AuthorizedParty != "" && !slices.Contains(p.audiences, claims.AuthorizedParty) {
+		return nil, fmt.Errorf("%w: unexpected azp", ErrInvalidIDToken)
+	}
+
+	return &IDTokenClaims{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Audience:      claims.Audience,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+		Nonce:         claims.Nonce,
+		IssuedAt:      claims.IssuedAt.Time,
+	}, nil
+}
+
+// discover returns the signing algorithms and key set of the provider,
+// fetching the discovery document outside mu on first use.
+func (p *Provider) discover(ctx context.Context) ([]string, *remoteKeySet, error) {
+	if algs, keySet := p.discovered(); keySet != nil {
+		return algs, keySet, nil
+	}
+
+	// one caller's cancellation must not fail the others sharing the fetch
+	_, err, _ := p.group.Do("discovery", func() (any, error) {
+		if _, keySet := p.discovered(); keySet != nil {
+			return nil, nil
+		}
+		return nil, p.fetchDiscovery(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	algs, keySet := p.discovered()
+	return algs, keySet, nil
+}
+
+func (p *Provider) discovered() ([]string, *remoteKeySet) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.algs, p.keySet
+}
+
+func (p *Provider) fetchDiscovery(ctx context.Context) error {
+	url := strings.TrimSuffix(p.issuer, "/") + "/.well-known/openid-configuration"
+	resp, err := p.client.R().
+		SetContext(ctx).
+		Get(url)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("fetch %s: unexpected status %d", url, resp.StatusCode())
+	}
+
+	doc := new(discoveryDocument)
+	if err := json.Unmarshal(resp.Bytes(), doc); err != nil {
+		return fmt.Errorf("decode %s: %w", url, err)
+	}
+	if doc.Issuer != p.issuer {
+		return fmt.Errorf("%w: got %q, want %q", ErrInvalidIssuer, doc.Issuer, p.issuer)
+	}
+	if doc.JWKSURI == "" {
+		return fmt.Errorf("discovery document for %s has no jwks_uri", p.issuer)
+	}
+
+	algs := []string{"RS256"} // required by OpenID Connect Core when unspecified
+	if len(doc.IDTokenSigningAlgs) > 0 {
+		algs = slices.DeleteFunc(slices.Clone(doc.IDTokenSigningAlgs), func(alg string) bool {
+			return !slices.Contains(supportedAlgs, alg)
+		})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.algs = algs
+	p.keySet = newRemoteKeySet(p.client, doc.JWKSURI)
+	return nil
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Email           string       `json:"email"`
+	EmailVerified   flexibleBool `json:"email_verified"`
+	Name            string       `json:"name"`
+	GivenName       string       `json:"given_name"`
+	FamilyName      string       `json:"family_name"`
+	Nonce           string       `json:"nonce"`
+	AuthorizedParty string       `json:"azp"`
+}
+
+// flexibleBool accepts both JSON booleans and the "true"/"false" strings
+// some providers emit for email_verified.
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		*b = flexibleBool(v)
+	case string:
+		*b = flexibleBool(strings.EqualFold(v, "true"))
+	default:
+		*b = false
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"resty.dev/v3"
+)
+
+// fakeIssuer is a local OpenID provider serving discovery and JWKS documents.
+type fakeIssuer struct {
+	server     *httptest.Server
+	key        *security.SigningKey
+	jwksHits   atomic.Int32
+	discovered atomic.Int32
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	key, err := security.NewSigningKey("key-1", "ES256", private, time.Time{})
+	require.NoError(t, err)
+
+	ring, err := security.NewKeyRingFromKeys([]*security.SigningKey{key}, 0)
+	require.NoError(t, err)
+
+	f := &fakeIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		f.discovered.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                f.server.URL,
+			"jwks_uri":                              f.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"ES256", "HS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		f.jwksHits.Add(1)
+		_ = json.NewEncoder(w).Encode(ring.JWKS())
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeIssuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(f.key.Method, claims)
+	token.Header["kid"] = f.key.KID
+	signed, err := token.SignedString(f.key.PrivateKey)
+	require.NoError(t, err)
+	return signed
+}
+
+func (f *fakeIssuer) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            f.server.URL,
+		"sub":            "subject-1",
+		"aud":            "client-1",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          "user@example.com",
+		"email_verified": "true",
+		"given_name":     "Ada",
+		"nonce":          "nonce-1",
+	}
+}
+
+func newTestProviders(issuer *fakeIssuer) *Providers {
+	return &Providers{providers: map[string]*Provider{
+		"fake": NewProvider(config.OIDCProvider{
+			Name:      "fake",
+			Issuer:    issuer.server.URL,
+			ClientIDs: []string{"client-1"},
+		}, resty.New()),
+	}}
+}
+
+func TestProviders_VerifyIDToken_Success(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	providers := newTestProviders(issuer)
+
+	claims, err := providers.VerifyIDToken(context.Background(), "fake", issuer.sign(t, issuer.claims()), "nonce-1")
+	require.NoError(t, err)
+	require.Equal(t, "subject-1", claims.Subject)
+	require.Equal(t, "user@example.com", claims.Email)
+	require.True(t, claims.EmailVerified)
+	require.Equal(t, "Ada", claims.GivenName)
+
+	// discovery and keys are cached across verifications
+	_, err = providers.VerifyIDToken(context.Background(), "fake", issuer.sign(t, issuer.claims()), "nonce-1")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), issuer.discovered.Load())
+	require.Equal(t, int32(1), issuer.jwksHits.Load())
+}
+
+func TestProviders_VerifyIDToken_RequiresNonce(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	// an empty nonce must not turn the check off
+	_, err := newTestProviders(issuer).VerifyIDToken(context.Background(), "fake", issuer.sign(t, issuer.claims()), "")
+	require.ErrorIs(t, err, ErrInvalidIDToken)
+}
+
+func TestProviders_VerifyIDToken_Rejects(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+	}{
+		{name: "wrong audience", mutate: func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{name: "wrong issuer", mutate: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{name: "expired", mutate: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "missing exp", mutate: func(c jwt.MapClaims) { delete(c, "exp") }},
+		{name: "issued in the future", mutate: func(c jwt.MapClaims) { c["iat"] = time.Now().Add(time.Hour).Unix() }},
+		{name: "foreign azp", mutate: func(c jwt.MapClaims) { c["azp"] = "other-client" }},
+		{name: "missing subject", mutate: func(c jwt.MapClaims) { delete(c, "sub") }},
+		{name: "missing nonce", mutate: func(c jwt.MapClaims) { delete(c, "nonce") }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := issuer.claims()
+			test.mutate(claims)
+
+			_, err := newTestProviders(issuer).VerifyIDToken(context.Background(), "fake", issuer.sign(t, claims), "nonce-1")
+			require.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestProviders_VerifyIDToken_RejectsHMAC(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	// HS256 is advertised by the fake issuer but must never be accepted
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, issuer.claims())
+	token.Header["kid"] = issuer.key.KID
+	signed, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = newTestProviders(issuer).VerifyIDToken(context.Background(), "fake", signed, "nonce-1")
+	require.ErrorIs(t, err, ErrInvalidIDToken)
+}
+
+func TestProviders_VerifyIDToken_UnknownKidRefetchIsLimited(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	providers := newTestProviders(issuer)
+
+	token := jwt.NewWithClaims(issuer.key.Method, issuer.claims())
+	token.Header["kid"] = "unknown"
+	signed, err := token.SignedString(issuer.key.PrivateKey)
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err = providers.VerifyIDToken(context.Background(), "fake", signed, "nonce-1")
+		require.ErrorIs(t, err, ErrInvalidIDToken)
+	}
+	require.Equal(t, int32(1), issuer.jwksHits.Load())
+}
+
+func TestProviders_VerifyIDToken_ColdCacheSharesOneFetch(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	providers := newTestProviders(issuer)
+	signed := issuer.sign(t, issuer.claims())
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Go(func() {
+			_, errs[i] = providers.VerifyIDToken(context.Background(), "fake", signed, "nonce-1")
+		})
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), issuer.discovered.Load())
+	require.Equal(t, int32(1), issuer.jwksHits.Load())
+}
+
+func TestProviders_VerifyIDToken_UnknownProvider(t *testing.T) {
+	_, err := (&Providers{}).VerifyIDToken(context.Background(), "missing", "token", "")
+	require.ErrorIs(t, err, ErrUnknownProvider)
+}
+
+func TestProvider_Discover_IssuerMismatch(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	provider := NewProvider(config.OIDCProvider{
+		Name:      "fake",
+		Issuer:    issuer.server.URL + "/",
+		ClientIDs: []string{"client-1"},
+	}, resty.New())
+
+	_, err := provider.VerifyIDToken(context.Background(), issuer.sign(t, issuer.claims()), "nonce-1")
+	require.ErrorIs(t, err, ErrInvalidIssuer)
+}
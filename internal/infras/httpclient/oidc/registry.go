@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/config"
+)
+
+// IDTokenVerifier verifies ID tokens issued by one of the configured providers.
+// The token must carry nonce.
+type IDTokenVerifier interface {
+	VerifyIDToken(ctx context.Context, provider string, idToken string, nonce string) (*IDTokenClaims, error)
+}
+
+// Providers is the set of OpenID Connect providers from config.Auth.OIDCProviders.
+// Google is included under GoogleProviderName when Auth.GoogleClientID is set
+// and no configured provider already uses that name.
+type Providers struct {
+	providers map[string]*Provider
+}
+
+func NewProviders(config *config.ServiceConfig) *Providers {
+	client := newHTTPClient(config)
+	providers := make(map[string]*Provider, len(config.Auth.OIDCProviders))
+	for _, cfg := range config.Auth.OIDCProviders {
+		// without an audience any token from the issuer would be accepted
+		if cfg.Name == "" || cfg.Issuer == "" || len(cfg.ClientIDs)+len(cfg.Audiences) == 0 {
+			slog.Error("skipping oidc provider with incomplete config",
+				slog.String("name", cfg.Name),
+				slog.String("issuer", cfg.Issuer))
+			continue
+		}
+		providers[cfg.Name] = NewProvider(cfg, client)
+	}
+
+	if _, ok := providers[GoogleProviderName]; !ok && config.Auth.GoogleClientID != "" {
+		providers[GoogleProviderName] = newGoogleOIDCProvider(client, googleJWKSURL, config.Auth.GoogleClientID).provider
+	}
+
+	return &Providers{providers: providers}
+}
+
+func (p *Providers) VerifyIDToken(ctx context.Context, provider string, idToken string, nonce string) (*IDTokenClaims, error) {
+	item, ok := p.providers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	return item.VerifyIDToken(ctx, idToken, nonce)
+}
@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tdatIT/backend-go/pkgs/utils/genid"
+)
+
+// FileMailer stores each message as an .eml file in dir; intended for local runs.
+type FileMailer struct {
+	from string
+	dir  string
+}
+
+func NewFileMailer(from string, dir string) *FileMailer {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	return &FileMailer{from: from, dir: dir}
+}
+
+func (m *FileMailer) Send(_ context.Context, msg *Message) error {
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405"), genid.GenerateNanoID())
+	return os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, msg, now), 0o600)
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional emails.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewMailer returns the mailer selected by Mail.Driver: smtp, file or log (default).
+func NewMailer(cfg *config.ServiceConfig) Mailer {
+	switch cfg.Mail.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.Mail)
+	case "file":
+		return NewFileMailer(cfg.Mail.From, cfg.Mail.FileDir)
+	default:
+		return NewLogMailer(cfg.Mail.From)
+	}
+}
+
+// LogMailer writes messages to the application log; intended for local runs.
+type LogMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(_ context.Context, msg *Message) error {
+	slog.Info("mail sent",
+		slog.String("from", m.from),
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body))
+	return nil
+}
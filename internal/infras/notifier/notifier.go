@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/config"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+)
+
+// Notification is a message for a single user.
+type Notification struct {
+	UserID  uint64
+	Email   string
+	Subject string
+	Body    string
+}
+
+// Notifier tells users about activity on their account.
+type Notifier interface {
+	Notify(ctx context.Context, msg *Notification) error
+}
+
+// NewNotifier returns the notifier selected by Notifier.Driver: mail or log (default).
+func NewNotifier(cfg *config.ServiceConfig, mail mailer.Mailer) Notifier {
+	switch cfg.Notifier.Driver {
+	case "mail":
+		return NewMailNotifier(mail)
+	default:
+		return NewLogNotifier()
+	}
+}
+
+// LogNotifier writes notifications to the application log; a stand-in until
+// push or in-app channels exist.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, msg *Notification) error {
+	slog.Info("notification sent",
+		slog.Uint64("user_id", msg.UserID),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body))
+	return nil
+}
+
+// MailNotifier emails notifications to the user's address.
+type MailNotifier struct {
+	mailer mailer.Mailer
+}
+
+func NewMailNotifier(mail mailer.Mailer) *MailNotifier {
+	return &MailNotifier{mailer: mail}
+}
+
+func (n *MailNotifier) Notify(ctx context.Context, msg *Notification) error {
+	return n.mailer.Send(ctx, &mailer.Message{
+		To:      msg.Email,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+	})
+}
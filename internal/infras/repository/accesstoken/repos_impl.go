@@ -0,0 +1,75 @@
+package accesstoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"gorm.io/gorm"
+)
+
+type reposImpl struct {
+	orm orm.ORM
+}
+
+func NewRepository(orm orm.ORM) Repository {
+	return &reposImpl{
+		orm: orm,
+	}
+}
+
+func (r reposImpl) Create(ctx context.Context, item *models.PersonalAccessToken) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(item).Error
+	})
+}
+
+func (r reposImpl) FindByUserID(ctx context.Context, userID uint64) ([]*models.PersonalAccessToken, error) {
+	var items []*models.PersonalAccessToken
+	err := r.orm.GormDB().WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r reposImpl) FindActiveByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	item := new(models.PersonalAccessToken)
+	err := r.orm.GormDB().WithContext(ctx).
+		Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(item).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Model(&models.PersonalAccessToken{}).
+			Where("id = ?", id).
+			Update("last_used_at", usedAt).Error
+	})
+}
+
+func (r reposImpl) Revoke(ctx context.Context, userID uint64, id uint64) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PersonalAccessToken{}).
+			Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+			Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
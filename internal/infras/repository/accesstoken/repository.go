@@ -0,0 +1,22 @@
+package accesstoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// Repository defines persistence operations for PersonalAccessToken models.
+type Repository interface {
+	Create(ctx context.Context, item *models.PersonalAccessToken) error
+	// FindByUserID returns the user's tokens that have not been revoked,
+	// newest first. Expired tokens are included.
+	FindByUserID(ctx context.Context, userID uint64) ([]*models.PersonalAccessToken, error)
+	// FindActiveByHash returns gorm.ErrRecordNotFound for unknown, revoked or
+	// expired tokens.
+	FindActiveByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error)
+	TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error
+	// Revoke returns gorm.ErrRecordNotFound when the user has no such active token.
+	Revoke(ctx context.Context, userID uint64, id uint64) error
+}
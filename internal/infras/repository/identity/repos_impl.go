@@ -0,0 +1,73 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reposImpl struct {
+	orm orm.ORM
+}
+
+func NewRepository(orm orm.ORM) Repository {
+	return &reposImpl{
+		orm: orm,
+	}
+}
+
+func (r reposImpl) Create(ctx context.Context, item *models.UserIdentity) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(item)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrDuplicatedKey
+		}
+
+		return nil
+	})
+}
+
+func (r reposImpl) FindByUserID(ctx context.Context, userID uint64) ([]*models.UserIdentity, error) {
+	var items []*models.UserIdentity
+	err := r.orm.GormDB().
+		WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r reposImpl) DeleteByID(ctx context.Context, userID uint64, id uint64) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// lock the user so concurrent unlinks cannot both pass the check below
+		account := new(models.User)
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, userID).Error; err != nil {
+			return err
+		}
+
+		item := new(models.UserIdentity)
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(item).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.UserIdentity{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count <= 1 && !account.HasPassword() {
+			return ErrLastLoginMethod
+		}
+
+		return tx.Delete(item).Error
+	})
+}
@@ -0,0 +1,24 @@
+package identity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// ErrLastLoginMethod is returned when removing an identity would leave the
+// user with no way to sign in.
+var ErrLastLoginMethod = errors.New("cannot remove the last login method")
+
+// Repository defines persistence operations for UserIdentity models.
+type Repository interface {
+	// Create links an identity. It returns gorm.ErrDuplicatedKey when the
+	// (provider, subject) pair is already linked.
+	Create(ctx context.Context, item *models.UserIdentity) error
+	FindByUserID(ctx context.Context, userID uint64) ([]*models.UserIdentity, error)
+	// DeleteByID removes one of the user's identities unless it is the
+	// user's only remaining login method, in which case ErrLastLoginMethod
+	// is returned. It returns gorm.ErrRecordNotFound for an unknown id.
+	DeleteByID(ctx context.Context, userID uint64, id uint64) error
+}
@@ -0,0 +1,97 @@
+package mfa
+
+import (
+	"context"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"gorm.io/gorm"
+)
+
+type reposImpl struct {
+	orm orm.ORM
+}
+
+func NewRepository(orm orm.ORM) Repository {
+	return &reposImpl{
+		orm: orm,
+	}
+}
+
+func (r reposImpl) FindByUserID(ctx context.Context, userID uint64) (*models.UserMFA, error) {
+	item := new(models.UserMFA)
+	if err := r.orm.GormDB().WithContext(ctx).First(item, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) Save(ctx context.Context, item *models.UserMFA) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Save(item).Error
+	})
+}
+
+func (r reposImpl) Enable(ctx context.Context, userID uint64, step int64, codeHashes []string) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.UserMFA{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]any{
+				"enabled":        true,
+				"last_used_step": step,
+				"confirmed_at":   time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]*models.MFARecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, &models.MFARecoveryCode{UserID: userID, CodeHash: hash})
+		}
+
+		return tx.Create(codes).Error
+	})
+}
+
+func (r reposImpl) MarkStepUsed(ctx context.Context, userID uint64, step int64) (bool, error) {
+	result := r.orm.GormDB().WithContext(ctx).
+		Model(&models.UserMFA{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r reposImpl) ConsumeRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	result := r.orm.GormDB().WithContext(ctx).
+		Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r reposImpl) Delete(ctx context.Context, userID uint64) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&models.UserMFA{}).Error
+	})
+}
@@ -0,0 +1,24 @@
+package mfa
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// Repository defines persistence operations for TOTP enrollments and recovery codes.
+type Repository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*models.UserMFA, error)
+	// Save creates or replaces the user's pending enrollment.
+	Save(ctx context.Context, item *models.UserMFA) error
+	// Enable activates the enrollment and replaces the user's recovery codes.
+	Enable(ctx context.Context, userID uint64, step int64, codeHashes []string) error
+	// MarkStepUsed records step as the last accepted TOTP step. It returns
+	// false when step is not newer than the stored one, i.e. a replayed code.
+	MarkStepUsed(ctx context.Context, userID uint64, step int64) (bool, error)
+	// ConsumeRecoveryCode marks an unused recovery code as used. It returns
+	// gorm.ErrRecordNotFound when no such code exists.
+	ConsumeRecoveryCode(ctx context.Context, userID uint64, codeHash string) error
+	// Delete removes the enrollment and all recovery codes.
+	Delete(ctx context.Context, userID uint64) error
+}
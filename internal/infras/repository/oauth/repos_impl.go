@@ -0,0 +1,121 @@
+package oauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reposImpl struct {
+	orm orm.ORM
+}
+
+func NewRepository(orm orm.ORM) Repository {
+	return &reposImpl{
+		orm: orm,
+	}
+}
+
+func (r reposImpl) SeedClients(ctx context.Context, items []*models.OAuthClient) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "secret_hash", "redirect_uris", "scopes", "first_party", "updated_at"}),
+		}).Create(items).Error
+	})
+}
+
+func (r reposImpl) FindClientByID(ctx context.Context, id string) (*models.OAuthClient, error) {
+	item := new(models.OAuthClient)
+	err := r.orm.GormDB().WithContext(ctx).
+		Where("id = ?", id).
+		First(item).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) FindConsent(ctx context.Context, userID uint64, clientID string) (*models.OAuthConsent, error) {
+	item := new(models.OAuthConsent)
+	err := r.orm.GormDB().WithContext(ctx).
+		Where("user_id = ? AND client_id = ?", userID, clientID).
+		First(item).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) SaveConsent(ctx context.Context, item *models.OAuthConsent) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"scopes", "updated_at"}),
+		}).Create(item).Error
+	})
+}
+
+func (r reposImpl) FindConsentsByUserID(ctx context.Context, userID uint64) ([]*models.OAuthConsent, error) {
+	var items []*models.OAuthConsent
+	err := r.orm.GormDB().WithContext(ctx).
+		Preload("Client").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r reposImpl) DeleteConsent(ctx context.Context, userID uint64, clientID string) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND client_id = ?", userID, clientID).Delete(&models.OAuthConsent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r reposImpl) CreateCode(ctx context.Context, item *models.OAuthAuthorizationCode) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(item).Error
+	})
+}
+
+func (r reposImpl) ConsumeCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error) {
+	item := new(models.OAuthAuthorizationCode)
+	err := r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code_hash = ? AND used_at IS NULL AND expires_at > ?", codeHash, now).
+			First(item).Error; err != nil {
+			return err
+		}
+
+		item.UsedAt = &now
+		return tx.Model(item).Update("used_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
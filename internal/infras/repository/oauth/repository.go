@@ -0,0 +1,29 @@
+package oauth
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// Repository defines persistence operations for OAuth clients, consents and
+// authorization codes.
+type Repository interface {
+	// SeedClients creates or updates the given clients. Clients not in items
+	// are untouched.
+	SeedClients(ctx context.Context, items []*models.OAuthClient) error
+	FindClientByID(ctx context.Context, id string) (*models.OAuthClient, error)
+
+	FindConsent(ctx context.Context, userID uint64, clientID string) (*models.OAuthConsent, error)
+	// SaveConsent creates the consent or replaces its scopes.
+	SaveConsent(ctx context.Context, item *models.OAuthConsent) error
+	// FindConsentsByUserID returns the user's consents with their clients.
+	FindConsentsByUserID(ctx context.Context, userID uint64) ([]*models.OAuthConsent, error)
+	// DeleteConsent returns gorm.ErrRecordNotFound when there is no consent.
+	DeleteConsent(ctx context.Context, userID uint64, clientID string) error
+
+	CreateCode(ctx context.Context, item *models.OAuthAuthorizationCode) error
+	// ConsumeCode marks an unused, unexpired code as used and returns it.
+	// It returns gorm.ErrRecordNotFound when no such code exists.
+	ConsumeCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error)
+}
@@ -0,0 +1,116 @@
+package role
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reposImpl struct {
+	orm orm.ORM
+}
+
+func NewRepository(orm orm.ORM) Repository {
+	return &reposImpl{
+		orm: orm,
+	}
+}
+
+func (r reposImpl) Seed(ctx context.Context, seeds []*models.Role) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, seed := range seeds {
+			permissions := make([]*models.Permission, 0, len(seed.Permissions))
+			for _, p := range seed.Permissions {
+				item := &models.Permission{Name: p.Name}
+				if err := tx.Where(models.Permission{Name: p.Name}).
+					Attrs(models.Permission{Description: p.Description}).
+					FirstOrCreate(item).Error; err != nil {
+					return err
+				}
+				permissions = append(permissions, item)
+			}
+
+			item := &models.Role{Name: seed.Name}
+			if err := tx.Where(models.Role{Name: seed.Name}).
+				Assign(models.Role{Description: seed.Description}).
+				FirstOrCreate(item).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(item).Association("Permissions").Replace(permissions); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r reposImpl) FindAll(ctx context.Context) ([]*models.Role, error) {
+	var items []*models.Role
+	err := r.orm.GormDB().WithContext(ctx).
+		Preload("Permissions").
+		Order("name ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r reposImpl) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	item := new(models.Role)
+	err := r.orm.GormDB().WithContext(ctx).
+		Preload("Permissions").
+		Where("name = ?", name).
+		First(item).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) FindByUserID(ctx context.Context, userID uint64) ([]*models.Role, error) {
+	var items []*models.Role
+	err := r.orm.GormDB().WithContext(ctx).
+		Preload("Permissions").
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r reposImpl) Assign(ctx context.Context, item *models.UserRole) (bool, error) {
+	var created bool
+	err := r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(item)
+		created = result.RowsAffected > 0
+		return result.Error
+	})
+
+	return created, err
+}
+
+func (r reposImpl) Unassign(ctx context.Context, userID uint64, roleID uint64) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&models.UserRole{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
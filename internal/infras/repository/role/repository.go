@@ -0,0 +1,23 @@
+package role
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// Repository defines persistence operations for roles, permissions and
+// user-role assignments.
+type Repository interface {
+	// Seed creates missing roles and permissions and sets each seeded role's
+	// permissions to exactly the given set. Roles not in seeds are untouched.
+	Seed(ctx context.Context, seeds []*models.Role) error
+	FindAll(ctx context.Context) ([]*models.Role, error)
+	FindByName(ctx context.Context, name string) (*models.Role, error)
+	// FindByUserID returns the user's roles with their permissions.
+	FindByUserID(ctx context.Context, userID uint64) ([]*models.Role, error)
+	// Assign reports whether the role was newly assigned.
+	Assign(ctx context.Context, item *models.UserRole) (bool, error)
+	// Unassign returns gorm.ErrRecordNotFound when the user does not have the role.
+	Unassign(ctx context.Context, userID uint64, roleID uint64) error
+}
@@ -0,0 +1,12 @@
+package securityevent
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// Repository defines persistence operations for SecurityEvent models.
+type Repository interface {
+	Create(ctx context.Context, item *models.SecurityEvent) error
+}
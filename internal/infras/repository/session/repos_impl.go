@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/bytedance/sonic"
@@ -11,6 +12,7 @@ import (
 	"github.com/tdatIT/backend-go/pkgs/db/orm"
 	"github.com/tdatIT/backend-go/pkgs/utils/genid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type reposImpl struct {
@@ -48,7 +50,7 @@ func (r reposImpl) FindByID(ctx context.Context, id string) (*models.Session, er
 	}
 
 	item := new(models.Session)
-	err := r.orm.GormDB().WithContext(ctx).First(item, id).Error
+	err := r.orm.GormDB().WithContext(ctx).First(item, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +78,7 @@ func (r reposImpl) FindBySessionID(ctx context.Context, sessionID string) (*mode
 	}
 
 	item := new(models.Session)
-	err := r.orm.GormDB().WithContext(ctx).First(item, sessionID).Error
+	err := r.orm.GormDB().WithContext(ctx).First(item, "id = ?", sessionID).Error
 	if err != nil {
 		return nil, err
 	}
@@ -96,20 +98,46 @@ func (r reposImpl) Update(ctx context.Context, item *models.Session) error {
 	return r.setCache(ctx, item)
 }
 
+// RotateRefreshJTI checks and swaps the JTI in one conditional update, so of
+// two concurrent refreshes with the same token only one wins. The other gets
+// gorm.ErrDuplicatedKey because its JTI has just been rotated.
 func (r reposImpl) RotateRefreshJTI(ctx context.Context, id string, oldJTI string, newJTI string) error {
 	item := new(models.Session)
 	if err := r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("id = ?", id).First(item).Error; err != nil {
-			return err
+		result := tx.Model(&models.Session{}).
+			Where("id = ? AND refresh_jti = ?", id, oldJTI).
+			Updates(map[string]any{
+				"refresh_jti":  newJTI,
+				"last_used_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
 		}
-
-		if item.RefreshJTI != oldJTI {
+		if result.RowsAffected == 0 {
+			rotated, err := isRotated(tx, id, oldJTI)
+			if err != nil {
+				return err
+			}
+			if rotated {
+				return gorm.ErrDuplicatedKey
+			}
 			return gorm.ErrRecordNotFound
 		}
 
-		item.RefreshJTI = newJTI
-		item.LastUsedAt = new(time.Now())
-		return tx.Save(item).Error
+		// Keep the old JTI so a replay of it can be detected later
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.RotatedRefreshToken{
+			SessionID: id,
+			JTI:       oldJTI,
+			RotatedAt: time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrDuplicatedKey
+		}
+
+		return tx.First(item, "id = ?", id).Error
 	}); err != nil {
 		return err
 	}
@@ -117,10 +145,37 @@ func (r reposImpl) RotateRefreshJTI(ctx context.Context, id string, oldJTI strin
 	return r.setCache(ctx, item)
 }
 
+func (r reposImpl) IsRotatedRefreshJTI(ctx context.Context, id string, jti string) (bool, error) {
+	return isRotated(r.orm.GormDB().WithContext(ctx), id, jti)
+}
+
+// PruneRotatedRefreshJTIs deletes rotated JTIs that can no longer be
+// replayed: those of inactive sessions, and those rotated longer than the
+// refresh token lifetime ago, whose tokens have expired.
+func (r reposImpl) PruneRotatedRefreshJTIs(ctx context.Context) (int64, error) {
+	result := r.orm.GormDB().WithContext(ctx).
+		Where("rotated_at < ? OR session_id IN (?)", time.Now().Add(-r.sessionTTL),
+			r.orm.GormDB().Model(&models.Session{}).Select("id").Where("is_active = ?", false)).
+		Delete(&models.RotatedRefreshToken{})
+	return result.RowsAffected, result.Error
+}
+
+func isRotated(db *gorm.DB, id string, jti string) (bool, error) {
+	var count int64
+	err := db.Model(&models.RotatedRefreshToken{}).
+		Where("session_id = ? AND jti = ?", id, jti).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 func (r reposImpl) Deactivate(ctx context.Context, id string) error {
 	item := new(models.Session)
 	if err := r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.First(item, id).Error; err != nil {
+		if err := tx.First(item, "id = ?", id).Error; err != nil {
 			return err
 		}
 		item.IsActive = false
@@ -134,6 +189,86 @@ func (r reposImpl) Deactivate(ctx context.Context, id string) error {
 	return r.setCache(ctx, item)
 }
 
+func (r reposImpl) FindActiveByUserID(ctx context.Context, userID uint64) ([]*models.Session, error) {
+	var items []*models.Session
+	err := r.orm.GormDB().WithContext(ctx).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Order("last_used_at DESC NULLS LAST").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r reposImpl) FindDevicesByUserID(ctx context.Context, userID uint64, exceptID string) ([]*models.Session, error) {
+	var items []*models.Session
+	err := r.orm.GormDB().WithContext(ctx).
+		Model(&models.Session{}).
+		Distinct("browser", "os", "device_type", "country").
+		Where("user_id = ? AND id <> ? AND COALESCE(client_id, '') = ''", userID, exceptID).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// DeactivateAllByUserID deactivates every active session of the user except
+// exceptID (if set) and evicts them from cache. It returns the number of
+// sessions deactivated.
+func (r reposImpl) DeactivateAllByUserID(ctx context.Context, userID uint64, exceptID string) (int64, error) {
+	return r.deactivateWhere(ctx, func(query *gorm.DB) *gorm.DB {
+		query = query.Where("user_id = ?", userID)
+		if exceptID != "" {
+			query = query.Where("id <> ?", exceptID)
+		}
+		return query
+	})
+}
+
+func (r reposImpl) DeactivateByClient(ctx context.Context, userID uint64, clientID string) (int64, error) {
+	return r.deactivateWhere(ctx, func(query *gorm.DB) *gorm.DB {
+		return query.Where("user_id = ? AND client_id = ?", userID, clientID)
+	})
+}
+
+// deactivateWhere deactivates the active sessions matched by filter and
+// evicts them from cache.
+func (r reposImpl) deactivateWhere(ctx context.Context, filter func(query *gorm.DB) *gorm.DB) (int64, error) {
+	var ids []string
+	if err := r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := filter(tx.Model(&models.Session{}).Where("is_active = ?", true))
+		if err := query.Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		return tx.Model(&models.Session{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"is_active":    false,
+				"last_used_at": time.Now(),
+			}).Error
+	}); err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := r.deleteCache(ctx, r.cacheKeyByID(id)); err != nil {
+			slog.Warn("failed to evict session cache",
+				slog.String("sess_id", id),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return int64(len(ids)), nil
+}
+
 func (r reposImpl) cacheKeyByID(id string) string {
 	return fmt.Sprintf("session:id:%s", id)
 }
@@ -156,6 +291,14 @@ func (r reposImpl) getCache(ctx context.Context, key string) (*models.Session, e
 	return item, nil
 }
 
+func (r reposImpl) deleteCache(ctx context.Context, key string) error {
+	if r.cache == nil {
+		return nil
+	}
+
+	return r.cache.Delete(ctx, key)
+}
+
 func (r reposImpl) setCache(ctx context.Context, item *models.Session) error {
 	data, err := sonic.Marshal(item)
 	if err != nil {
@@ -13,6 +13,21 @@ type Repository interface {
 	FindByRefreshJTI(ctx context.Context, jti string) (*models.Session, error)
 	FindBySessionID(ctx context.Context, sessionID string) (*models.Session, error)
 	Update(ctx context.Context, item *models.Session) error
+	// RotateRefreshJTI replaces oldJTI with newJTI. It returns
+	// gorm.ErrDuplicatedKey when oldJTI has already been rotated and
+	// gorm.ErrRecordNotFound when it was never the session's JTI.
 	RotateRefreshJTI(ctx context.Context, id string, oldJTI string, newJTI string) error
+	IsRotatedRefreshJTI(ctx context.Context, id string, jti string) (bool, error)
+	// PruneRotatedRefreshJTIs deletes the rotated JTIs that can no longer be
+	// replayed and returns how many were deleted.
+	PruneRotatedRefreshJTIs(ctx context.Context) (int64, error)
 	Deactivate(ctx context.Context, id string) error
+	FindActiveByUserID(ctx context.Context, userID uint64) ([]*models.Session, error)
+	DeactivateAllByUserID(ctx context.Context, userID uint64, exceptID string) (int64, error)
+	// FindDevicesByUserID returns the distinct browser, OS, device type and
+	// country of the user's sessions, active or not, other than exceptID.
+	// Sessions opened by OAuth clients are left out.
+	FindDevicesByUserID(ctx context.Context, userID uint64, exceptID string) ([]*models.Session, error)
+	// DeactivateByClient deactivates the user's sessions opened by an OAuth client.
+	DeactivateByClient(ctx context.Context, userID uint64, clientID string) (int64, error)
 }
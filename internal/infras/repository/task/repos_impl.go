@@ -2,12 +2,36 @@ package task
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/tdatIT/backend-go/internal/domain/models"
 	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// rankStep is the gap left between neighbouring tasks, so a task can usually
+// be placed between two others by rewriting that task alone. When a gap runs
+// out the group is spread out again.
+const rankStep = 1 << 16
+
+var listSpec = pageable.Spec{
+	SortFields: map[string]string{
+		"order":       `"order"`,
+		"title":       "title",
+		"priority":    "priority",
+		"dueAt":       "due_at",
+		"createdAt":   "created_at",
+		"updatedAt":   "updated_at",
+		"completedAt": "completed_at",
+	},
+	DefaultSort:   `"order" asc, id asc`,
+	SearchColumns: []string{"title", "description"},
+	DateColumn:    "due_at",
+}
+
 type reposImpl struct {
 	orm orm.ORM
 }
@@ -20,6 +44,16 @@ func NewRepository(orm orm.ORM) Repository {
 
 func (r reposImpl) Create(ctx context.Context, item *models.Task) error {
 	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockGroups(tx, item.GroupID); err != nil {
+			return err
+		}
+
+		last, err := lastRank(tx, item.GroupID, 0)
+		if err != nil {
+			return err
+		}
+
+		item.Order = last + rankStep
 		return tx.Create(item).Error
 	})
 }
@@ -42,18 +76,26 @@ func (r reposImpl) FindAllBy(ctx context.Context, params *GetListParams) ([]*mod
 		count int64
 	)
 
-	if params == nil {
-		params = &GetListParams{}
+	if params == nil || params.UserID == 0 {
+		return nil, 0, ErrOwnerRequired
 	}
 
-	db := r.orm.GormDB().WithContext(ctx).Model(&models.Task{})
+	db := r.orm.GormDB().WithContext(ctx).Model(&models.Task{}).
+		Scopes(pageable.Filter(&params.ListQuery, listSpec)).
+		Where("group_id IN (SELECT id FROM task_groups WHERE user_id = ?)", params.UserID)
+	if params.GroupID != 0 {
+		db = db.Where("group_id = ?", params.GroupID)
+	}
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
 
 	err := db.Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = db.Offset(params.Offset).Limit(params.Limit).Find(&items).Error
+	err = db.Scopes(pageable.Page(&params.ListQuery, listSpec)).Find(&items).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -62,8 +104,76 @@ func (r reposImpl) FindAllBy(ctx context.Context, params *GetListParams) ([]*mod
 }
 
 func (r reposImpl) Update(ctx context.Context, item *models.Task) error {
+	// a stale copy of the task must not undo a concurrent move or status change
+	return r.orm.GormDB().WithContext(ctx).
+		Model(item).
+		Select("title", "description", "priority", "due_at").
+		Updates(item).Error
+}
+
+func (r reposImpl) ChangeStatus(ctx context.Context, item *models.Task, fromStatus string) error {
+	result := r.orm.GormDB().WithContext(ctx).
+		Model(item).
+		Where("status = ?", fromStatus).
+		Select("status", "completed_at").
+		Updates(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
+	}
+
+	return nil
+}
+
+func (r reposImpl) Move(ctx context.Context, id uint64, fromGroupID uint64, pos Position) (*models.Task, error) {
+	item := new(models.Task)
+	err := r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockGroups(tx, fromGroupID, pos.GroupID); err != nil {
+			return err
+		}
+
+		if err := tx.Where("id = ? AND group_id = ?", id, fromGroupID).First(item).Error; err != nil {
+			return err
+		}
+
+		rank, err := placeRank(tx, item.ID, pos)
+		if err != nil {
+			return err
+		}
+
+		// only the placement is written, so a concurrent edit of the other fields is kept
+		item.GroupID = pos.GroupID
+		item.Order = rank
+		return tx.Model(item).Select("group_id", "order").Updates(item).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) Reorder(ctx context.Context, groupID uint64, ids []uint64) error {
 	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Save(item).Error
+		if err := lockGroups(tx, groupID); err != nil {
+			return err
+		}
+
+		var current []uint64
+		if err := tx.Model(&models.Task{}).Where("group_id = ?", groupID).Pluck("id", &current).Error; err != nil {
+			return err
+		}
+
+		sorted := slices.Clone(ids)
+		slices.Sort(sorted)
+		slices.Sort(current)
+		if !slices.Equal(sorted, current) {
+			return ErrStaleOrder
+		}
+
+		return rankInOrder(tx, ids)
 	})
 }
 
@@ -72,3 +182,131 @@ func (r reposImpl) Delete(ctx context.Context, id uint64) error {
 		return tx.Delete(&models.Task{}, id).Error
 	})
 }
+
+// lockGroups locks the task groups for the rest of the transaction, lowest id
+// first so that two moves between the same groups cannot deadlock. Every
+// change to the order of a group happens under this lock.
+func lockGroups(tx *gorm.DB, ids ...uint64) error {
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+
+	var groups []*models.TaskGroup
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&groups).Error
+	if err != nil {
+		return err
+	}
+	if len(groups) != len(ids) {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// siblings selects the tasks of a group other than exceptID.
+func siblings(tx *gorm.DB, groupID uint64, exceptID uint64) *gorm.DB {
+	return tx.Model(&models.Task{}).Where("group_id = ? AND id <> ?", groupID, exceptID)
+}
+
+func lastRank(tx *gorm.DB, groupID uint64, exceptID uint64) (int, error) {
+	var last int
+	err := siblings(tx, groupID, exceptID).
+		Select(`COALESCE(MAX("order"), 0)`).
+		Scan(&last).Error
+	return last, err
+}
+
+// placeRank returns the rank that puts task id at pos, spreading the group
+// out first when its neighbours leave no room.
+func placeRank(tx *gorm.DB, id uint64, pos Position) (int, error) {
+	if pos.BeforeID == 0 && pos.AfterID == 0 {
+		last, err := lastRank(tx, pos.GroupID, id)
+		return last + rankStep, err
+	}
+
+	lo, hi, err := neighbourRanks(tx, id, pos)
+	if err != nil {
+		return 0, err
+	}
+	if hi-lo < 2 {
+		var ids []uint64
+		err := siblings(tx, pos.GroupID, id).
+			Order(`"order" asc, id asc`).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return 0, err
+		}
+		if err := rankInOrder(tx, ids); err != nil {
+			return 0, err
+		}
+
+		if lo, hi, err = neighbourRanks(tx, id, pos); err != nil {
+			return 0, err
+		}
+	}
+
+	return lo + (hi-lo)/2, nil
+}
+
+// neighbourRanks returns the ranks of the two tasks that task id goes between.
+// At either end of the group a bound one step past the sibling is used.
+func neighbourRanks(tx *gorm.DB, id uint64, pos Position) (int, int, error) {
+	siblingID := pos.BeforeID
+	if siblingID == 0 {
+		siblingID = pos.AfterID
+	}
+	if siblingID == id {
+		return 0, 0, ErrInvalidPosition
+	}
+
+	sibling := new(models.Task)
+	if err := siblings(tx, pos.GroupID, id).Where("id = ?", siblingID).First(sibling).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, 0, ErrInvalidPosition
+		}
+		return 0, 0, err
+	}
+
+	var neighbours []*models.Task
+	if pos.BeforeID != 0 {
+		err := siblings(tx, pos.GroupID, id).
+			Where(`("order", id) < (?, ?)`, sibling.Order, sibling.ID).
+			Order(`"order" desc, id desc`).
+			Limit(1).
+			Find(&neighbours).Error
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(neighbours) == 0 {
+			return sibling.Order - 2*rankStep, sibling.Order, nil
+		}
+		return neighbours[0].Order, sibling.Order, nil
+	}
+
+	err := siblings(tx, pos.GroupID, id).
+		Where(`("order", id) > (?, ?)`, sibling.Order, sibling.ID).
+		Order(`"order" asc, id asc`).
+		Limit(1).
+		Find(&neighbours).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(neighbours) == 0 {
+		return sibling.Order, sibling.Order + 2*rankStep, nil
+	}
+	return sibling.Order, neighbours[0].Order, nil
+}
+
+// rankInOrder spreads the given tasks out evenly in the order listed.
+func rankInOrder(tx *gorm.DB, ids []uint64) error {
+	for i, id := range ids {
+		err := tx.Model(&models.Task{}).
+			Where("id = ?", id).
+			UpdateColumn("order", (i+1)*rankStep).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
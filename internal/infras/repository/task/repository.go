@@ -2,20 +2,64 @@ package task
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
 )
 
+var (
+	// ErrInvalidPosition is returned when a task is placed next to itself or
+	// next to a task that is not in the destination group.
+	ErrInvalidPosition = errors.New("task sibling is not in the destination group")
+	// ErrStaleOrder is returned when a reorder does not list exactly the
+	// tasks of the group, usually because another client changed it.
+	ErrStaleOrder = errors.New("task order does not match the group")
+	// ErrStatusChanged is returned when a task no longer has the status a
+	// status change started from.
+	ErrStatusChanged = errors.New("task status changed concurrently")
+	// ErrOwnerRequired is returned when a listing does not name the owner, so
+	// a caller that forgets to pass it cannot see every user's tasks.
+	ErrOwnerRequired = errors.New("task listing requires an owner")
+)
+
+// Position places a task in GroupID right before BeforeID or right after
+// AfterID. With neither set the task goes to the end of the group.
+type Position struct {
+	GroupID  uint64
+	BeforeID uint64
+	AfterID  uint64
+}
+
+// GetListParams filters tasks. Search matches title or description, the date
+// range applies to due_at and sortField accepts order, title, priority, dueAt,
+// createdAt, updatedAt and completedAt.
 type GetListParams struct {
-	Offset int
-	Limit  int
+	pageable.ListQuery
+	UserID  uint64 // owner of the group; required
+	GroupID uint64 // zero lists tasks of every group
+	Status  string // empty lists every status
 }
 
 // Repository defines persistence operations for Task models.
 type Repository interface {
+	// Create appends the task to the end of its group.
 	Create(ctx context.Context, item *models.Task) error
 	FindByID(ctx context.Context, id uint64) (*models.Task, error)
 	FindAllBy(ctx context.Context, params *GetListParams) ([]*models.Task, int64, error)
+	// Update writes the title, description, priority and due date of the
+	// task; the other columns have their own methods.
 	Update(ctx context.Context, item *models.Task) error
+	// ChangeStatus writes the status and completion time of the task only if
+	// it still has fromStatus; otherwise it returns ErrStatusChanged.
+	ChangeStatus(ctx context.Context, item *models.Task, fromStatus string) error
+	// Move moves a task of fromGroupID to pos. It returns
+	// gorm.ErrRecordNotFound when the task is no longer in fromGroupID and
+	// ErrInvalidPosition when the sibling is not in pos.GroupID.
+	Move(ctx context.Context, id uint64, fromGroupID uint64, pos Position) (*models.Task, error)
+	// Reorder ranks the tasks of a group in the order of ids, which must list
+	// every task of the group exactly once; otherwise ErrStaleOrder is
+	// returned.
+	Reorder(ctx context.Context, groupID uint64, ids []uint64) error
 	Delete(ctx context.Context, id uint64) error
 }
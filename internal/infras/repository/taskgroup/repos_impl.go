@@ -5,9 +5,21 @@ import (
 
 	"github.com/tdatIT/backend-go/internal/domain/models"
 	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
 	"gorm.io/gorm"
 )
 
+var listSpec = pageable.Spec{
+	SortFields: map[string]string{
+		"name":      "name",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	},
+	DefaultSort:   "id asc",
+	SearchColumns: []string{"name", "description"},
+	DateColumn:    "created_at",
+}
+
 type reposImpl struct {
 	orm orm.ORM
 }
@@ -42,18 +54,20 @@ func (r reposImpl) FindAllBy(ctx context.Context, params *GetListParams) ([]*mod
 		count int64
 	)
 
-	if params == nil {
-		params = &GetListParams{}
+	if params == nil || params.UserID == 0 {
+		return nil, 0, ErrOwnerRequired
 	}
 
-	db := r.orm.GormDB().WithContext(ctx).Model(&models.TaskGroup{})
+	db := r.orm.GormDB().WithContext(ctx).Model(&models.TaskGroup{}).
+		Scopes(pageable.Filter(&params.ListQuery, listSpec)).
+		Where("user_id = ?", params.UserID)
 
 	err := db.Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = db.Offset(params.Offset).Limit(params.Limit).Find(&items).Error
+	err = db.Scopes(pageable.Page(&params.ListQuery, listSpec)).Find(&items).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -61,14 +75,22 @@ func (r reposImpl) FindAllBy(ctx context.Context, params *GetListParams) ([]*mod
 	return items, count, nil
 }
 
-func (r reposImpl) Update(ctx context.Context, item *models.TaskGroup) error {
-	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Save(item).Error
-	})
+func (r reposImpl) Update(ctx context.Context, item *models.TaskGroup, columns ...string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return r.orm.GormDB().WithContext(ctx).
+		Model(item).
+		Select(columns).
+		Updates(item).Error
 }
 
 func (r reposImpl) Delete(ctx context.Context, id uint64) error {
 	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&models.Task{}).Error; err != nil {
+			return err
+		}
 		return tx.Delete(&models.TaskGroup{}, id).Error
 	})
 }
@@ -2,13 +2,22 @@ package taskgroup
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
 )
 
+// ErrOwnerRequired is returned when a listing does not name the owner, so a
+// caller that forgets to pass it cannot see every user's groups.
+var ErrOwnerRequired = errors.New("task group listing requires an owner")
+
+// GetListParams filters task groups. Search matches name or description, the
+// date range applies to created_at and sortField accepts name, createdAt and
+// updatedAt.
 type GetListParams struct {
-	Offset int
-	Limit  int
+	pageable.ListQuery
+	UserID uint64 // owner; required
 }
 
 // Repository defines persistence operations for TaskGroup models.
@@ -16,6 +25,9 @@ type Repository interface {
 	Create(ctx context.Context, item *models.TaskGroup) error
 	FindByID(ctx context.Context, id uint64) (*models.TaskGroup, error)
 	FindAllBy(ctx context.Context, params *GetListParams) ([]*models.TaskGroup, int64, error)
-	Update(ctx context.Context, item *models.TaskGroup) error
+	// Update writes only the given columns of item, so concurrent edits of
+	// other fields are not overwritten by a stale copy.
+	Update(ctx context.Context, item *models.TaskGroup, columns ...string) error
+	// Delete removes the group together with its tasks.
 	Delete(ctx context.Context, id uint64) error
 }
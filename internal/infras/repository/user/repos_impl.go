@@ -2,12 +2,24 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/tdatIT/backend-go/internal/domain/models"
 	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
 	"gorm.io/gorm"
 )
 
+var listSpec = pageable.Spec{
+	SortFields: map[string]string{
+		"createdAt":   "created_at",
+		"lastLoginAt": "last_login_at",
+	},
+	DefaultSort:   "id asc",
+	SearchColumns: []string{"username", "email"},
+	DateColumn:    "created_at",
+}
+
 type reposImpl struct {
 	orm orm.ORM
 }
@@ -66,7 +78,8 @@ func (r reposImpl) FindByOIDC(ctx context.Context, provider string, subject stri
 	item := new(models.User)
 	err := r.orm.GormDB().
 		WithContext(ctx).
-		Where("oidc_provider = ? AND oidc_subject = ?", provider, subject).
+		Joins("JOIN user_identities ON user_identities.user_id = users.id").
+		Where("user_identities.provider = ? AND user_identities.subject = ?", provider, subject).
 		First(item).Error
 	if err != nil {
 		return nil, err
@@ -81,14 +94,28 @@ func (r reposImpl) FindAllAndCount(ctx context.Context, params GetListParams) ([
 		count int64
 	)
 
-	db := r.orm.GormDB().WithContext(ctx).Model(&models.User{})
+	db := r.orm.GormDB().WithContext(ctx).Model(&models.User{}).
+		Scopes(pageable.Filter(&params.ListQuery, listSpec))
+	if params.IsActive != nil {
+		db = db.Where("is_active = ?", *params.IsActive)
+	}
+	switch params.Provider {
+	case "":
+	case ProviderPassword:
+		db = db.Where("password_hash <> ''")
+	default:
+		db = db.Where("EXISTS (SELECT 1 FROM user_identities WHERE user_identities.user_id = users.id AND user_identities.provider = ?)",
+			params.Provider)
+	}
 
 	err := db.Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = db.Offset(params.Offset).Limit(params.Limit).Find(&items).Error
+	err = db.Preload("Identities").
+		Scopes(pageable.Page(&params.ListQuery, listSpec)).
+		Find(&items).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -102,6 +129,54 @@ func (r reposImpl) Update(ctx context.Context, item *models.User) error {
 	})
 }
 
+func (r reposImpl) UpdateProfile(ctx context.Context, item *models.User) error {
+	return r.orm.GormDB().WithContext(ctx).
+		Model(item).
+		Select("first_name", "last_name").
+		Updates(item).Error
+}
+
+func (r reposImpl) UpdatePassword(ctx context.Context, item *models.User) error {
+	return r.orm.GormDB().WithContext(ctx).
+		Model(item).
+		Select("password_hash", "password_changed_at").
+		Updates(item).Error
+}
+
+func (r reposImpl) UpdateEmail(ctx context.Context, item *models.User) error {
+	return r.orm.GormDB().WithContext(ctx).
+		Model(item).
+		Select("email", "email_verified_at").
+		Updates(item).Error
+}
+
+func (r reposImpl) MarkEmailVerified(ctx context.Context, id uint64, email string, verifiedAt time.Time) error {
+	return r.orm.GormDB().WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ? AND email = ? AND email_verified_at IS NULL", id, email).
+		Update("email_verified_at", verifiedAt).Error
+}
+
+func (r reposImpl) UpdateStatus(ctx context.Context, item *models.User) error {
+	return r.orm.GormDB().WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", item.ID).
+		Updates(map[string]any{
+			"is_active":         item.IsActive,
+			"deleted_at":        item.DeletedAt,
+			"status_reason":     item.StatusReason,
+			"status_changed_by": item.StatusChangedBy,
+			"status_changed_at": item.StatusChangedAt,
+		}).Error
+}
+
+func (r reposImpl) UpgradePasswordHash(ctx context.Context, id uint64, oldHash string, newHash string) error {
+	return r.orm.GormDB().WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ? AND password_hash = ?", id, oldHash).
+		Update("password_hash", newHash).Error
+}
+
 func (r reposImpl) Delete(ctx context.Context, id uint64) error {
 	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return tx.Delete(&models.User{}, id).Error
@@ -2,13 +2,22 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/utils/pageable"
 )
 
+// ProviderPassword selects accounts that can sign in with a password in
+// GetListParams.Provider; any other value is matched against linked identities.
+const ProviderPassword = "password"
+
+// GetListParams filters users. Search matches username or email, the date
+// range applies to created_at and sortField accepts createdAt and lastLoginAt.
 type GetListParams struct {
-	Offset int
-	Limit  int
+	pageable.ListQuery
+	IsActive *bool
+	Provider string
 }
 
 // Repository defines persistence operations for User models.
@@ -20,5 +29,21 @@ type Repository interface {
 	FindByOIDC(ctx context.Context, provider string, subject string) (*models.User, error)
 	FindAllAndCount(ctx context.Context, params GetListParams) ([]*models.User, int64, error)
 	Update(ctx context.Context, item *models.User) error
+	// UpdateProfile writes only the name columns of item, so an edit cannot
+	// overwrite a concurrent status or password change.
+	UpdateProfile(ctx context.Context, item *models.User) error
+	// UpdatePassword writes only the password hash and its change time.
+	UpdatePassword(ctx context.Context, item *models.User) error
+	// UpdateEmail writes only the email address and its verification time.
+	UpdateEmail(ctx context.Context, item *models.User) error
+	// MarkEmailVerified sets the verification time only while the address is
+	// still email, so it cannot confirm an address changed in the meantime.
+	MarkEmailVerified(ctx context.Context, id uint64, email string, verifiedAt time.Time) error
+	// UpdateStatus writes only the active, deleted and status audit columns of
+	// item, so it cannot overwrite a concurrent profile or password change.
+	UpdateStatus(ctx context.Context, item *models.User) error
+	// UpgradePasswordHash replaces the hash only if it is still oldHash, so a
+	// rehash on login cannot overwrite a password changed in the meantime.
+	UpgradePasswordHash(ctx context.Context, id uint64, oldHash string, newHash string) error
 	Delete(ctx context.Context, id uint64) error
 }
@@ -0,0 +1,67 @@
+package usertoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/pkgs/db/orm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reposImpl struct {
+	orm orm.ORM
+}
+
+func NewRepository(orm orm.ORM) Repository {
+	return &reposImpl{
+		orm: orm,
+	}
+}
+
+func (r reposImpl) Create(ctx context.Context, item *models.UserToken) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(item).Error
+	})
+}
+
+func (r reposImpl) FindActive(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error) {
+	item := new(models.UserToken)
+	err := r.orm.GormDB().WithContext(ctx).
+		Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, tokenHash, time.Now()).
+		First(item).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) Consume(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error) {
+	item := new(models.UserToken)
+	err := r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, tokenHash, now).
+			First(item).Error; err != nil {
+			return err
+		}
+
+		item.UsedAt = &now
+		return tx.Model(item).Update("used_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r reposImpl) InvalidateByUser(ctx context.Context, userID uint64, purpose string) error {
+	return r.orm.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Model(&models.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+			Update("used_at", time.Now()).Error
+	})
+}
@@ -0,0 +1,19 @@
+package usertoken
+
+import (
+	"context"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+// Repository defines persistence operations for UserToken models.
+type Repository interface {
+	Create(ctx context.Context, item *models.UserToken) error
+	// FindActive returns an unused, unexpired token without consuming it.
+	// It returns gorm.ErrRecordNotFound when no such token exists.
+	FindActive(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error)
+	// Consume marks an unused, unexpired token as used and returns it.
+	// It returns gorm.ErrRecordNotFound when no such token exists.
+	Consume(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error)
+	InvalidateByUser(ctx context.Context, userID uint64, purpose string) error
+}
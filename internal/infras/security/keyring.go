@@ -0,0 +1,293 @@
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoSigningKey      = errors.New("no signing key available")
+	ErrNoActiveKey       = errors.New("no signing key is active yet")
+	ErrUnknownKeyID      = errors.New("unknown key id")
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+)
+
+// SigningKeyConfig describes one asymmetric key loaded from a PEM file.
+type SigningKeyConfig struct {
+	KID            string
+	Algorithm      string // RS256 | ES256 | EdDSA, inferred from the key when empty
+	PrivateKeyFile string
+	ActivateAt     time.Time // zero means active immediately
+}
+
+// SigningKey is a loaded key pair identified by its kid.
+type SigningKey struct {
+	KID        string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+	ActivateAt time.Time
+}
+
+// KeyRing holds the asymmetric signing keys. The newest key whose ActivateAt
+// has passed signs new tokens; the ones it replaced stay available for
+// verification until retiredTTL has elapsed since they were superseded.
+type KeyRing struct {
+	mu         sync.RWMutex
+	keys       []*SigningKey
+	active     *SigningKey
+	verifiable map[string]*SigningKey
+	retiredTTL time.Duration
+}
+
+func NewKeyRing(configs []SigningKeyConfig, retiredTTL time.Duration) (*KeyRing, error) {
+	keys := make([]*SigningKey, 0, len(configs))
+	for _, cfg := range configs {
+		key, err := loadSigningKey(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("load signing key %q: %w", cfg.PrivateKeyFile, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeyRingFromKeys(keys, retiredTTL)
+}
+
+func NewKeyRingFromKeys(keys []*SigningKey, retiredTTL time.Duration) (*KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoSigningKey
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key.KID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.KID)
+		}
+		seen[key.KID] = struct{}{}
+	}
+
+	sorted := append([]*SigningKey(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActivateAt.Before(sorted[j].ActivateAt)
+	})
+
+	now := time.Now()
+	if sorted[0].ActivateAt.After(now) {
+		return nil, ErrNoActiveKey
+	}
+
+	ring := &KeyRing{
+		keys:       sorted,
+		retiredTTL: retiredTTL,
+	}
+	ring.Rotate(now)
+
+	return ring, nil
+}
+
+// Rotate recomputes the active key and the verification set for the given
+// time. A key is never used before its ActivateAt; when none has been reached
+// by now the ring is left unchanged.
+func (r *KeyRing) Rotate(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	activeIdx := -1
+	for i, key := range r.keys {
+		if !key.ActivateAt.After(now) {
+			activeIdx = i
+		}
+	}
+	if activeIdx < 0 {
+		return
+	}
+
+	verifiable := make(map[string]*SigningKey, len(r.keys))
+	for i, key := range r.keys {
+		switch {
+		case i >= activeIdx:
+			// Active and upcoming keys are published ahead of use
+			verifiable[key.KID] = key
+		case now.Before(r.keys[i+1].ActivateAt.Add(r.retiredTTL)):
+			verifiable[key.KID] = key
+		}
+	}
+
+	if r.active != r.keys[activeIdx] {
+		slog.Info("jwt signing key activated", slog.String("kid", r.keys[activeIdx].KID))
+	}
+	r.active = r.keys[activeIdx]
+	r.verifiable = verifiable
+}
+
+// StartRotation re-evaluates the active key every interval until ctx is done.
+func (r *KeyRing) StartRotation(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				r.Rotate(now)
+			}
+		}
+	}()
+}
+
+func (r *KeyRing) Active() *SigningKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.active
+}
+
+func (r *KeyRing) Lookup(kid string) (*SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.verifiable[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	return key, nil
+}
+
+// JWKS returns the public keys that tokens may currently be verified with.
+func (r *KeyRing) JWKS() *JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := &JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(r.verifiable))}
+	for _, key := range r.keys {
+		if _, ok := r.verifiable[key.KID]; !ok {
+			continue
+		}
+		jwk, err := newJSONWebKey(key.KID, key.Method.Alg(), key.PublicKey)
+		if err != nil {
+			slog.Error("failed to encode public key", slog.String("kid", key.KID), slog.String("error", err.Error()))
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+// NewSigningKey builds a SigningKey from an in-memory private key.
+func NewSigningKey(kid string, algorithm string, private crypto.Signer, activateAt time.Time) (*SigningKey, error) {
+	method, err := signingMethodFor(algorithm, private)
+	if err != nil {
+		return nil, err
+	}
+
+	public := private.Public()
+	if kid == "" {
+		kid, err = keyThumbprint(public)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &SigningKey{
+		KID:        kid,
+		Method:     method,
+		PrivateKey: private,
+		PublicKey:  public,
+		ActivateAt: activateAt,
+	}, nil
+}
+
+func loadSigningKey(cfg SigningKeyConfig) (*SigningKey, error) {
+	data, err := os.ReadFile(cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	private, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSigningKey(cfg.KID, cfg.Algorithm, private, cfg.ActivateAt)
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, ErrUnsupportedKey
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, ErrInvalidPrivateKey
+}
+
+func signingMethodFor(algorithm string, private crypto.Signer) (jwt.SigningMethod, error) {
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == "" || algorithm == jwt.SigningMethodRS256.Alg() {
+			return jwt.SigningMethodRS256, nil
+		}
+	case *ecdsa.PrivateKey:
+		if key.Curve != elliptic.P256() {
+			return nil, ErrUnsupportedKey
+		}
+		if algorithm == "" || algorithm == jwt.SigningMethodES256.Alg() {
+			return jwt.SigningMethodES256, nil
+		}
+	case ed25519.PrivateKey:
+		if algorithm == "" || algorithm == jwt.SigningMethodEdDSA.Alg() {
+			return jwt.SigningMethodEdDSA, nil
+		}
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	return nil, fmt.Errorf("algorithm %q does not match key type: %w", algorithm, ErrUnsupportedKey)
+}
+
+// keyThumbprint derives a stable kid from the public key.
+func keyThumbprint(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
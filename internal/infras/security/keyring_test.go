@@ -0,0 +1,167 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKey(t *testing.T, kid string, activateAt time.Time) *SigningKey {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	key, err := NewSigningKey(kid, "ES256", private, activateAt)
+	require.NoError(t, err)
+	return key
+}
+
+func TestJWTTokenManager_SignAndVerifyWithKeyRing(t *testing.T) {
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		alg     string
+		private crypto.Signer
+	}{
+		{name: "EdDSA", alg: "EdDSA", private: edPrivate},
+		{name: "RS256", alg: "RS256", private: rsaPrivate},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := NewSigningKey("", tc.alg, tc.private, time.Time{})
+			require.NoError(t, err)
+			require.NotEmpty(t, key.KID)
+
+			ring, err := NewKeyRingFromKeys([]*SigningKey{key}, time.Hour)
+			require.NoError(t, err)
+
+			manager := NewJWTTokenManager(JWTConfig{KeyRing: ring})
+			access, refresh, _, err := manager.GenerateTokens(7, "sess-1", "refresh-1", AccessClaims{})
+			require.NoError(t, err)
+
+			claims, err := manager.VerifyToken(access)
+			require.NoError(t, err)
+			require.Equal(t, "7", claims.Subject)
+			require.Equal(t, "sess-1", claims.SessionID)
+
+			claims, err = manager.VerifyToken(refresh)
+			require.NoError(t, err)
+			require.Equal(t, "refresh-1", claims.ID)
+
+			jwks := manager.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			require.Equal(t, key.KID, jwks.Keys[0].Kid)
+			require.Equal(t, tc.alg, jwks.Keys[0].Alg)
+
+			public, err := jwks.Keys[0].PublicKey()
+			require.NoError(t, err)
+			require.NotNil(t, public)
+		})
+	}
+}
+
+func TestKeyRing_RotateKeepsRetiredKeys(t *testing.T) {
+	now := time.Now()
+	oldKey := newTestKey(t, "old", now.Add(-48*time.Hour))
+	newKey := newTestKey(t, "new", now.Add(-time.Hour))
+	nextKey := newTestKey(t, "next", now.Add(time.Hour))
+
+	ring, err := NewKeyRingFromKeys([]*SigningKey{nextKey, oldKey, newKey}, 2*time.Hour)
+	require.NoError(t, err)
+
+	require.Equal(t, "new", ring.Active().KID)
+	for _, kid := range []string{"old", "new", "next"} {
+		_, err := ring.Lookup(kid)
+		require.NoError(t, err, kid)
+	}
+
+	// Once the retired TTL has passed the old key disappears
+	ring.Rotate(now.Add(90 * time.Minute))
+	require.Equal(t, "next", ring.Active().KID)
+	_, err = ring.Lookup("old")
+	require.ErrorIs(t, err, ErrUnknownKeyID)
+	_, err = ring.Lookup("new")
+	require.NoError(t, err)
+	require.Len(t, ring.JWKS().Keys, 2)
+}
+
+func TestJWTTokenManager_VerifyRejectsUnknownKid(t *testing.T) {
+	signer, err := NewKeyRingFromKeys([]*SigningKey{newTestKey(t, "a", time.Time{})}, time.Hour)
+	require.NoError(t, err)
+	verifier, err := NewKeyRingFromKeys([]*SigningKey{newTestKey(t, "b", time.Time{})}, time.Hour)
+	require.NoError(t, err)
+
+	access, _, _, err := NewJWTTokenManager(JWTConfig{KeyRing: signer}).GenerateTokens(1, "sess-1", "jti", AccessClaims{})
+	require.NoError(t, err)
+
+	_, err = NewJWTTokenManager(JWTConfig{KeyRing: verifier}).VerifyToken(access)
+	require.Error(t, err)
+}
+
+func TestJWTTokenManager_HS256Fallback(t *testing.T) {
+	manager := NewJWTTokenManager(JWTConfig{Secret: "secret"})
+	access, _, _, err := manager.GenerateTokens(1, "sess-1", "jti", AccessClaims{})
+	require.NoError(t, err)
+
+	claims, err := manager.VerifyToken(access)
+	require.NoError(t, err)
+	require.Equal(t, "1", claims.Subject)
+	require.Empty(t, manager.JWKS().Keys)
+}
+
+func TestJWTTokenManager_TokenTypes(t *testing.T) {
+	manager := NewJWTTokenManager(JWTConfig{Secret: "secret"})
+	access, refresh, _, err := manager.GenerateTokens(1, "sess-1", "jti", AccessClaims{})
+	require.NoError(t, err)
+
+	accessClaims, err := manager.VerifyToken(access)
+	require.NoError(t, err)
+	require.Equal(t, TokenTypeAccess, accessClaims.TokenType)
+
+	refreshClaims, err := manager.VerifyToken(refresh)
+	require.NoError(t, err)
+	require.Equal(t, TokenTypeRefresh, refreshClaims.TokenType)
+}
+
+func TestKeyRing_NeverActivatesFutureKeys(t *testing.T) {
+	now := time.Now()
+
+	_, err := NewKeyRingFromKeys([]*SigningKey{newTestKey(t, "next", now.Add(time.Hour))}, time.Hour)
+	require.ErrorIs(t, err, ErrNoActiveKey)
+
+	ring, err := NewKeyRingFromKeys([]*SigningKey{
+		newTestKey(t, "current", now.Add(-time.Hour)),
+		newTestKey(t, "next", now.Add(time.Hour)),
+	}, time.Hour)
+	require.NoError(t, err)
+
+	// A clock running behind must not pick a key that is not due yet
+	ring.Rotate(now.Add(-2 * time.Hour))
+	require.Equal(t, "current", ring.Active().KID)
+}
+
+func TestJWTTokenManager_HS256WithKeyRing(t *testing.T) {
+	ring, err := NewKeyRingFromKeys([]*SigningKey{newTestKey(t, "a", time.Time{})}, time.Hour)
+	require.NoError(t, err)
+
+	legacy, _, _, err := NewJWTTokenManager(JWTConfig{Secret: "secret"}).GenerateTokens(1, "sess-1", "jti", AccessClaims{})
+	require.NoError(t, err)
+
+	_, err = NewJWTTokenManager(JWTConfig{Secret: "secret", KeyRing: ring}).VerifyToken(legacy)
+	require.ErrorIs(t, err, ErrUnexpectedSigningMethod)
+
+	claims, err := NewJWTTokenManager(JWTConfig{Secret: "secret", KeyRing: ring, AcceptHS256: true}).VerifyToken(legacy)
+	require.NoError(t, err)
+	require.Equal(t, "1", claims.Subject)
+}
@@ -1,9 +1,174 @@
 package security
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
 
 const DefaultCost = bcrypt.DefaultCost
 
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
+var (
+	ErrPasswordMismatch        = errors.New("password does not match")
+	ErrUnsupportedPasswordHash = errors.New("unsupported password hash format")
+)
+
+// Argon2Params are the argon2id cost parameters; Memory is in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follow the OWASP recommendation for argon2id.
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type PasswordHashConfig struct {
+	// Algorithm used for new hashes: argon2id (default) or bcrypt.
+	Algorithm  string
+	Argon2     Argon2Params
+	BcryptCost int
+	// MaxConcurrent caps the hashes computed at once, bounding the memory
+	// argon2id takes under a burst of logins. Defaults to the number of CPUs.
+	MaxConcurrent int
+}
+
+// PasswordHasher creates self-describing password hashes and verifies hashes
+// made by any supported algorithm, reporting those that should be upgraded.
+//
+// bcrypt hashes keep their modular crypt format ($2a$10$...), argon2id hashes
+// use the PHC string format ($argon2id$v=19$m=65536,t=3,p=2$salt$key).
+// Hash and Verify wait while MaxConcurrent other calls are running.
+type PasswordHasher struct {
+	algorithm  string
+	argon2     Argon2Params
+	bcryptCost int
+	slots      chan struct{}
+	dummyHash  func() string
+}
+
+func NewPasswordHasher(cfg PasswordHashConfig) *PasswordHasher {
+	h := &PasswordHasher{
+		algorithm:  cfg.Algorithm,
+		argon2:     cfg.Argon2,
+		bcryptCost: cfg.BcryptCost,
+	}
+	if h.algorithm != PasswordAlgorithmBcrypt {
+		h.algorithm = PasswordAlgorithmArgon2id
+	}
+	if h.argon2.Memory == 0 {
+		h.argon2.Memory = DefaultArgon2Params.Memory
+	}
+	if h.argon2.Iterations == 0 {
+		h.argon2.Iterations = DefaultArgon2Params.Iterations
+	}
+	if h.argon2.Parallelism == 0 {
+		h.argon2.Parallelism = DefaultArgon2Params.Parallelism
+	}
+	if h.argon2.SaltLength == 0 {
+		h.argon2.SaltLength = DefaultArgon2Params.SaltLength
+	}
+	if h.argon2.KeyLength == 0 {
+		h.argon2.KeyLength = DefaultArgon2Params.KeyLength
+	}
+	if h.bcryptCost <= 0 {
+		h.bcryptCost = DefaultCost
+	}
+	maxConcurrent := cfg.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = runtime.NumCPU()
+	}
+	h.slots = make(chan struct{}, maxConcurrent)
+	h.dummyHash = sync.OnceValue(func() string {
+		hash, _ := h.Hash("dummy-password")
+		return hash
+	})
+
+	return h
+}
+
+// Hash returns a new hash of password using the configured algorithm.
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	h.slots <- struct{}{}
+	defer func() { <-h.slots }()
+
+	if h.algorithm == PasswordAlgorithmBcrypt {
+		return HashPassword(password, h.bcryptCost)
+	}
+
+	salt := make([]byte, h.argon2.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	return encodeArgon2id(h.argon2, salt, argon2id(password, salt, h.argon2)), nil
+}
+
+// Verify checks password against hash. rehash is true when the password
+// matched but the hash uses another algorithm or weaker parameters than the
+// ones currently configured.
+func (h *PasswordHasher) Verify(hash string, password string) (rehash bool, err error) {
+	h.slots <- struct{}{}
+	defer func() { <-h.slots }()
+
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		if subtle.ConstantTimeCompare(key, argon2id(password, salt, params)) != 1 {
+			return false, ErrPasswordMismatch
+		}
+
+		return h.algorithm != PasswordAlgorithmArgon2id ||
+			params.Memory != h.argon2.Memory ||
+			params.Iterations != h.argon2.Iterations ||
+			params.Parallelism != h.argon2.Parallelism ||
+			params.KeyLength != h.argon2.KeyLength, nil
+	case isBcryptHash(hash):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			return false, ErrPasswordMismatch
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return false, err
+		}
+
+		return h.algorithm != PasswordAlgorithmBcrypt || cost != h.bcryptCost, nil
+	default:
+		return false, ErrUnsupportedPasswordHash
+	}
+}
+
+// CompareDummy spends the same time as Verify on a current hash so that a
+// login for an unknown account cannot be told apart by response time.
+func (h *PasswordHasher) CompareDummy(password string) {
+	_, _ = h.Verify(h.dummyHash(), password)
+}
+
+// HashPassword returns a bcrypt hash of password.
 func HashPassword(password string, cost int) (string, error) {
 	if cost <= 0 {
 		cost = DefaultCost
@@ -17,6 +182,57 @@ func HashPassword(password string, cost int) (string, error) {
 	return string(hash), nil
 }
 
+// ComparePassword checks password against a hash in any supported format.
 func ComparePassword(hash string, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	_, err := defaultPasswordHasher().Verify(hash, password)
+	return err
+}
+
+var defaultPasswordHasher = sync.OnceValue(func() *PasswordHasher {
+	return NewPasswordHasher(PasswordHashConfig{})
+})
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func argon2id(password string, salt []byte, params Argon2Params) []byte {
+	return argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+}
+
+func encodeArgon2id(params Argon2Params, salt []byte, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2id(hash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
 }
@@ -0,0 +1,149 @@
+package security
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultPasswordMinLength = 8
+	defaultPasswordMaxLength = 128
+	// identifiers shorter than this are too common to be meaningful in a password
+	minIdentifierLength = 3
+)
+
+var (
+	ErrPasswordLength         = errors.New("password length is outside the allowed range")
+	ErrPasswordCharClasses    = errors.New("password is missing a required character class")
+	ErrPasswordCommon         = errors.New("password is too common")
+	ErrPasswordContainsUserID = errors.New("password contains the username or email")
+)
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+type PasswordPolicyConfig struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// BlocklistFile adds one password per line to the built-in common password list.
+	BlocklistFile string
+}
+
+// PasswordPolicy decides whether a new password is acceptable.
+type PasswordPolicy struct {
+	cfg       PasswordPolicyConfig
+	blocklist map[string]struct{}
+}
+
+// NewPasswordPolicy builds the policy. An unreadable BlocklistFile is logged
+// and the built-in list is used on its own.
+func NewPasswordPolicy(cfg PasswordPolicyConfig) *PasswordPolicy {
+	if cfg.MinLength <= 0 {
+		cfg.MinLength = defaultPasswordMinLength
+	}
+	if cfg.MaxLength <= 0 {
+		cfg.MaxLength = defaultPasswordMaxLength
+	}
+
+	p := &PasswordPolicy{cfg: cfg, blocklist: make(map[string]struct{})}
+	// the embedded list is a string, so reading it cannot fail
+	_ = p.addBlocklist(strings.NewReader(commonPasswords))
+
+	if cfg.BlocklistFile != "" {
+		if err := p.loadBlocklistFile(cfg.BlocklistFile); err != nil {
+			slog.Error("failed to load password blocklist",
+				slog.String("file", cfg.BlocklistFile),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return p
+}
+
+func (p *PasswordPolicy) loadBlocklistFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return p.addBlocklist(file)
+}
+
+func (p *PasswordPolicy) addBlocklist(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.ToLower(strings.TrimSpace(scanner.Text())); line != "" {
+			p.blocklist[line] = struct{}{}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Validate checks password against the policy. identifiers are values the
+// password must not contain or be contained in, such as the username and email.
+func (p *PasswordPolicy) Validate(password string, identifiers ...string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength || length > p.cfg.MaxLength {
+		return ErrPasswordLength
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if (p.cfg.RequireUpper && !upper) || (p.cfg.RequireLower && !lower) ||
+		(p.cfg.RequireDigit && !digit) || (p.cfg.RequireSymbol && !symbol) {
+		return ErrPasswordCharClasses
+	}
+
+	normalized := strings.ToLower(password)
+	if _, ok := p.blocklist[normalized]; ok {
+		return ErrPasswordCommon
+	}
+
+	for _, identifier := range expandIdentifiers(identifiers) {
+		if strings.Contains(normalized, identifier) || strings.Contains(identifier, normalized) {
+			return ErrPasswordContainsUserID
+		}
+	}
+
+	return nil
+}
+
+// expandIdentifiers lowercases identifiers and adds the local part of emails.
+func expandIdentifiers(identifiers []string) []string {
+	out := make([]string, 0, len(identifiers)*2)
+	for _, identifier := range identifiers {
+		identifier = strings.ToLower(strings.TrimSpace(identifier))
+		if local, _, ok := strings.Cut(identifier, "@"); ok && len(local) >= minIdentifierLength {
+			out = append(out, local)
+		}
+		if len(identifier) >= minIdentifierLength {
+			out = append(out, identifier)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,137 @@
+package security
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var cheapArgon2 = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func TestPasswordHasher_Argon2idRoundTrip(t *testing.T) {
+	hasher := NewPasswordHasher(PasswordHashConfig{Argon2: cheapArgon2})
+
+	hash, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	rehash, err := hasher.Verify(hash, "correct horse")
+	require.NoError(t, err)
+	require.False(t, rehash)
+
+	_, err = hasher.Verify(hash, "wrong horse")
+	require.ErrorIs(t, err, ErrPasswordMismatch)
+
+	other, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other, "salt must differ per hash")
+}
+
+func TestPasswordHasher_LimitsConcurrentHashes(t *testing.T) {
+	hasher := NewPasswordHasher(PasswordHashConfig{Argon2: cheapArgon2, MaxConcurrent: 1})
+	hasher.slots <- struct{}{} // another hash is running
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = hasher.Hash("correct horse")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("hash ran while the only slot was taken")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	<-hasher.slots
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("hash did not run after the slot was released")
+	}
+}
+
+func TestPasswordHasher_RehashOnAlgorithmOrParamChange(t *testing.T) {
+	bcryptHash, err := HashPassword("correct horse", 4)
+	require.NoError(t, err)
+
+	hasher := NewPasswordHasher(PasswordHashConfig{Argon2: cheapArgon2})
+	rehash, err := hasher.Verify(bcryptHash, "correct horse")
+	require.NoError(t, err)
+	require.True(t, rehash, "bcrypt hashes are upgraded to argon2id")
+
+	weak, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+
+	stronger := NewPasswordHasher(PasswordHashConfig{Argon2: Argon2Params{Memory: 2048, Iterations: 1, Parallelism: 1}})
+	rehash, err = stronger.Verify(weak, "correct horse")
+	require.NoError(t, err)
+	require.True(t, rehash, "argon2id hashes with old parameters are upgraded")
+
+	bcryptHasher := NewPasswordHasher(PasswordHashConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: 4})
+	rehash, err = bcryptHasher.Verify(bcryptHash, "correct horse")
+	require.NoError(t, err)
+	require.False(t, rehash)
+}
+
+func TestPasswordHasher_UnsupportedFormat(t *testing.T) {
+	hasher := NewPasswordHasher(PasswordHashConfig{Argon2: cheapArgon2})
+
+	for _, hash := range []string{"", "plain", "$argon2id$v=19$m=1024$bad", "$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5"} {
+		_, err := hasher.Verify(hash, "password")
+		require.ErrorIs(t, err, ErrUnsupportedPasswordHash, hash)
+	}
+}
+
+func TestComparePassword_AcceptsBothFormats(t *testing.T) {
+	bcryptHash, err := HashPassword("correct horse", 4)
+	require.NoError(t, err)
+	require.NoError(t, ComparePassword(bcryptHash, "correct horse"))
+
+	argonHash, err := NewPasswordHasher(PasswordHashConfig{Argon2: cheapArgon2}).Hash("correct horse")
+	require.NoError(t, err)
+	require.NoError(t, ComparePassword(argonHash, "correct horse"))
+	require.Error(t, ComparePassword(argonHash, "wrong"))
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := NewPasswordPolicy(PasswordPolicyConfig{RequireDigit: true, RequireSymbol: true})
+
+	tests := []struct {
+		name     string
+		password string
+		want     error
+	}{
+		{name: "ok", password: "blue-kettle-42", want: nil},
+		{name: "too short", password: "a-1", want: ErrPasswordLength},
+		{name: "too long", password: strings.Repeat("a-1", 50), want: ErrPasswordLength},
+		{name: "missing digit", password: "blue-kettle", want: ErrPasswordCharClasses},
+		{name: "missing symbol", password: "bluekettle42", want: ErrPasswordCharClasses},
+		{name: "common", password: "P@ssw0rd1", want: ErrPasswordCommon},
+		{name: "contains username", password: "johnny-2024!", want: ErrPasswordContainsUserID},
+		{name: "contains email local part", password: "x-jdoe-99", want: ErrPasswordContainsUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, "johnny", "jdoe@example.com")
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestPasswordPolicy_BlocklistFile(t *testing.T) {
+	file := t.TempDir() + "/blocklist.txt"
+	require.NoError(t, os.WriteFile(file, []byte("Company2026!\n\n"), 0o600))
+
+	policy := NewPasswordPolicy(PasswordPolicyConfig{BlocklistFile: file})
+	require.ErrorIs(t, policy.Validate("company2026!"), ErrPasswordCommon)
+	require.NoError(t, policy.Validate("another-phrase-7"))
+}
@@ -0,0 +1,27 @@
+package security
+
+import "strings"
+
+// PersonalAccessTokenPrefix marks personal access tokens so they can be told
+// apart from JWTs and found by secret scanners.
+const PersonalAccessTokenPrefix = "pat_"
+
+// personalAccessTokenDisplayLength is how much of a token is kept in clear.
+const personalAccessTokenDisplayLength = len(PersonalAccessTokenPrefix) + 8
+
+// GeneratePersonalAccessToken returns a new token, its display prefix and the
+// hash to persist.
+func GeneratePersonalAccessToken() (token string, prefix string, hash string, err error) {
+	secret, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	token = PersonalAccessTokenPrefix + secret
+	return token, token[:personalAccessTokenDisplayLength], HashOpaqueToken(token), nil
+}
+
+// IsPersonalAccessToken reports whether token looks like a personal access token.
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
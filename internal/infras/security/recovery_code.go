@@ -0,0 +1,47 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+)
+
+const (
+	recoveryCodeBytes = 10 // 80 bits, 16 base32 characters
+	recoveryGroupSize = 4
+)
+
+var recoveryEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// GenerateRecoveryCodes returns n random codes formatted as xxxx-xxxx-xxxx-xxxx.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	buf := make([]byte, recoveryCodeBytes)
+	for range n {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+
+		raw := recoveryEncoding.EncodeToString(buf)
+		groups := make([]string, 0, len(raw)/recoveryGroupSize)
+		for i := 0; i < len(raw); i += recoveryGroupSize {
+			groups = append(groups, raw[i:i+recoveryGroupSize])
+		}
+		codes = append(codes, strings.Join(groups, "-"))
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode hashes a recovery code for lookup, ignoring case,
+// separators and surrounding whitespace so users can type it loosely.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+
+	return HashOpaqueToken(normalized)
+}
@@ -11,36 +11,65 @@ import (
 	"github.com/google/uuid"
 )
 
+// Token types carried in the typ claim, so a refresh token can never be
+// presented where an access token is expected or the other way round.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 var (
 	ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
 	ErrInvalidToken            = errors.New("invalid token")
 )
 
 type TokenManager interface {
-	GenerateTokens(subjectID uint64, sessionID string, refreshJTI string) (accessToken string, refreshToken string, accessExp time.Time, err error)
+	GenerateTokens(subjectID uint64, sessionID string, refreshJTI string, access AccessClaims) (accessToken string, refreshToken string, accessExp time.Time, err error)
 	VerifyToken(tokenString string) (*CustomClaims, error)
+	JWKS() *JSONWebKeySet
 }
 
 type JWTConfig struct {
 	Secret          string
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
+	// KeyRing enables asymmetric signing; when nil tokens are signed with Secret (HS256).
+	KeyRing *KeyRing
+	// AcceptHS256 keeps tokens signed with Secret valid alongside KeyRing while
+	// switching to asymmetric keys. It has no effect without a KeyRing.
+	AcceptHS256 bool
 }
 
 type JWTTokenManager struct {
 	secret          []byte
+	keyRing         *KeyRing
+	acceptHS256     bool
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 }
 
+// AccessClaims are the claims only carried by access tokens.
+type AccessClaims struct {
+	// Roles reflect the roles at issue time and are meant for downstream
+	// services; permission checks here reload them.
+	Roles []string
+	// ClientID and Scope are set for tokens issued to OAuth clients.
+	ClientID string
+	Scope    string
+}
+
 type CustomClaims struct {
-	SessionID string `json:"sid"`
+	TokenType string   `json:"typ"`
+	SessionID string   `json:"sid"`
+	Roles     []string `json:"roles,omitempty"`
+	ClientID  string   `json:"client_id,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func NewJWTTokenManager(cfg JWTConfig) *JWTTokenManager {
-	if cfg.Secret == "" {
-		slog.Error("JWT secret is required")
+	if cfg.Secret == "" && cfg.KeyRing == nil {
+		slog.Error("JWT secret or signing keys are required")
 		os.Exit(1)
 	}
 
@@ -56,22 +85,24 @@ func NewJWTTokenManager(cfg JWTConfig) *JWTTokenManager {
 
 	return &JWTTokenManager{
 		secret:          []byte(cfg.Secret),
+		keyRing:         cfg.KeyRing,
+		acceptHS256:     cfg.AcceptHS256,
 		accessTokenTTL:  accessTTL,
 		refreshTokenTTL: refreshTTL,
 	}
 }
 
-func (m *JWTTokenManager) GenerateTokens(subjectID uint64, sessionID string, refreshJTI string) (string, string, time.Time, error) {
+func (m *JWTTokenManager) GenerateTokens(subjectID uint64, sessionID string, refreshJTI string, access AccessClaims) (string, string, time.Time, error) {
 	now := time.Now()
 	accessExp := now.Add(m.accessTokenTTL)
 	refreshExp := now.Add(m.refreshTokenTTL)
 
-	accessToken, err := m.signToken(subjectID, sessionID, uuid.NewString(), accessExp)
+	accessToken, err := m.signToken(TokenTypeAccess, subjectID, sessionID, uuid.NewString(), access, accessExp)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
 
-	refreshToken, err := m.signToken(subjectID, sessionID, refreshJTI, refreshExp)
+	refreshToken, err := m.signToken(TokenTypeRefresh, subjectID, sessionID, refreshJTI, AccessClaims{}, refreshExp)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
@@ -79,9 +110,13 @@ func (m *JWTTokenManager) GenerateTokens(subjectID uint64, sessionID string, ref
 	return accessToken, refreshToken, accessExp, nil
 }
 
-func (m *JWTTokenManager) signToken(subjectID uint64, sessionID string, tokenID string, exp time.Time) (string, error) {
+func (m *JWTTokenManager) signToken(tokenType string, subjectID uint64, sessionID string, tokenID string, access AccessClaims, exp time.Time) (string, error) {
 	claims := CustomClaims{
+		TokenType: tokenType,
 		SessionID: sessionID,
+		Roles:     access.Roles,
+		ClientID:  access.ClientID,
+		Scope:     access.Scope,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   strconv.FormatUint(subjectID, 10),
 			ID:        tokenID,
@@ -90,18 +125,20 @@ func (m *JWTTokenManager) signToken(subjectID uint64, sessionID string, tokenID
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(m.secret)
+	if m.keyRing == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(m.secret)
+	}
+
+	key := m.keyRing.Active()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.PrivateKey)
 }
 
 func (m *JWTTokenManager) VerifyToken(tokenString string) (*CustomClaims, error) {
 	claims := new(CustomClaims)
-	parsed, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrUnexpectedSigningMethod
-		}
-		return m.secret, nil
-	})
+	parsed, err := jwt.ParseWithClaims(tokenString, claims, m.keyFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -111,3 +148,37 @@ func (m *JWTTokenManager) VerifyToken(tokenString string) (*CustomClaims, error)
 
 	return claims, nil
 }
+
+// JWKS returns the public keys for verifying tokens; it is empty in HS256 mode.
+func (m *JWTTokenManager) JWKS() *JSONWebKeySet {
+	if m.keyRing == nil {
+		return &JSONWebKeySet{Keys: []JSONWebKey{}}
+	}
+	return m.keyRing.JWKS()
+}
+
+func (m *JWTTokenManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		// Once asymmetric keys are in use HS256 is only accepted when asked
+		// for, so tokens issued before the switch can run out
+		if len(m.secret) == 0 || (m.keyRing != nil && !m.acceptHS256) {
+			return nil, ErrUnexpectedSigningMethod
+		}
+		return m.secret, nil
+	}
+
+	if m.keyRing == nil {
+		return nil, ErrUnexpectedSigningMethod
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, err := m.keyRing.Lookup(kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.Method.Alg() != token.Method.Alg() {
+		return nil, ErrUnexpectedSigningMethod
+	}
+
+	return key.PublicKey, nil
+}
@@ -0,0 +1,78 @@
+package security
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// SHA-1 test vectors from RFC 6238 Appendix B, truncated to 6 digits
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+	}
+	for _, test := range tests {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(test.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, test.expected, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := TOTPCode(secret, TOTPStep(now.Add(-30*time.Second)))
+	require.NoError(t, err)
+
+	step, ok := ValidateTOTP(secret, code, now)
+	require.True(t, ok)
+	require.Equal(t, TOTPStep(now)-1, step)
+
+	_, ok = ValidateTOTP(secret, code, now.Add(5*time.Minute))
+	require.False(t, ok)
+
+	uri := TOTPProvisioningURI("backend-go", "user@example.com", secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/backend-go:user@example.com?"))
+}
+
+func TestSecretBox_SealOpen(t *testing.T) {
+	box := NewSecretBox("passphrase")
+
+	sealed, err := box.Seal("secret")
+	require.NoError(t, err)
+
+	opened, err := box.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, "secret", opened)
+
+	_, err = NewSecretBox("other").Open(sealed)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+
+	seen := make(map[string]struct{}, len(codes))
+	for _, code := range codes {
+		require.Regexp(t, `^[a-z2-7]{4}(-[a-z2-7]{4}){3}$`, code)
+		seen[code] = struct{}{}
+	}
+	require.Len(t, seen, 10)
+
+	require.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))+" "))
+}
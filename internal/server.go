@@ -1,25 +1,52 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/tdatIT/backend-go/config"
 	"github.com/tdatIT/backend-go/internal/application/auth"
+	"github.com/tdatIT/backend-go/internal/application/task"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"github.com/tdatIT/backend-go/internal/infras/device"
+	"github.com/tdatIT/backend-go/internal/infras/mailer"
+	"github.com/tdatIT/backend-go/internal/infras/notifier"
+	"github.com/tdatIT/backend-go/internal/infras/repository/accesstoken"
+	"github.com/tdatIT/backend-go/internal/infras/repository/identity"
+	"github.com/tdatIT/backend-go/internal/infras/repository/mfa"
+	"github.com/tdatIT/backend-go/internal/infras/repository/oauth"
+	"github.com/tdatIT/backend-go/internal/infras/repository/role"
+	"github.com/tdatIT/backend-go/internal/infras/repository/securityevent"
 	"github.com/tdatIT/backend-go/internal/infras/repository/session"
+	taskRepository "github.com/tdatIT/backend-go/internal/infras/repository/task"
+	taskGroupRepository "github.com/tdatIT/backend-go/internal/infras/repository/taskgroup"
 	"github.com/tdatIT/backend-go/internal/infras/repository/user"
+	"github.com/tdatIT/backend-go/internal/infras/repository/usertoken"
 	"github.com/tdatIT/backend-go/internal/infras/security"
 	httpComponent "github.com/tdatIT/backend-go/internal/tranport/http"
+	"github.com/tdatIT/backend-go/pkgs/background"
 	"github.com/tdatIT/backend-go/pkgs/cache"
 	"github.com/tdatIT/backend-go/pkgs/db/orm"
 	"github.com/tdatIT/backend-go/pkgs/db/rdclient"
 	htlcheck "github.com/tdatIT/backend-go/pkgs/hltcheck"
 	"github.com/tdatIT/backend-go/pkgs/logger"
+	"gorm.io/gorm"
 )
 
+// rotatedTokenPruneInterval is how often rotated refresh JTIs that can no
+// longer be replayed are deleted.
+const rotatedTokenPruneInterval = time.Hour
+
 type Service struct {
-	_config *config.ServiceConfig
-	_echo   *echo.Echo
+	_config     *config.ServiceConfig
+	_echo       *echo.Echo
+	_background *background.Runner
+	_locator    device.Locator
 }
 
 func InitServer() (*Service, error) {
@@ -43,28 +70,249 @@ func InitServer() (*Service, error) {
 	//init repositories, services, handlers
 	userRepo := user.NewRepository(database)
 	sessRepo := session.NewRepository(database, cacheEngine, svcConfig.Auth.RefreshTokenTTL)
+	startRotatedTokenPruning(context.Background(), sessRepo, rotatedTokenPruneInterval)
+	securityEventRepo := securityevent.NewRepository(database)
+	userTokenRepo := usertoken.NewRepository(database)
+	mfaRepo := mfa.NewRepository(database)
+	identityRepo := identity.NewRepository(database)
+	roleRepo := role.NewRepository(database)
+	accessTokenRepo := accesstoken.NewRepository(database)
+	oauthRepo := oauth.NewRepository(database)
+	taskGroupRepo := taskGroupRepository.NewRepository(database)
+	taskRepo := taskRepository.NewRepository(database)
+
+	if err := seedRoles(context.Background(), svcConfig, roleRepo, userRepo); err != nil {
+		slog.Error("failed to seed roles", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := seedOAuthClients(context.Background(), svcConfig, oauthRepo); err != nil {
+		slog.Error("failed to seed oauth clients", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// TOTP secrets must not become readable to anyone holding the token
+	// signing secret, so they need a key of their own
+	if svcConfig.Auth.MFAEncryptionKey == "" || svcConfig.Auth.MFAEncryptionKey == svcConfig.Auth.JWTSecret {
+		err := errors.New("auth.mfaEncryptionKey must be set and differ from auth.jwtSecret")
+		slog.Error("invalid mfa configuration", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	keyRing, err := initKeyRing(svcConfig)
+	if err != nil {
+		slog.Error("failed to load jwt signing keys", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	locator, err := device.NewLocator(svcConfig.Auth.GeoIPDatabase)
+	if err != nil {
+		slog.Error("failed to open geoip database", slog.String("error", err.Error()))
+		return nil, err
+	}
 
 	tokenManager := security.NewJWTTokenManager(security.JWTConfig{
 		Secret:          svcConfig.Auth.JWTSecret,
 		AccessTokenTTL:  svcConfig.Auth.AccessTokenTTL,
 		RefreshTokenTTL: svcConfig.Auth.RefreshTokenTTL,
+		KeyRing:         keyRing,
+		AcceptHS256:     svcConfig.Auth.AcceptLegacyHS256,
 	})
 
-	authApp := auth.NewApplication(svcConfig, userRepo, sessRepo, tokenManager)
+	mailSender := mailer.NewMailer(svcConfig)
+	runner := background.NewRunner(svcConfig.Server.DefaultTimeout)
+	authApp := auth.NewApplication(
+		svcConfig,
+		userRepo,
+		sessRepo,
+		securityEventRepo,
+		userTokenRepo,
+		mfaRepo,
+		identityRepo,
+		roleRepo,
+		accessTokenRepo,
+		oauthRepo,
+		tokenManager,
+		mailSender,
+		notifier.NewNotifier(svcConfig, mailSender),
+		locator,
+		cacheEngine,
+		runner,
+	)
+	taskGroupApp := taskgroup.NewApplication(taskGroupRepo)
+	taskApp := task.NewApplication(taskGroupRepo, taskRepo)
 
 	//health service
 	healthsvc, _ := htlcheck.NewHealthCheckService(svcConfig, database, redis)
 
 	//init http server
-	echoHttp := httpComponent.InitHttpComponent(svcConfig, jsonLogHandler, authApp, healthsvc)
+	echoHttp, err := httpComponent.InitHttpComponent(svcConfig, jsonLogHandler, authApp, taskGroupApp, taskApp, healthsvc)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Service{
-		_config: svcConfig,
-		_echo:   echoHttp,
+		_config:     svcConfig,
+		_echo:       echoHttp,
+		_background: runner,
+		_locator:    locator,
 	}, nil
 }
 
+// initKeyRing loads the asymmetric signing keys and schedules their rotation.
+// It returns nil when no keys are configured so the token manager falls back to HS256.
+func initKeyRing(cfg *config.ServiceConfig) (*security.KeyRing, error) {
+	if len(cfg.Auth.SigningKeys) == 0 {
+		return nil, nil
+	}
+
+	keyConfigs := make([]security.SigningKeyConfig, 0, len(cfg.Auth.SigningKeys))
+	for _, key := range cfg.Auth.SigningKeys {
+		item := security.SigningKeyConfig{
+			KID:            key.KID,
+			Algorithm:      key.Algorithm,
+			PrivateKeyFile: key.PrivateKeyFile,
+		}
+		if key.ActivateAt != "" {
+			activateAt, err := time.Parse(time.RFC3339, key.ActivateAt)
+			if err != nil {
+				return nil, err
+			}
+			item.ActivateAt = activateAt
+		}
+		keyConfigs = append(keyConfigs, item)
+	}
+
+	retiredTTL := cfg.Auth.RetiredKeyTTL
+	if retiredTTL == 0 {
+		retiredTTL = cfg.Auth.RefreshTokenTTL
+	}
+
+	keyRing, err := security.NewKeyRing(keyConfigs, retiredTTL)
+	if err != nil {
+		return nil, err
+	}
+	keyRing.StartRotation(context.Background(), cfg.Auth.KeyRotationCheckInterval)
+
+	return keyRing, nil
+}
+
+// startRotatedTokenPruning deletes expired rotated refresh JTIs every
+// interval until ctx is done.
+func startRotatedTokenPruning(ctx context.Context, sessRepo session.Repository, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := sessRepo.PruneRotatedRefreshJTIs(ctx)
+				if err != nil {
+					slog.Error("failed to prune rotated refresh tokens", slog.String("error", err.Error()))
+					continue
+				}
+				if deleted > 0 {
+					slog.Info("pruned rotated refresh tokens", slog.Int64("deleted", deleted))
+				}
+			}
+		}
+	}()
+}
+
+// seedRoles creates the roles from config and assigns them to the listed user
+// ids. Accounts are matched by id rather than username, so nobody can claim a
+// seeded role by signing up under a name before its owner does. A missing
+// account fails startup. Assignments are never removed here; revoke them
+// through the API.
+func seedRoles(ctx context.Context, cfg *config.ServiceConfig, roleRepo role.Repository, userRepo user.Repository) error {
+	if len(cfg.Auth.Roles) == 0 {
+		return nil
+	}
+
+	seeds := make([]*models.Role, 0, len(cfg.Auth.Roles))
+	for _, item := range cfg.Auth.Roles {
+		seed := &models.Role{
+			Name:        item.Name,
+			Description: item.Description,
+			Permissions: make([]*models.Permission, 0, len(item.Permissions)),
+		}
+		for _, name := range item.Permissions {
+			seed.Permissions = append(seed.Permissions, &models.Permission{Name: name})
+		}
+		seeds = append(seeds, seed)
+	}
+
+	if err := roleRepo.Seed(ctx, seeds); err != nil {
+		return err
+	}
+
+	for _, item := range cfg.Auth.Roles {
+		if len(item.UserIDs) == 0 {
+			continue
+		}
+
+		roleItem, err := roleRepo.FindByName(ctx, item.Name)
+		if err != nil {
+			return err
+		}
+
+		for _, userID := range item.UserIDs {
+			account, err := userRepo.FindByID(ctx, userID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("role %q is seeded for unknown user %d", item.Name, userID)
+				}
+				return err
+			}
+
+			if _, err := roleRepo.Assign(ctx, &models.UserRole{UserID: account.ID, RoleID: roleItem.ID}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// seedOAuthClients creates or updates the OAuth clients from config. Clients
+// removed from config are kept so their consents and sessions stay readable.
+func seedOAuthClients(ctx context.Context, cfg *config.ServiceConfig, oauthRepo oauth.Repository) error {
+	if len(cfg.Auth.OAuthClients) == 0 {
+		return nil
+	}
+
+	seeds := make([]*models.OAuthClient, 0, len(cfg.Auth.OAuthClients))
+	for _, item := range cfg.Auth.OAuthClients {
+		seed := &models.OAuthClient{
+			ID:           item.ID,
+			Name:         item.Name,
+			RedirectURIs: item.RedirectURIs,
+			Scopes:       item.Scopes,
+			FirstParty:   item.FirstParty,
+		}
+		if item.Secret != "" {
+			seed.SecretHash = security.HashOpaqueToken(item.Secret)
+		}
+		seeds = append(seeds, seed)
+	}
+
+	return oauthRepo.SeedClients(ctx, seeds)
+}
+
 func (s *Service) StartHTTP() error {
 	slog.Info("starting HTTP server", slog.String("address", s._config.Server.HttpPort))
 	return s._echo.Start(s._config.Server.HttpPort)
 }
+
+// Shutdown waits for the work started in the background, such as outgoing
+// mail, after the HTTP server has stopped and then releases the GeoIP database.
+func (s *Service) Shutdown(ctx context.Context) error {
+	if err := s._background.Wait(ctx); err != nil {
+		return err
+	}
+
+	return s._locator.Close()
+}
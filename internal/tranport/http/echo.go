@@ -1,7 +1,9 @@
 package http
 
 import (
+	"fmt"
 	"log/slog"
+	"net"
 
 	"github.com/hellofresh/health-go/v5"
 	"github.com/labstack/echo-contrib/v5/echoprometheus"
@@ -9,8 +11,11 @@ import (
 	"github.com/labstack/echo/v5/middleware"
 	"github.com/tdatIT/backend-go/config"
 	"github.com/tdatIT/backend-go/internal/application/auth"
+	"github.com/tdatIT/backend-go/internal/application/task"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup"
 	"github.com/tdatIT/backend-go/internal/tranport/http/handler"
 	"github.com/tdatIT/backend-go/internal/tranport/http/helper"
+	httpMiddleware "github.com/tdatIT/backend-go/internal/tranport/http/middleware"
 	"github.com/tdatIT/backend-go/internal/tranport/http/router"
 	"github.com/tdatIT/backend-go/pkgs/utils/valid"
 )
@@ -26,10 +31,20 @@ func InitHttpComponent(
 	cfg *config.ServiceConfig,
 	slogHandler *slog.JSONHandler,
 	authApp *auth.Application,
+	taskGroupApp *taskgroup.Application,
+	taskApp *task.Application,
 	healthsvc *health.Health,
-) *echo.Echo {
+) (*echo.Echo, error) {
 	e := echo.New()
 
+	// Client IPs feed login throttling and session records, so forwarded
+	// headers are only honoured from the configured proxies
+	ipExtractor, err := newIPExtractor(cfg.Server.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	e.IPExtractor = ipExtractor
+
 	// Recovery middleware to recover from panics and return a 500 error
 	e.Use(middleware.Recover())
 
@@ -63,8 +78,34 @@ func InitHttpComponent(
 
 	// Register routes
 	api := e.Group("/api")
-	authHandler := handler.NewAuthHandler(authApp)
-	router.RegisterAuthRoutes(api, authHandler)
+	sessionCookies := helper.NewSessionCookies(cfg)
+	authMiddleware := httpMiddleware.NewAuthMiddleware(authApp)
+	authHandler := handler.NewAuthHandler(authApp, sessionCookies)
+	router.RegisterAuthRoutes(api, authHandler, authMiddleware.Authenticate, authMiddleware.RequireSession, authMiddleware.RequirePermission, httpMiddleware.CSRF(sessionCookies))
+	router.RegisterTaskGroupRoutes(api, handler.NewTaskGroupHandler(taskGroupApp), handler.NewTaskHandler(taskApp), authMiddleware.Authenticate, authMiddleware.RequireScope)
+	router.RegisterWellKnownRoutes(e, authHandler)
 
-	return e
+	return e, nil
+}
+
+// newIPExtractor reads the client IP from X-Forwarded-For only when the
+// request came through one of trustedProxies, and from the TCP peer otherwise.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
 }
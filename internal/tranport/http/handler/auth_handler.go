@@ -1,22 +1,28 @@
 package handler
 
 import (
+	"errors"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/labstack/echo/v5"
 	"github.com/tdatIT/backend-go/internal/application/auth"
+	authHelper "github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
 	"github.com/tdatIT/backend-go/internal/tranport/http/helper"
+	"github.com/tdatIT/backend-go/pkgs/svcerr"
 	"github.com/tdatIT/backend-go/pkgs/utils/valid"
 )
 
 type AuthHandler struct {
-	app *auth.Application
+	app     *auth.Application
+	cookies *helper.SessionCookies
 }
 
-func NewAuthHandler(app *auth.Application) *AuthHandler {
-	return &AuthHandler{app: app}
+func NewAuthHandler(app *auth.Application, cookies *helper.SessionCookies) *AuthHandler {
+	return &AuthHandler{app: app, cookies: cookies}
 }
 
 func (h *AuthHandler) LoginByUserPass(c *echo.Context) error {
@@ -31,13 +37,17 @@ func (h *AuthHandler) LoginByUserPass(c *echo.Context) error {
 		return err
 	}
 
+	// throttling keys on the client address, so never trust one from the body
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
+
 	res, err := h.app.Queries.LoginByUsernameAndPassword.Handle(c.Request().Context(), req)
 	if err != nil {
 		slog.Error("failed to login by username and password", slog.String("error", err.Error()))
 		return err
 	}
 
-	return helper.WriteSuccess(c, res)
+	return h.writeTokens(c, &res.RefreshToken, res)
 }
 
 func (h *AuthHandler) LoginByGoogle(c *echo.Context) error {
@@ -61,9 +71,46 @@ func (h *AuthHandler) LoginByGoogle(c *echo.Context) error {
 		return err
 	}
 
+	return h.writeTokens(c, &res.RefreshToken, res)
+}
+
+func (h *AuthHandler) IssueOIDCNonce(c *echo.Context) error {
+	res, err := h.app.Commands.IssueOIDCNonce.Handle(c.Request().Context(), &userdto.IssueOIDCNonceReq{})
+	if err != nil {
+		slog.Error("failed to issue oidc nonce", slog.String("error", err.Error()))
+		return err
+	}
+
 	return helper.WriteSuccess(c, res)
 }
 
+func (h *AuthHandler) LoginByOIDC(c *echo.Context) error {
+	req := new(userdto.LoginByOIDCReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	req.Provider = c.Param("provider")
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.LoginByOIDC.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to login by oidc",
+			slog.String("provider", req.Provider),
+			slog.String("error", err.Error()))
+		return err
+	}
+
+	return h.writeTokens(c, &res.RefreshToken, res)
+}
+
 func (h *AuthHandler) Register(c *echo.Context) error {
 	req := new(userdto.RegisterReq)
 	if err := c.Bind(req); err != nil {
@@ -82,60 +129,846 @@ func (h *AuthHandler) Register(c *echo.Context) error {
 		return err
 	}
 
+	return h.writeTokens(c, &res.RefreshToken, res)
+}
+
+// RefreshToken reads the refresh token from the session cookie when present,
+// otherwise from the bearer header.
+func (h *AuthHandler) RefreshToken(c *echo.Context) error {
+	token, fromCookie := h.cookies.RefreshToken(c)
+	if !fromCookie {
+		var err error
+		if token, err = helper.ExtractBearerToken(c); err != nil {
+			return err
+		}
+	}
+
+	req := &userdto.RefreshTokenReq{
+		RefreshToken: token,
+		UserAgent:    c.Request().UserAgent(),
+		IPAddress:    c.RealIP(),
+	}
+
+	res, err := h.app.Queries.RefreshToken.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to refresh token", slog.String("error", err.Error()))
+		if fromCookie && (errors.Is(err, authHelper.ErrInvalidToken) || errors.Is(err, authHelper.ErrRefreshTokenReused)) {
+			h.cookies.Clear(c)
+		}
+		return err
+	}
+
+	return h.writeTokens(c, &res.RefreshToken, res)
+}
+
+func (h *AuthHandler) Logout(c *echo.Context) error {
+	token, err := helper.ExtractBearerToken(c)
+	if err != nil {
+		return err
+	}
+
+	req := &userdto.LogoutReq{AccessToken: token}
+
+	if err := h.app.Commands.Logout.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to logout", slog.String("error", err.Error()))
+		return err
+	}
+
+	h.cookies.Clear(c)
+	return helper.WriteSuccess(c, nil)
+}
+
+// writeTokens answers a request that issued a token pair. In cookie mode the
+// refresh token is moved into the HttpOnly cookie and left out of the body.
+func (h *AuthHandler) writeTokens(c *echo.Context, refreshToken *string, res any) error {
+	if *refreshToken != "" && h.cookies.Active(c) {
+		if err := h.cookies.Set(c, *refreshToken); err != nil {
+			slog.Error("failed to set session cookies", slog.String("error", err.Error()))
+			return err
+		}
+		*refreshToken = ""
+	}
+
 	return helper.WriteSuccess(c, res)
 }
 
-func extractBearerToken(c *echo.Context) (string, error) {
-	bearer := c.Request().Header.Get("Authorization")
-	if bearer == "" {
-		slog.Warn("missing Authorization header")
-		return "", helper.ErrMissingAuthHeader
+// JWKS serves the raw key set (not wrapped in Response) as clients expect RFC 7517 JSON.
+func (h *AuthHandler) JWKS(c *echo.Context) error {
+	res, err := h.app.Queries.GetJWKS.Handle(c.Request().Context(), &userdto.GetJWKSReq{})
+	if err != nil {
+		slog.Error("failed to get jwks", slog.String("error", err.Error()))
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, res)
+}
+
+// Introspect answers RFC 7662 requests with a raw (not wrapped) JSON body.
+func (h *AuthHandler) Introspect(c *echo.Context) error {
+	req := new(userdto.IntrospectReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	// RFC 6749 2.3.1: Basic credentials are form-encoded before base64
+	if id, secret, ok := c.Request().BasicAuth(); ok {
+		req.ClientID = formUnescape(id)
+		req.ClientSecret = formUnescape(secret)
 	}
 
-	const prefix = "Bearer "
-	if !strings.HasPrefix(bearer, prefix) {
-		slog.Warn("invalid Authorization header format")
-		return "", helper.ErrInvalidAuthHeader
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
 	}
 
-	token := strings.TrimSpace(bearer[len(prefix):])
-	if token == "" {
-		slog.Warn("invalid Authorization header format")
-		return "", helper.ErrInvalidAuthHeader
+	res, err := h.app.Queries.IntrospectToken.Handle(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, authHelper.ErrInvalidClient) {
+			c.Response().Header().Set("WWW-Authenticate", `Basic realm="introspect"`)
+		}
+		return err
 	}
 
-	return token, nil
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, res)
 }
 
-func (h *AuthHandler) RefreshToken(c *echo.Context) error {
-	token, err := extractBearerToken(c)
+// ForwardAuth answers gateway auth subrequests with 200 and identity headers,
+// or 401 when the bearer token is missing or not active.
+func (h *AuthHandler) ForwardAuth(c *echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	token, err := helper.ExtractBearerToken(c)
+	if err != nil {
+		c.Response().Header().Set("WWW-Authenticate", "Bearer")
+		return helper.ErrUnauthorized
+	}
+
+	res, err := h.app.Queries.ForwardAuth.Handle(c.Request().Context(), &userdto.ForwardAuthReq{AccessToken: token})
 	if err != nil {
+		if errors.Is(err, authHelper.ErrInvalidToken) {
+			c.Response().Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
 		return err
 	}
 
-	req := &userdto.RefreshTokenReq{RefreshToken: token}
+	c.Response().Header().Set("X-User-Id", res.UserID)
+	c.Response().Header().Set("X-Session-Id", res.SessionID)
+	c.Response().Header().Set("X-User-Roles", strings.Join(res.Roles, ","))
+	return c.NoContent(http.StatusOK)
+}
 
-	res, err := h.app.Queries.RefreshToken.Handle(c.Request().Context(), req)
+func (h *AuthHandler) Me(c *echo.Context) error {
+	p, err := helper.GetPrincipal(c)
 	if err != nil {
-		slog.Error("failed to refresh token", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, &userdto.AuthInfoRes{
+		UserID:    p.UserID,
+		SessionID: p.SessionID,
+		JTI:       p.JTI,
+		Roles:     p.Roles,
+	})
+}
+
+func (h *AuthHandler) ListSessions(c *echo.Context) error {
+	res, err := h.app.Queries.ListSessions.Handle(c.Request().Context(), &userdto.ListSessionsReq{})
+	if err != nil {
+		slog.Error("failed to list sessions", slog.String("error", err.Error()))
 		return err
 	}
 
 	return helper.WriteSuccess(c, res)
 }
 
-func (h *AuthHandler) Logout(c *echo.Context) error {
-	token, err := extractBearerToken(c)
+func (h *AuthHandler) RevokeSession(c *echo.Context) error {
+	req := &userdto.RevokeSessionReq{SessionID: c.Param("id")}
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.RevokeSession.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to revoke session", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) RevokeOtherSessions(c *echo.Context) error {
+	res, err := h.app.Commands.RevokeOtherSessions.Handle(c.Request().Context(), &userdto.RevokeOtherSessionsReq{})
 	if err != nil {
+		slog.Error("failed to revoke other sessions", slog.String("error", err.Error()))
 		return err
 	}
 
-	req := &userdto.LogoutReq{AccessToken: token}
+	return helper.WriteSuccess(c, res)
+}
 
-	if err := h.app.Commands.Logout.Handle(c.Request().Context(), req); err != nil {
-		slog.Error("failed to logout", slog.String("error", err.Error()))
+func (h *AuthHandler) RequestPasswordReset(c *echo.Context) error {
+	req := new(userdto.RequestPasswordResetReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.RequestPasswordReset.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to request password reset", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ConfirmPasswordReset(c *echo.Context) error {
+	req := new(userdto.ConfirmPasswordResetReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.ConfirmPasswordReset.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to confirm password reset", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) VerifyEmail(c *echo.Context) error {
+	req := new(userdto.VerifyEmailReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.VerifyEmail.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to verify email", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ResendEmailVerification(c *echo.Context) error {
+	if err := h.app.Commands.ResendEmailVerification.Handle(c.Request().Context(), &userdto.ResendEmailVerificationReq{}); err != nil {
+		slog.Error("failed to resend email verification", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ChangeEmail(c *echo.Context) error {
+	req := new(userdto.ChangeEmailReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	req.IPAddress = c.RealIP()
+
+	if err := h.app.Commands.ChangeEmail.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to change email", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ChangePassword(c *echo.Context) error {
+	req := new(userdto.ChangePasswordReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
+
+	res, err := h.app.Commands.ChangePassword.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to change password", slog.String("error", err.Error()))
+		return err
+	}
+
+	return h.writeTokens(c, &res.RefreshToken, res)
+}
+
+func (h *AuthHandler) VerifyMFA(c *echo.Context) error {
+	req := new(userdto.VerifyMFAReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
+
+	res, err := h.app.Queries.VerifyMFA.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to verify mfa challenge", slog.String("error", err.Error()))
+		return err
+	}
+
+	return h.writeTokens(c, &res.RefreshToken, res)
+}
+
+func (h *AuthHandler) EnrollTOTP(c *echo.Context) error {
+	res, err := h.app.Commands.EnrollTOTP.Handle(c.Request().Context(), &userdto.EnrollTOTPReq{})
+	if err != nil {
+		slog.Error("failed to enroll totp", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) ConfirmTOTP(c *echo.Context) error {
+	req := new(userdto.ConfirmTOTPReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.ConfirmTOTP.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to confirm totp", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) DisableTOTP(c *echo.Context) error {
+	req := new(userdto.DisableTOTPReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.DisableTOTP.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to disable totp", slog.String("error", err.Error()))
 		return err
 	}
 
 	return helper.WriteSuccess(c, nil)
 }
+
+func (h *AuthHandler) ListIdentities(c *echo.Context) error {
+	res, err := h.app.Queries.ListIdentities.Handle(c.Request().Context(), &userdto.ListIdentitiesReq{})
+	if err != nil {
+		slog.Error("failed to list identities", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) LinkIdentity(c *echo.Context) error {
+	req := new(userdto.LinkIdentityReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	req.Provider = c.Param("provider")
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.LinkIdentity.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to link identity", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) UnlinkIdentity(c *echo.Context) error {
+	req := new(userdto.UnlinkIdentityReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request params", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.UnlinkIdentity.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to unlink identity", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ListRoles(c *echo.Context) error {
+	res, err := h.app.Queries.ListRoles.Handle(c.Request().Context(), &userdto.ListRolesReq{})
+	if err != nil {
+		slog.Error("failed to list roles", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) ListUserRoles(c *echo.Context) error {
+	req := new(userdto.ListUserRolesReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request params", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.ListUserRoles.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to list user roles", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) AssignRole(c *echo.Context) error {
+	req := new(userdto.AssignRoleReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.AssignRole.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to assign role", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) RevokeRole(c *echo.Context) error {
+	req := new(userdto.RevokeRoleReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request params", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.RevokeRole.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to revoke role", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) SuspendUser(c *echo.Context) error {
+	req := new(userdto.SuspendUserReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.SuspendUser.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to suspend user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ReactivateUser(c *echo.Context) error {
+	req := new(userdto.ReactivateUserReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.ReactivateUser.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to reactivate user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) DeleteUser(c *echo.Context) error {
+	req := new(userdto.DeleteUserReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.DeleteUser.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to delete user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ListUsers(c *echo.Context) error {
+	req := new(userdto.ListUsersReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.ListUsers.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to list users", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) GetUser(c *echo.Context) error {
+	req := new(userdto.GetUserReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.GetUser.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to get user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) UpdateUser(c *echo.Context) error {
+	req := new(userdto.UpdateUserReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.UpdateUser.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to update user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ForceLogout(c *echo.Context) error {
+	req := new(userdto.ForceLogoutReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.ForceLogout.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to force logout", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) SendPasswordReset(c *echo.Context) error {
+	req := new(userdto.SendPasswordResetReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.SendPasswordReset.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to send password reset", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *AuthHandler) ListAccessTokens(c *echo.Context) error {
+	res, err := h.app.Queries.ListAccessTokens.Handle(c.Request().Context(), &userdto.ListPersonalAccessTokensReq{})
+	if err != nil {
+		slog.Error("failed to list personal access tokens", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) CreateAccessToken(c *echo.Context) error {
+	req := new(userdto.CreatePersonalAccessTokenReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.CreateAccessToken.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to create personal access token", slog.String("error", err.Error()))
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) RevokeAccessToken(c *echo.Context) error {
+	req := new(userdto.RevokePersonalAccessTokenReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request params", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.RevokeAccessToken.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to revoke personal access token", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func formUnescape(value string) string {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// Authorize validates an OAuth authorization request and hands it to the
+// consent page. Errors about the client or redirect URI are shown here;
+// any other error is sent back to the client's redirect URI.
+func (h *AuthHandler) Authorize(c *echo.Context) error {
+	req := new(userdto.AuthorizeReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request query", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.GetAuthorization.Handle(c.Request().Context(), req)
+	if err != nil {
+		if authHelper.IsRedirectableOAuthError(err) {
+			return c.Redirect(http.StatusFound, authHelper.AuthorizeErrorRedirect(req.RedirectURI, req.State, err))
+		}
+		slog.Warn("failed to validate authorization request", slog.String("error", err.Error()))
+		return err
+	}
+
+	if res.RedirectTo != "" {
+		return c.Redirect(http.StatusFound, res.RedirectTo)
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+// DecideAuthorization is called by the consent page for the signed in user.
+func (h *AuthHandler) DecideAuthorization(c *echo.Context) error {
+	req := new(userdto.AuthorizeDecisionReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request body failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.Authorize.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to authorize oauth client", slog.String("error", err.Error()))
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return helper.WriteSuccess(c, res)
+}
+
+// OAuthToken answers RFC 6749 token requests. Both success and error bodies
+// are raw (not wrapped) JSON as the RFC requires.
+func (h *AuthHandler) OAuthToken(c *echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Pragma", "no-cache")
+
+	req := new(userdto.OAuthTokenReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return writeOAuthError(c, authHelper.ErrOAuthInvalidRequest)
+	}
+
+	// RFC 6749 2.3.1: Basic credentials are form-encoded before base64
+	if id, secret, ok := c.Request().BasicAuth(); ok {
+		req.ClientID = formUnescape(id)
+		req.ClientSecret = formUnescape(secret)
+	}
+	req.UserAgent = c.Request().UserAgent()
+	req.IPAddress = c.RealIP()
+
+	res, err := h.app.Queries.OAuthToken.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Warn("failed to issue oauth token",
+			slog.String("client_id", req.ClientID),
+			slog.String("grant_type", req.GrantType),
+			slog.String("error", err.Error()))
+		if errors.Is(err, authHelper.ErrInvalidClient) {
+			c.Response().Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		return writeOAuthError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *AuthHandler) ListConsents(c *echo.Context) error {
+	res, err := h.app.Queries.ListConsents.Handle(c.Request().Context(), &userdto.ListConsentsReq{})
+	if err != nil {
+		slog.Error("failed to list oauth consents", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *AuthHandler) RevokeConsent(c *echo.Context) error {
+	req := &userdto.RevokeConsentReq{ClientID: c.Param("client_id")}
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.RevokeConsent.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to revoke oauth consent", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func writeOAuthError(c *echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	description := "internal server error"
+	if svcErr, ok := errors.AsType[*svcerr.Error](err); ok {
+		status = svcErr.HTTPStatus
+		description = svcErr.Message
+	}
+
+	return c.JSON(status, userdto.OAuthErrorRes{
+		Error:            authHelper.OAuthErrorCode(err),
+		ErrorDescription: description,
+	})
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v5"
+	"github.com/tdatIT/backend-go/internal/application/task"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskdto"
+	"github.com/tdatIT/backend-go/internal/tranport/http/helper"
+	"github.com/tdatIT/backend-go/pkgs/utils/valid"
+)
+
+type TaskHandler struct {
+	app *task.Application
+}
+
+func NewTaskHandler(app *task.Application) *TaskHandler {
+	return &TaskHandler{app: app}
+}
+
+func (h *TaskHandler) ListTasks(c *echo.Context) error {
+	req := new(taskdto.ListTasksReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.ListTasks.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to list tasks", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskHandler) GetTask(c *echo.Context) error {
+	req := new(taskdto.GetTaskReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.GetTask.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to get task", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskHandler) CreateTask(c *echo.Context) error {
+	req := new(taskdto.CreateTaskReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.CreateTask.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to create task", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskHandler) UpdateTask(c *echo.Context) error {
+	req := new(taskdto.UpdateTaskReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.UpdateTask.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to update task", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskHandler) ChangeTaskStatus(c *echo.Context) error {
+	req := new(taskdto.ChangeTaskStatusReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.ChangeTaskStatus.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to change task status", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskHandler) DeleteTask(c *echo.Context) error {
+	req := new(taskdto.DeleteTaskReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.DeleteTask.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to delete task", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
+
+func (h *TaskHandler) MoveTask(c *echo.Context) error {
+	req := new(taskdto.MoveTaskReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.MoveTask.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to move task", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskHandler) ReorderTasks(c *echo.Context) error {
+	req := new(taskdto.ReorderTasksReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.ReorderTasks.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to reorder tasks", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
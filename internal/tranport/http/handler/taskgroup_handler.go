@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v5"
+	"github.com/tdatIT/backend-go/internal/application/taskgroup"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/taskgroupdto"
+	"github.com/tdatIT/backend-go/internal/tranport/http/helper"
+	"github.com/tdatIT/backend-go/pkgs/utils/valid"
+)
+
+type TaskGroupHandler struct {
+	app *taskgroup.Application
+}
+
+func NewTaskGroupHandler(app *taskgroup.Application) *TaskGroupHandler {
+	return &TaskGroupHandler{app: app}
+}
+
+func (h *TaskGroupHandler) ListTaskGroups(c *echo.Context) error {
+	req := new(taskgroupdto.ListTaskGroupsReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.ListTaskGroups.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to list task groups", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskGroupHandler) GetTaskGroup(c *echo.Context) error {
+	req := new(taskgroupdto.GetTaskGroupReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Queries.GetTaskGroup.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to get task group", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskGroupHandler) CreateTaskGroup(c *echo.Context) error {
+	req := new(taskgroupdto.CreateTaskGroupReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.CreateTaskGroup.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to create task group", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskGroupHandler) RenameTaskGroup(c *echo.Context) error {
+	req := new(taskgroupdto.RenameTaskGroupReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.RenameTaskGroup.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to rename task group", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskGroupHandler) UpdateTaskGroup(c *echo.Context) error {
+	req := new(taskgroupdto.UpdateTaskGroupReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	res, err := h.app.Commands.UpdateTaskGroup.Handle(c.Request().Context(), req)
+	if err != nil {
+		slog.Error("failed to update task group", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, res)
+}
+
+func (h *TaskGroupHandler) DeleteTaskGroup(c *echo.Context) error {
+	req := new(taskgroupdto.DeleteTaskGroupReq)
+	if err := c.Bind(req); err != nil {
+		slog.Error("failed to bind request body", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := valid.GetValidator().Validate(req); err != nil {
+		slog.Warn("validation request failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if err := h.app.Commands.DeleteTaskGroup.Handle(c.Request().Context(), req); err != nil {
+		slog.Error("failed to delete task group", slog.String("error", err.Error()))
+		return err
+	}
+
+	return helper.WriteSuccess(c, nil)
+}
@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+)
+
+const principalKey = "auth.principal"
+
+// ExtractBearerToken reads the token from the Authorization header.
+func ExtractBearerToken(c *echo.Context) (string, error) {
+	bearer := c.Request().Header.Get("Authorization")
+	if bearer == "" {
+		slog.Warn("missing Authorization header")
+		return "", ErrMissingAuthHeader
+	}
+
+	const prefix = "Bearer "
+	if !strings.HasPrefix(bearer, prefix) {
+		slog.Warn("invalid Authorization header format")
+		return "", ErrInvalidAuthHeader
+	}
+
+	token := strings.TrimSpace(bearer[len(prefix):])
+	if token == "" {
+		slog.Warn("invalid Authorization header format")
+		return "", ErrInvalidAuthHeader
+	}
+
+	return token, nil
+}
+
+// SetPrincipal stores the authenticated principal on both the echo context
+// and the request context so application code can read it back.
+func SetPrincipal(c *echo.Context, p *principal.Principal) {
+	c.Set(principalKey, p)
+	c.SetRequest(c.Request().WithContext(principal.NewContext(c.Request().Context(), p)))
+}
+
+// GetPrincipal returns the principal set by the auth middleware.
+func GetPrincipal(c *echo.Context) (*principal.Principal, error) {
+	if p, ok := c.Get(principalKey).(*principal.Principal); ok && p != nil {
+		return p, nil
+	}
+
+	if p, ok := principal.FromContext(c.Request().Context()); ok {
+		return p, nil
+	}
+
+	return nil, ErrUnauthorized
+}
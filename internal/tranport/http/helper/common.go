@@ -21,6 +21,13 @@ var (
 		HTTPStatus: http.StatusBadRequest,
 	}
 
+	ErrUnauthorized = &svcerr.Error{
+		Message:    "unauthorized",
+		VIMessage:  "Chưa xác thực",
+		Code:       "01",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
 	ErrRequestTimeout = &svcerr.Error{
 		Message:    "request timeout",
 		VIMessage:  "Yêu cầu đã hết thời gian chờ",
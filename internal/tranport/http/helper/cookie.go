@@ -0,0 +1,150 @@
+package helper
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/tdatIT/backend-go/config"
+	authHelper "github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+)
+
+const (
+	SessionModeHeader = "X-Session-Mode"
+	SessionModeCookie = "cookie"
+
+	// refreshCookiePath keeps the refresh cookie off every route except the
+	// auth endpoints that read it.
+	refreshCookiePath        = "/api/v1/auth"
+	defaultRefreshCookieName = "refresh_token"
+	defaultCSRFCookieName    = "csrf_token"
+	defaultCSRFHeaderName    = "X-CSRF-Token"
+	defaultCookieMaxAge      = 30 * 24 * time.Hour
+)
+
+// SessionCookies sets and reads the refresh and CSRF cookies of the browser
+// session mode.
+type SessionCookies struct {
+	enabled       bool
+	refreshCookie string
+	csrfCookie    string
+	csrfHeader    string
+	domain        string
+	sameSite      http.SameSite
+	maxAge        time.Duration
+}
+
+func NewSessionCookies(cfg *config.ServiceConfig) *SessionCookies {
+	cookieCfg := cfg.Auth.CookieSession
+	cookies := &SessionCookies{
+		enabled:       cookieCfg.Enabled,
+		refreshCookie: cookieCfg.RefreshCookieName,
+		csrfCookie:    cookieCfg.CSRFCookieName,
+		csrfHeader:    cookieCfg.CSRFHeaderName,
+		domain:        cookieCfg.Domain,
+		maxAge:        cfg.Auth.RefreshTokenTTL,
+	}
+	if cookies.refreshCookie == "" {
+		cookies.refreshCookie = defaultRefreshCookieName
+	}
+	if cookies.csrfCookie == "" {
+		cookies.csrfCookie = defaultCSRFCookieName
+	}
+	if cookies.csrfHeader == "" {
+		cookies.csrfHeader = defaultCSRFHeaderName
+	}
+	if cookies.maxAge <= 0 {
+		cookies.maxAge = defaultCookieMaxAge
+	}
+
+	switch strings.ToLower(cookieCfg.SameSite) {
+	case "lax":
+		cookies.sameSite = http.SameSiteLaxMode
+	case "none":
+		cookies.sameSite = http.SameSiteNoneMode
+	default:
+		cookies.sameSite = http.SameSiteStrictMode
+	}
+
+	return cookies
+}
+
+// Active reports whether the request uses the cookie mode, either by asking
+// for it or by already carrying a refresh cookie.
+func (s *SessionCookies) Active(c *echo.Context) bool {
+	if !s.enabled {
+		return false
+	}
+	if strings.EqualFold(c.Request().Header.Get(SessionModeHeader), SessionModeCookie) {
+		return true
+	}
+	_, ok := s.RefreshToken(c)
+	return ok
+}
+
+// RefreshToken returns the refresh token from the cookie, if any.
+func (s *SessionCookies) RefreshToken(c *echo.Context) (string, bool) {
+	if !s.enabled {
+		return "", false
+	}
+	cookie, err := c.Cookie(s.refreshCookie)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	return cookie.Value, true
+}
+
+// Set stores the refresh token and a fresh CSRF token. The CSRF cookie is
+// readable by scripts so the SPA can copy it into the CSRF header.
+func (s *SessionCookies) Set(c *echo.Context, refreshToken string) error {
+	csrfToken, _, err := security.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	maxAge := int(s.maxAge.Seconds())
+	c.SetCookie(s.cookie(s.refreshCookie, refreshToken, refreshCookiePath, true, maxAge))
+	c.SetCookie(s.cookie(s.csrfCookie, csrfToken, "/", false, maxAge))
+	return nil
+}
+
+// Clear expires both cookies.
+func (s *SessionCookies) Clear(c *echo.Context) {
+	if !s.enabled {
+		return
+	}
+	c.SetCookie(s.cookie(s.refreshCookie, "", refreshCookiePath, true, -1))
+	c.SetCookie(s.cookie(s.csrfCookie, "", "/", false, -1))
+}
+
+// VerifyCSRF checks the double-submit token: the header must equal the CSRF
+// cookie, which a cross-site page can neither read nor set.
+func (s *SessionCookies) VerifyCSRF(c *echo.Context) error {
+	cookie, err := c.Cookie(s.csrfCookie)
+	if err != nil || cookie.Value == "" {
+		return authHelper.ErrInvalidCSRFToken
+	}
+
+	header := c.Request().Header.Get(s.csrfHeader)
+	if subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+		return authHelper.ErrInvalidCSRFToken
+	}
+
+	return nil
+}
+
+func (s *SessionCookies) cookie(name string, value string, path string, httpOnly bool, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   s.domain,
+		MaxAge:   maxAge,
+		Secure:   true,
+		HttpOnly: httpOnly,
+		SameSite: s.sameSite,
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/tdatIT/backend-go/internal/application/auth"
+	authHelper "github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/domain/dtos/userdto"
+	"github.com/tdatIT/backend-go/internal/domain/principal"
+	"github.com/tdatIT/backend-go/internal/infras/security"
+	"github.com/tdatIT/backend-go/internal/tranport/http/helper"
+)
+
+type AuthMiddleware struct {
+	app *auth.Application
+}
+
+func NewAuthMiddleware(app *auth.Application) *AuthMiddleware {
+	return &AuthMiddleware{app: app}
+}
+
+// Authenticate verifies the bearer access token and its session, or a personal
+// access token, then stores the resulting principal in the request context.
+func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		token, err := helper.ExtractBearerToken(c)
+		if err != nil {
+			return err
+		}
+
+		if security.IsPersonalAccessToken(token) {
+			return m.authenticatePersonalAccessToken(c, next, token)
+		}
+
+		res, err := m.app.Queries.VerifyToken.Handle(c.Request().Context(), &userdto.VerifyTokenReq{AccessToken: token})
+		if err != nil {
+			slog.Warn("failed to authenticate request", slog.String("error", err.Error()))
+			return err
+		}
+
+		userID, err := strconv.ParseUint(res.Sub, 10, 64)
+		if err != nil {
+			slog.Warn("invalid subject in access token",
+				slog.String("sub", res.Sub),
+				slog.String("error", err.Error()))
+			return helper.ErrUnauthorized
+		}
+
+		helper.SetPrincipal(c, &principal.Principal{
+			UserID:    userID,
+			SessionID: res.SessionID,
+			JTI:       res.JTI,
+			ClientID:  res.ClientID,
+			Scopes:    strings.Fields(res.Scope),
+			Roles:     res.Roles,
+		})
+
+		return next(c)
+	}
+}
+
+func (m *AuthMiddleware) authenticatePersonalAccessToken(c *echo.Context, next echo.HandlerFunc, token string) error {
+	res, err := m.app.Queries.VerifyAccessToken.Handle(c.Request().Context(), &userdto.VerifyPersonalAccessTokenReq{Token: token})
+	if err != nil {
+		slog.Warn("failed to authenticate personal access token", slog.String("error", err.Error()))
+		return err
+	}
+
+	helper.SetPrincipal(c, &principal.Principal{
+		UserID:  res.UserID,
+		TokenID: res.TokenID,
+		Scopes:  res.Scopes,
+		Roles:   res.Roles,
+	})
+
+	return next(c)
+}
+
+// RequireSession rejects personal access tokens and OAuth client tokens on
+// account management routes, so a leaked or delegated token cannot change
+// credentials or mint further tokens. It must run after Authenticate.
+func (m *AuthMiddleware) RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		p, err := helper.GetPrincipal(c)
+		if err != nil {
+			return err
+		}
+		if p.SessionID == "" || p.ClientID != "" {
+			return authHelper.ErrSessionRequired
+		}
+
+		return next(c)
+	}
+}
+
+// RequirePermission rejects requests whose principal lacks permission. It must
+// run after Authenticate.
+func (m *AuthMiddleware) RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if err := m.app.Authorizer.Require(c.Request().Context(), permission); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireScope rejects personal access tokens and OAuth tokens that were not
+// granted scope. Session tokens pass. It must run after Authenticate.
+func (m *AuthMiddleware) RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if err := m.app.Authorizer.RequireScope(c.Request().Context(), scope); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/tdatIT/backend-go/internal/tranport/http/helper"
+)
+
+// CSRF enforces the double-submit token on unsafe requests that carry the
+// refresh cookie. Bearer-only requests are not sent automatically by the
+// browser, so they pass through unchecked.
+func CSRF(cookies *helper.SessionCookies) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+
+			if _, ok := cookies.RefreshToken(c); !ok {
+				return next(c)
+			}
+
+			if err := cookies.VerifyCSRF(c); err != nil {
+				slog.Warn("csrf check failed",
+					slog.String("method", c.Request().Method),
+					slog.String("path", c.Request().URL.Path))
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -2,17 +2,86 @@ package router
 
 import (
 	"github.com/labstack/echo/v5"
+	authHelper "github.com/tdatIT/backend-go/internal/application/auth/helper"
 	"github.com/tdatIT/backend-go/internal/tranport/http/handler"
 )
 
 func RegisterAuthRoutes(
 	router *echo.Group,
 	authHandler *handler.AuthHandler,
+	authenticate echo.MiddlewareFunc,
+	requireSession echo.MiddlewareFunc,
+	requirePermission func(permission string) echo.MiddlewareFunc,
+	csrf echo.MiddlewareFunc,
 ) {
-	auth := router.Group("/v1/auth")
+	// service-to-service endpoints never see the browser session cookie
+	service := router.Group("/v1/auth")
+	service.POST("/introspect", authHandler.Introspect)
+	// gateways may replay the original method on the auth subrequest
+	service.Any("/forward", authHandler.ForwardAuth)
+
+	auth := router.Group("/v1/auth", csrf)
 	auth.POST("/login", authHandler.LoginByUserPass)
+	auth.POST("/oidc/nonce", authHandler.IssueOIDCNonce)
 	auth.POST("/via-google", authHandler.LoginByGoogle)
+	auth.POST("/oidc/:provider", authHandler.LoginByOIDC)
 	auth.POST("/register", authHandler.Register)
 	auth.POST("/refresh", authHandler.RefreshToken)
 	auth.POST("/logout", authHandler.Logout)
+	auth.POST("/password/forgot", authHandler.RequestPasswordReset)
+	auth.POST("/password/reset", authHandler.ConfirmPasswordReset)
+	auth.POST("/email/verify", authHandler.VerifyEmail)
+	auth.POST("/mfa/verify", authHandler.VerifyMFA)
+
+	protected := auth.Group("", authenticate)
+	protected.GET("/me", authHandler.Me)
+
+	// account management is not available to personal access tokens
+	account := protected.Group("", requireSession)
+	account.GET("/sessions", authHandler.ListSessions)
+	account.POST("/sessions/revoke-others", authHandler.RevokeOtherSessions)
+	account.DELETE("/sessions/:id", authHandler.RevokeSession)
+	account.POST("/email/verify/resend", authHandler.ResendEmailVerification)
+	account.PUT("/email", authHandler.ChangeEmail)
+	account.PUT("/password", authHandler.ChangePassword)
+	account.POST("/mfa/totp/enroll", authHandler.EnrollTOTP)
+	account.POST("/mfa/totp/confirm", authHandler.ConfirmTOTP)
+	account.POST("/mfa/totp/disable", authHandler.DisableTOTP)
+	account.GET("/identities", authHandler.ListIdentities)
+	account.POST("/identities/:provider", authHandler.LinkIdentity)
+	account.DELETE("/identities/:id", authHandler.UnlinkIdentity)
+	account.GET("/tokens", authHandler.ListAccessTokens)
+	account.POST("/tokens", authHandler.CreateAccessToken)
+	account.DELETE("/tokens/:id", authHandler.RevokeAccessToken)
+	account.GET("/consents", authHandler.ListConsents)
+	account.DELETE("/consents/:client_id", authHandler.RevokeConsent)
+
+	roles := protected.Group("", requirePermission(authHelper.PermissionManageRoles))
+	roles.GET("/roles", authHandler.ListRoles)
+	roles.GET("/users/:id/roles", authHandler.ListUserRoles)
+	roles.POST("/users/:id/roles", authHandler.AssignRole)
+	roles.DELETE("/users/:id/roles/:role", authHandler.RevokeRole)
+
+	users := protected.Group("", requirePermission(authHelper.PermissionManageUsers))
+	users.GET("/users", authHandler.ListUsers)
+	users.GET("/users/:id", authHandler.GetUser)
+	users.PUT("/users/:id", authHandler.UpdateUser)
+	users.POST("/users/:id/logout", authHandler.ForceLogout)
+	users.POST("/users/:id/password-reset", authHandler.SendPasswordReset)
+	users.POST("/users/:id/suspend", authHandler.SuspendUser)
+	users.POST("/users/:id/reactivate", authHandler.ReactivateUser)
+	users.DELETE("/users/:id", authHandler.DeleteUser)
+
+	oauth := router.Group("/v1/oauth")
+	oauth.GET("/authorize", authHandler.Authorize)
+	oauth.POST("/authorize", authHandler.DecideAuthorization, authenticate, requireSession)
+	oauth.POST("/token", authHandler.OAuthToken)
+}
+
+func RegisterWellKnownRoutes(
+	router *echo.Echo,
+	authHandler *handler.AuthHandler,
+) {
+	wellKnown := router.Group("/.well-known")
+	wellKnown.GET("/jwks.json", authHandler.JWKS)
 }
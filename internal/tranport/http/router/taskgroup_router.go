@@ -0,0 +1,35 @@
+package router
+
+import (
+	"github.com/labstack/echo/v5"
+	authHelper "github.com/tdatIT/backend-go/internal/application/auth/helper"
+	"github.com/tdatIT/backend-go/internal/tranport/http/handler"
+)
+
+func RegisterTaskGroupRoutes(
+	router *echo.Group,
+	taskGroupHandler *handler.TaskGroupHandler,
+	taskHandler *handler.TaskHandler,
+	authenticate echo.MiddlewareFunc,
+	requireScope func(scope string) echo.MiddlewareFunc,
+) {
+	groups := router.Group("/v1/groups", authenticate)
+	canRead := requireScope(authHelper.ScopeTasksRead)
+	canWrite := requireScope(authHelper.ScopeTasksWrite)
+
+	groups.GET("", taskGroupHandler.ListTaskGroups, canRead)
+	groups.POST("", taskGroupHandler.CreateTaskGroup, canWrite)
+	groups.GET("/:id", taskGroupHandler.GetTaskGroup, canRead)
+	groups.PUT("/:id/name", taskGroupHandler.RenameTaskGroup, canWrite)
+	groups.PATCH("/:id", taskGroupHandler.UpdateTaskGroup, canWrite)
+	groups.DELETE("/:id", taskGroupHandler.DeleteTaskGroup, canWrite)
+
+	groups.GET("/:id/tasks", taskHandler.ListTasks, canRead)
+	groups.POST("/:id/tasks", taskHandler.CreateTask, canWrite)
+	groups.PUT("/:id/tasks/order", taskHandler.ReorderTasks, canWrite)
+	groups.GET("/:id/tasks/:taskId", taskHandler.GetTask, canRead)
+	groups.PATCH("/:id/tasks/:taskId", taskHandler.UpdateTask, canWrite)
+	groups.PUT("/:id/tasks/:taskId/status", taskHandler.ChangeTaskStatus, canWrite)
+	groups.POST("/:id/tasks/:taskId/move", taskHandler.MoveTask, canWrite)
+	groups.DELETE("/:id/tasks/:taskId", taskHandler.DeleteTask, canWrite)
+}
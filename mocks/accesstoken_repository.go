@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+type MockAccessTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccessTokenRepository) Create(ctx context.Context, item *models.PersonalAccessToken) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockAccessTokenRepository) FindByUserID(ctx context.Context, userID uint64) ([]*models.PersonalAccessToken, error) {
+	args := m.Called(ctx, userID)
+	var results []*models.PersonalAccessToken
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*models.PersonalAccessToken)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) FindActiveByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	args := m.Called(ctx, tokenHash)
+	var result *models.PersonalAccessToken
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.PersonalAccessToken)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockAccessTokenRepository) Revoke(ctx context.Context, userID uint64, id uint64) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/application/auth/helper"
+)
+
+type MockAuthorizer struct {
+	mock.Mock
+}
+
+func (m *MockAuthorizer) Grants(ctx context.Context, userID uint64) (*helper.Grants, error) {
+	args := m.Called(ctx, userID)
+	var result *helper.Grants
+	if args.Get(0) != nil {
+		result = args.Get(0).(*helper.Grants)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockAuthorizer) Require(ctx context.Context, permission string) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockAuthorizer) RequireScope(ctx context.Context, scope string) error {
+	args := m.Called(ctx, scope)
+	return args.Error(0)
+}
+
+func (m *MockAuthorizer) Invalidate(ctx context.Context, userID uint64) {
+	m.Called(ctx, userID)
+}
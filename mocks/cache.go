@@ -34,3 +34,23 @@ func (m *MockCache) Expire(ctx context.Context, key string, ttl time.Duration) e
 	args := m.Called(ctx, key, ttl)
 	return args.Error(0)
 }
+
+func (m *MockCache) SetNX(ctx context.Context, key string, val interface{}, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, key, val, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	args := m.Called(ctx, key, ttl)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCache) Decr(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCache) Exists(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
@@ -11,11 +11,11 @@ type MockGoogleOIDCVerifier struct {
 	mock.Mock
 }
 
-func (m *MockGoogleOIDCVerifier) VerifyIDToken(ctx context.Context, idToken string) (*oidc.GoogleIDTokenInfo, error) {
-	args := m.Called(ctx, idToken)
-	var result *oidc.GoogleIDTokenInfo
+func (m *MockGoogleOIDCVerifier) VerifyIDToken(ctx context.Context, idToken string, nonce string) (*oidc.IDTokenClaims, error) {
+	args := m.Called(ctx, idToken, nonce)
+	var result *oidc.IDTokenClaims
 	if args.Get(0) != nil {
-		result = args.Get(0).(*oidc.GoogleIDTokenInfo)
+		result = args.Get(0).(*oidc.IDTokenClaims)
 	}
 	return result, args.Error(1)
 }
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/infras/httpclient/oidc"
+)
+
+type MockIDTokenVerifier struct {
+	mock.Mock
+}
+
+func (m *MockIDTokenVerifier) VerifyIDToken(ctx context.Context, provider string, idToken string, nonce string) (*oidc.IDTokenClaims, error) {
+	args := m.Called(ctx, provider, idToken, nonce)
+	var result *oidc.IDTokenClaims
+	if args.Get(0) != nil {
+		result = args.Get(0).(*oidc.IDTokenClaims)
+	}
+	return result, args.Error(1)
+}
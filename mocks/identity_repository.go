@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+type MockIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepository) Create(ctx context.Context, item *models.UserIdentity) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockIdentityRepository) FindByUserID(ctx context.Context, userID uint64) ([]*models.UserIdentity, error) {
+	args := m.Called(ctx, userID)
+	var results []*models.UserIdentity
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*models.UserIdentity)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockIdentityRepository) DeleteByID(ctx context.Context, userID uint64, id uint64) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/infras/device"
+)
+
+type MockLocator struct {
+	mock.Mock
+}
+
+func (m *MockLocator) Locate(ip string) device.Location {
+	args := m.Called(ip)
+	return args.Get(0).(device.Location)
+}
+
+func (m *MockLocator) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+type MockLoginMonitor struct {
+	mock.Mock
+}
+
+func (m *MockLoginMonitor) Describe(sessionItem *models.Session) {
+	m.Called(sessionItem)
+}
+
+func (m *MockLoginMonitor) Check(ctx context.Context, account *models.User, sessionItem *models.Session) {
+	m.Called(ctx, account, sessionItem)
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) FindByUserID(ctx context.Context, userID uint64) (*models.UserMFA, error) {
+	args := m.Called(ctx, userID)
+	var result *models.UserMFA
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.UserMFA)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockMFARepository) Save(ctx context.Context, item *models.UserMFA) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) Enable(ctx context.Context, userID uint64, step int64, codeHashes []string) error {
+	args := m.Called(ctx, userID, step, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) MarkStepUsed(ctx context.Context, userID uint64, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) ConsumeRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) Delete(ctx context.Context, userID uint64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
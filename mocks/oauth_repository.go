@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+type MockOAuthRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthRepository) SeedClients(ctx context.Context, items []*models.OAuthClient) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockOAuthRepository) FindClientByID(ctx context.Context, id string) (*models.OAuthClient, error) {
+	args := m.Called(ctx, id)
+	var result *models.OAuthClient
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.OAuthClient)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockOAuthRepository) FindConsent(ctx context.Context, userID uint64, clientID string) (*models.OAuthConsent, error) {
+	args := m.Called(ctx, userID, clientID)
+	var result *models.OAuthConsent
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.OAuthConsent)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockOAuthRepository) SaveConsent(ctx context.Context, item *models.OAuthConsent) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockOAuthRepository) FindConsentsByUserID(ctx context.Context, userID uint64) ([]*models.OAuthConsent, error) {
+	args := m.Called(ctx, userID)
+	var results []*models.OAuthConsent
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*models.OAuthConsent)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockOAuthRepository) DeleteConsent(ctx context.Context, userID uint64, clientID string) error {
+	args := m.Called(ctx, userID, clientID)
+	return args.Error(0)
+}
+
+func (m *MockOAuthRepository) CreateCode(ctx context.Context, item *models.OAuthAuthorizationCode) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockOAuthRepository) ConsumeCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error) {
+	args := m.Called(ctx, codeHash)
+	var result *models.OAuthAuthorizationCode
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.OAuthAuthorizationCode)
+	}
+	return result, args.Error(1)
+}
@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) Seed(ctx context.Context, seeds []*models.Role) error {
+	args := m.Called(ctx, seeds)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) FindAll(ctx context.Context) ([]*models.Role, error) {
+	args := m.Called(ctx)
+	var results []*models.Role
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*models.Role)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockRoleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	args := m.Called(ctx, name)
+	var result *models.Role
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.Role)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockRoleRepository) FindByUserID(ctx context.Context, userID uint64) ([]*models.Role, error) {
+	args := m.Called(ctx, userID)
+	var results []*models.Role
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*models.Role)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockRoleRepository) Assign(ctx context.Context, item *models.UserRole) (bool, error) {
+	args := m.Called(ctx, item)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleRepository) Unassign(ctx context.Context, userID uint64, roleID uint64) error {
+	args := m.Called(ctx, userID, roleID)
+	return args.Error(0)
+}
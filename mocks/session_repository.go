@@ -53,7 +53,53 @@ func (m *MockSessionRepository) RotateRefreshJTI(ctx context.Context, id string,
 	return args.Error(0)
 }
 
+func (m *MockSessionRepository) IsRotatedRefreshJTI(ctx context.Context, id string, jti string) (bool, error) {
+	args := m.Called(ctx, id, jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) PruneRotatedRefreshJTIs(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockSessionRepository) Deactivate(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockSessionRepository) FindActiveByUserID(ctx context.Context, userID uint64) ([]*models.Session, error) {
+	args := m.Called(ctx, userID)
+	var results []*models.Session
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*models.Session)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockSessionRepository) FindDevicesByUserID(ctx context.Context, userID uint64, exceptID string) ([]*models.Session, error) {
+	args := m.Called(ctx, userID, exceptID)
+	var results []*models.Session
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*models.Session)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockSessionRepository) DeactivateAllByUserID(ctx context.Context, userID uint64, exceptID string) (int64, error) {
+	args := m.Called(ctx, userID, exceptID)
+	var total int64
+	if args.Get(0) != nil {
+		total = args.Get(0).(int64)
+	}
+	return total, args.Error(1)
+}
+
+func (m *MockSessionRepository) DeactivateByClient(ctx context.Context, userID uint64, clientID string) (int64, error) {
+	args := m.Called(ctx, userID, clientID)
+	var total int64
+	if args.Get(0) != nil {
+		total = args.Get(0).(int64)
+	}
+	return total, args.Error(1)
+}
@@ -44,6 +44,25 @@ func (m *MockTaskRepository) Update(ctx context.Context, item *models.Task) erro
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) ChangeStatus(ctx context.Context, item *models.Task, fromStatus string) error {
+	args := m.Called(ctx, item, fromStatus)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Move(ctx context.Context, id uint64, fromGroupID uint64, pos taskrepo.Position) (*models.Task, error) {
+	args := m.Called(ctx, id, fromGroupID, pos)
+	var result *models.Task
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.Task)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockTaskRepository) Reorder(ctx context.Context, groupID uint64, ids []uint64) error {
+	args := m.Called(ctx, groupID, ids)
+	return args.Error(0)
+}
+
 func (m *MockTaskRepository) Delete(ctx context.Context, id uint64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -39,8 +39,8 @@ func (m *MockTaskGroupRepository) FindAllBy(ctx context.Context, params *taskgro
 	return results, total, args.Error(2)
 }
 
-func (m *MockTaskGroupRepository) Update(ctx context.Context, item *models.TaskGroup) error {
-	args := m.Called(ctx, item)
+func (m *MockTaskGroupRepository) Update(ctx context.Context, item *models.TaskGroup, columns ...string) error {
+	args := m.Called(ctx, item, columns)
 	return args.Error(0)
 }
 
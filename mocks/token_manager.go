@@ -11,8 +11,8 @@ type MockTokenManager struct {
 	mock.Mock
 }
 
-func (m *MockTokenManager) GenerateTokens(subjectID uint64, sessionID string, refreshJTI string) (string, string, time.Time, error) {
-	args := m.Called(subjectID, sessionID, refreshJTI)
+func (m *MockTokenManager) GenerateTokens(subjectID uint64, sessionID string, refreshJTI string, access security.AccessClaims) (string, string, time.Time, error) {
+	args := m.Called(subjectID, sessionID, refreshJTI, access)
 	return args.String(0), args.String(1), args.Get(2).(time.Time), args.Error(3)
 }
 
@@ -24,3 +24,12 @@ func (m *MockTokenManager) VerifyToken(tokenString string) (*security.CustomClai
 	}
 	return result, args.Error(1)
 }
+
+func (m *MockTokenManager) JWKS() *security.JSONWebKeySet {
+	args := m.Called()
+	var result *security.JSONWebKeySet
+	if args.Get(0) != nil {
+		result = args.Get(0).(*security.JSONWebKeySet)
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/tdatIT/backend-go/internal/domain/models"
@@ -71,6 +72,36 @@ func (m *MockUserRepository) Update(ctx context.Context, item *models.User) erro
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, item *models.User) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, item *models.User) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateEmail(ctx context.Context, item *models.User) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id uint64, email string, verifiedAt time.Time) error {
+	args := m.Called(ctx, id, email, verifiedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateStatus(ctx context.Context, item *models.User) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpgradePasswordHash(ctx context.Context, id uint64, oldHash string, newHash string) error {
+	args := m.Called(ctx, id, oldHash, newHash)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id uint64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
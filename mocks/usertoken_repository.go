@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tdatIT/backend-go/internal/domain/models"
+)
+
+type MockUserTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserTokenRepository) Create(ctx context.Context, item *models.UserToken) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockUserTokenRepository) FindActive(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	var result *models.UserToken
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.UserToken)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockUserTokenRepository) Consume(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	var result *models.UserToken
+	if args.Get(0) != nil {
+		result = args.Get(0).(*models.UserToken)
+	}
+	return result, args.Error(1)
+}
+
+func (m *MockUserTokenRepository) InvalidateByUser(ctx context.Context, userID uint64, purpose string) error {
+	args := m.Called(ctx, userID, purpose)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const defaultTaskTimeout = 30 * time.Second
+
+// Runner runs work that must not hold up the response, such as sending mail,
+// and lets shutdown wait for it to finish.
+type Runner struct {
+	wg      sync.WaitGroup
+	timeout time.Duration
+}
+
+// NewRunner returns a Runner whose tasks are cancelled after timeout.
+func NewRunner(timeout time.Duration) *Runner {
+	if timeout <= 0 {
+		timeout = defaultTaskTimeout
+	}
+	return &Runner{timeout: timeout}
+}
+
+// Go runs fn in its own goroutine. The context passed to fn keeps the values
+// of ctx but is not cancelled when the request ends.
+func (r *Runner) Go(ctx context.Context, fn func(ctx context.Context)) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.Error("background task panicked", slog.Any("panic", rec))
+			}
+		}()
+
+		taskCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+		defer cancel()
+		fn(taskCtx)
+	}()
+}
+
+// Wait blocks until every started task has returned or ctx is done.
+func (r *Runner) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,55 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+func TestRunner_OutlivesRequestContext(t *testing.T) {
+	runner := NewRunner(time.Second)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+	var (
+		value   any
+		taskErr error
+	)
+	runner.Go(ctx, func(ctx context.Context) {
+		value = ctx.Value(ctxKey{})
+		taskErr = ctx.Err()
+	})
+	cancel()
+
+	require.NoError(t, runner.Wait(context.Background()))
+	require.Equal(t, "value", value)
+	require.NoError(t, taskErr)
+}
+
+func TestRunner_WaitHonoursDeadline(t *testing.T) {
+	runner := NewRunner(time.Second)
+
+	release := make(chan struct{})
+	runner.Go(context.Background(), func(ctx context.Context) {
+		<-release
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, runner.Wait(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, runner.Wait(context.Background()))
+}
+
+func TestRunner_RecoversPanics(t *testing.T) {
+	runner := NewRunner(time.Second)
+	runner.Go(context.Background(), func(context.Context) {
+		panic("boom")
+	})
+
+	require.NoError(t, runner.Wait(context.Background()))
+}
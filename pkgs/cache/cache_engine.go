@@ -10,4 +10,12 @@ type Cache interface {
 	Set(ctx context.Context, key string, val interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
 	Expire(ctx context.Context, key string, ttl time.Duration) error
+	// SetNX sets key only if it does not exist and reports whether it was set.
+	SetNX(ctx context.Context, key string, val interface{}, ttl time.Duration) (bool, error)
+	// Incr increments a counter and starts its ttl when the key is created.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Decr decrements an existing counter and keeps its ttl. A missing key is
+	// left alone and reported as 0.
+	Decr(ctx context.Context, key string) (int64, error)
+	Exists(ctx context.Context, key string) (bool, error)
 }
@@ -5,9 +5,29 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/tdatIT/backend-go/pkgs/db/rdclient"
 )
 
+// incrScript increments the counter and starts its ttl in one round trip, so
+// a failure between the two can never leave a counter that never expires.
+var incrScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// decrScript decrements the counter only if it still exists, so a counter
+// that expired meanwhile is not recreated without a ttl.
+var decrScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+return redis.call("DECR", KEYS[1])
+`)
+
 type redisInstance struct {
 	rdc rdclient.RedisClient
 }
@@ -44,3 +64,25 @@ func (r redisInstance) Expire(ctx context.Context, key string, ttl time.Duration
 	result := r.rdc.Client().Expire(ctx, key, ttl)
 	return result.Err()
 }
+
+func (r redisInstance) SetNX(ctx context.Context, key string, val interface{}, ttl time.Duration) (bool, error) {
+	result := r.rdc.Client().SetNX(ctx, key, val, ttl)
+	return result.Result()
+}
+
+func (r redisInstance) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrScript.Run(ctx, r.rdc.Client(), []string{key}, ttl.Milliseconds()).Int64()
+}
+
+func (r redisInstance) Decr(ctx context.Context, key string) (int64, error) {
+	return decrScript.Run(ctx, r.rdc.Client(), []string{key}).Int64()
+}
+
+func (r redisInstance) Exists(ctx context.Context, key string) (bool, error) {
+	count, err := r.rdc.Client().Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,73 @@
+package orm
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/tdatIT/backend-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// legacyIdentityConflict is a legacy identity that user_identities already
+// links to a different account.
+type legacyIdentityConflict struct {
+	UserID   uint64
+	Provider string
+	Subject  string
+	OwnerID  uint64
+}
+
+// migrateLegacyOIDCColumns moves the single identity once stored on users
+// (oidc_provider, oidc_subject) into user_identities and drops the columns.
+// The columns are dropped in the same transaction so a later unlink is not
+// undone by a re-run on the next start. When an identity is already linked
+// to another account the migration fails and keeps the columns, so the
+// conflict can be resolved by hand instead of losing the link.
+func migrateLegacyOIDCColumns(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&models.User{}, "oidc_provider") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var conflicts []legacyIdentityConflict
+		if err := tx.Raw(`
+			SELECT u.id AS user_id, u.oidc_provider AS provider, u.oidc_subject AS subject, i.user_id AS owner_id
+			FROM users u
+			JOIN user_identities i ON i.provider = u.oidc_provider AND i.subject = u.oidc_subject
+			WHERE u.oidc_provider <> '' AND u.oidc_subject <> '' AND i.user_id <> u.id`).
+			Scan(&conflicts).Error; err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			for _, item := range conflicts {
+				slog.Error("legacy oidc identity is linked to another user",
+					slog.Uint64("user_id", item.UserID),
+					slog.String("provider", item.Provider),
+					slog.String("subject", item.Subject),
+					slog.Uint64("owner_id", item.OwnerID))
+			}
+			return fmt.Errorf("%d legacy oidc identities are linked to other users", len(conflicts))
+		}
+
+		result := tx.Exec(`
+			INSERT INTO user_identities (user_id, provider, subject, email, created_at)
+			SELECT id, oidc_provider, oidc_subject, email, NOW()
+			FROM users
+			WHERE oidc_provider <> '' AND oidc_subject <> ''
+			ON CONFLICT (provider, subject) DO NOTHING`)
+		if result.Error != nil {
+			return result.Error
+		}
+		slog.Info("migrated legacy oidc identities", slog.Int64("rows", result.RowsAffected))
+
+		txMigrator := tx.Migrator()
+		for _, column := range []string{"oidc_provider", "oidc_subject"} {
+			if err := txMigrator.DropColumn(&models.User{}, column); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
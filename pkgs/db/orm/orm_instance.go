@@ -61,6 +61,19 @@ func newGormInstance(c Config) (ORM, error) {
 	err = db.AutoMigrate(
 		&models.User{},
 		&models.Session{},
+		&models.RotatedRefreshToken{},
+		&models.SecurityEvent{},
+		&models.UserToken{},
+		&models.UserIdentity{},
+		&models.Permission{},
+		&models.Role{},
+		&models.UserRole{},
+		&models.PersonalAccessToken{},
+		&models.OAuthClient{},
+		&models.OAuthConsent{},
+		&models.OAuthAuthorizationCode{},
+		&models.UserMFA{},
+		&models.MFARecoveryCode{},
 		&models.TaskGroup{},
 		&models.Task{},
 	)
@@ -69,6 +82,11 @@ func newGormInstance(c Config) (ORM, error) {
 		return nil, err
 	}
 
+	if err := migrateLegacyOIDCColumns(db); err != nil {
+		slog.Error("legacy oidc migration failed", slog.Any("err", err))
+		return nil, err
+	}
+
 	return &ormImpl{
 		db:    db,
 		sqlDB: sqlDB,
@@ -14,11 +14,11 @@ const (
 )
 
 type ListQuery struct {
-	Page          int     `query:"page" json:"page"`
-	Size          int     `query:"size" json:"size"`
+	Page          int     `query:"page" json:"page" validate:"min=0"`
+	Size          int     `query:"size" json:"size" validate:"min=0,max=100"`
 	Search        *string `query:"search"`
 	SortField     *string `query:"sortField"`
-	SortDirection *string `query:"sortDirection"`
+	SortDirection *string `query:"sortDirection" validate:"omitempty,oneof=asc desc"`
 	FromDate      *string `query:"fromDate" validate:"omitempty,datetime=2006-01-02"`
 	ToDate        *string `query:"toDate" validate:"omitempty,datetime=2006-01-02"`
 }
@@ -31,6 +31,17 @@ type ListResponse struct {
 	HasMore bool        `json:"hasMore"`
 }
 
+// NewListResponse builds the page of items that q asked for out of total rows.
+func NewListResponse(q *ListQuery, items interface{}, total int64) *ListResponse {
+	return &ListResponse{
+		Items:   items,
+		Total:   int(total),
+		Page:    q.GetPage(),
+		Size:    q.GetSize(),
+		HasMore: q.GetHasMore(int(total)),
+	}
+}
+
 // SetSize Set page size
 func (q *ListQuery) SetSize(sizeQuery string) error {
 	if sizeQuery == "" {
@@ -68,10 +79,7 @@ func (q *ListQuery) SetPage(pageQuery string) error {
 
 // GetOffset Get offset
 func (q *ListQuery) GetOffset() int {
-	if q.Page == 0 {
-		return 0
-	}
-	return (q.Page - 1) * q.Size
+	return (q.GetPage() - 1) * q.GetLimit()
 }
 
 func (q *ListQuery) GetLimit() int {
@@ -94,6 +94,18 @@ func TestGetOffset(t *testing.T) {
 			size:     10,
 			expected: 10,
 		},
+		{
+			name:     "Get Offset with Page 2 and Default Size",
+			page:     2,
+			size:     0,
+			expected: defaultSize,
+		},
+		{
+			name:     "Get Offset without Page",
+			page:     0,
+			size:     10,
+			expected: 0,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,127 @@
+package pageable
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tdatIT/backend-go/pkgs/svcerr"
+	"github.com/tdatIT/backend-go/pkgs/utils/datetime"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidSortField is added to the query when sortField is not one of the
+// entity's SortFields. It is the same business error for every list endpoint.
+var ErrInvalidSortField = &svcerr.Error{
+	Message:    "Sort field is not supported",
+	VIMessage:  "Trường sắp xếp không được hỗ trợ",
+	Code:       "PAGE-001",
+	HTTPStatus: http.StatusBadRequest,
+	GRPCCode:   codes.InvalidArgument,
+}
+
+// Spec describes how a ListQuery applies to one entity. Column names are
+// written into SQL as is, so they must never come from user input.
+type Spec struct {
+	SortFields    map[string]string // sortField value to column
+	DefaultSort   string            // order clause used when sortField is empty
+	SearchColumns []string          // columns matched case-insensitively against search
+	DateColumn    string            // timestamp filtered by fromDate/toDate
+}
+
+// Filter applies the search and date range of q. Use it before counting so the
+// total matches the filtered rows.
+func Filter(q *ListQuery, spec Spec) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if q.Search != nil {
+			db = db.Scopes(Search(*q.Search, spec.SearchColumns...))
+		}
+		if spec.DateColumn != "" {
+			db = db.Scopes(DateRange(spec.DateColumn, q.FromDate, q.ToDate))
+		}
+		return db
+	}
+}
+
+// Page applies the sort order, offset and limit of q.
+func Page(q *ListQuery, spec Spec) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(Sort(q, spec)).Offset(q.GetOffset()).Limit(q.GetLimit())
+	}
+}
+
+// Search matches term case-insensitively anywhere in any of columns. LIKE
+// wildcards in term are matched literally; a blank term matches every row.
+func Search(term string, columns ...string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		term = strings.TrimSpace(term)
+		if term == "" || len(columns) == 0 {
+			return db
+		}
+
+		pattern := "%" + escapeLike(term) + "%"
+		conditions := make([]string, 0, len(columns))
+		args := make([]any, 0, len(columns))
+		for _, column := range columns {
+			conditions = append(conditions, column+" ILIKE ?")
+			args = append(args, pattern)
+		}
+		return db.Where(strings.Join(conditions, " OR "), args...)
+	}
+}
+
+// DateRange keeps rows whose column falls between the from and to dates, both
+// inclusive. Either bound may be nil.
+func DateRange(column string, from *string, to *string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if from != nil {
+			start, err := datetime.TimeRequest(*from).ToTime()
+			if err != nil {
+				_ = db.AddError(err)
+				return db
+			}
+			db = db.Where(column+" >= ?", start)
+		}
+		if to != nil {
+			end, err := datetime.TimeRequest(*to).ToTime()
+			if err != nil {
+				_ = db.AddError(err)
+				return db
+			}
+			db = db.Where(column+" < ?", end.Add(24*time.Hour))
+		}
+		return db
+	}
+}
+
+// Sort orders by the column spec allows for sortField, descending unless
+// sortDirection is asc. Rows sharing a value are ordered by id so pages stay
+// stable. Without sortField spec.DefaultSort is used.
+func Sort(q *ListQuery, spec Spec) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if q.SortField == nil || *q.SortField == "" {
+			if spec.DefaultSort == "" {
+				return db
+			}
+			return db.Order(spec.DefaultSort)
+		}
+
+		column, ok := spec.SortFields[*q.SortField]
+		if !ok {
+			_ = db.AddError(ErrInvalidSortField)
+			return db
+		}
+
+		direction := DESCENDING
+		if q.SortDirection != nil && *q.SortDirection == ASCENDING {
+			direction = ASCENDING
+		}
+		return db.Order(column + " " + direction + " NULLS LAST, id " + direction)
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in a user-supplied search term.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,114 @@
+package pageable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type scopeRow struct {
+	ID        uint64
+	Name      string
+	CreatedAt time.Time
+}
+
+var testSpec = Spec{
+	SortFields:    map[string]string{"name": "name"},
+	DefaultSort:   "id asc",
+	SearchColumns: []string{"name", "description"},
+	DateColumn:    "created_at",
+}
+
+// dryRun returns a session that builds SQL without a database connection.
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open dry run session: %v", err)
+	}
+	return db
+}
+
+func TestFilterAndPage(t *testing.T) {
+	q := &ListQuery{
+		Page:          2,
+		Size:          10,
+		Search:        new(" 50%_off "),
+		SortField:     new("name"),
+		SortDirection: new(ASCENDING),
+		FromDate:      new("2026-01-01"),
+		ToDate:        new("2026-01-31"),
+	}
+
+	var rows []scopeRow
+	stmt := dryRun(t).Table("rows").
+		Scopes(Filter(q, testSpec), Page(q, testSpec)).
+		Find(&rows).Statement
+
+	if stmt.Error != nil {
+		t.Fatalf("unexpected error: %v", stmt.Error)
+	}
+	want := `SELECT * FROM "rows" WHERE (name ILIKE $1 OR description ILIKE $2) AND created_at >= $3 AND created_at < $4 ORDER BY name asc NULLS LAST, id asc LIMIT $5 OFFSET $6`
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("expected SQL %q, got %q", want, got)
+	}
+	if got := stmt.Vars[0]; got != `%50\%\_off%` {
+		t.Errorf("expected escaped pattern, got %v", got)
+	}
+	if got := stmt.Vars[3].(time.Time); !got.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the to date to be inclusive, got %v", got)
+	}
+}
+
+func TestPageDefaults(t *testing.T) {
+	q := &ListQuery{Search: new("  ")}
+
+	var rows []scopeRow
+	stmt := dryRun(t).Table("rows").
+		Scopes(Filter(q, testSpec), Page(q, testSpec)).
+		Find(&rows).Statement
+
+	want := `SELECT * FROM "rows" ORDER BY id asc LIMIT $1`
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("expected SQL %q, got %q", want, got)
+	}
+}
+
+func TestSortDescendingByDefault(t *testing.T) {
+	q := &ListQuery{SortField: new("name")}
+
+	var rows []scopeRow
+	stmt := dryRun(t).Table("rows").Scopes(Sort(q, testSpec)).Find(&rows).Statement
+
+	want := `SELECT * FROM "rows" ORDER BY name desc NULLS LAST, id desc`
+	if got := stmt.SQL.String(); got != want {
+		t.Errorf("expected SQL %q, got %q", want, got)
+	}
+}
+
+func TestSortRejectsUnknownField(t *testing.T) {
+	q := &ListQuery{SortField: new("password_hash")}
+
+	var rows []scopeRow
+	err := dryRun(t).Table("rows").Scopes(Page(q, testSpec)).Find(&rows).Error
+
+	if !errors.Is(err, ErrInvalidSortField) {
+		t.Errorf("expected ErrInvalidSortField, got %v", err)
+	}
+}
+
+func TestNewListResponse(t *testing.T) {
+	q := &ListQuery{Page: 2, Size: 10}
+
+	res := NewListResponse(q, []int{1}, 25)
+
+	if res.Total != 25 || res.Page != 2 || res.Size != 10 || !res.HasMore {
+		t.Errorf("unexpected response %+v", res)
+	}
+}